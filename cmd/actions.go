@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	actionsExportOutput string
+	actionsExportForce  bool
+	actionsImportFile   string
+)
+
+// actionsCmd groups action pack management commands
+var actionsCmd = &cobra.Command{
+	Use:   "actions",
+	Short: "Manage action packs",
+	Long:  `Export actions to a standalone file or import actions from one into the current config.`,
+}
+
+// actionsExportCmd exports actions to a standalone file
+var actionsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export actions to a standalone file",
+	Long: `Export the actions defined in the current config to a standalone file, as written.
+Actions imported from remote packs with $url are left out.
+
+Example:
+  gxf-discord-bot actions export --config config.yaml --output actions.yaml`,
+	RunE: runActionsExport,
+}
+
+// actionsImportCmd imports actions from a standalone file
+var actionsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import actions into the current config",
+	Long: `Merge the actions of a standalone file into the current config.
+Actions with the same name are replaced by the imported version. The rest of the
+config file, including comments, $url imports and {{profile}} variables, is kept as written.
+
+Example:
+  gxf-discord-bot actions import --config config.yaml --file actions.yaml`,
+	RunE: runActionsImport,
+}
+
+func init() {
+	rootCmd.AddCommand(actionsCmd)
+	actionsCmd.AddCommand(actionsExportCmd)
+	actionsCmd.AddCommand(actionsImportCmd)
+
+	actionsExportCmd.Flags().StringVarP(&actionsExportOutput, "output", "o", "actions.yaml", "output file path")
+	actionsExportCmd.Flags().BoolVarP(&actionsExportForce, "force", "f", false, "overwrite existing file")
+
+	actionsImportCmd.Flags().StringVar(&actionsImportFile, "file", "", "actions file to import")
+	_ = actionsImportCmd.MarkFlagRequired("file")
+	_ = actionsImportCmd.MarkFlagFilename("file", "yaml", "yml")
+}
+
+func runActionsExport(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if !actionsExportForce {
+		if _, err := os.Stat(actionsExportOutput); err == nil {
+			return fmt.Errorf("file %s already exists (use --force to overwrite)", actionsExportOutput)
+		}
+	}
+
+	exported, count, err := config.ExportActions(data)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(actionsExportOutput, exported, 0600); err != nil {
+		return fmt.Errorf("failed to write actions file: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Exported %d actions to %s\n", count, actionsExportOutput)
+	return nil
+}
+
+func runActionsImport(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	imported, err := config.LoadActions(actionsImportFile, loadOptions()...)
+	if err != nil {
+		return err
+	}
+
+	updated, err := config.ImportActions(data, imported)
+	if err != nil {
+		return err
+	}
+
+	// The file is validated as the bot loads it, with the imports and variables expanded
+	cfg, err := config.Parse(updated, loadOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration after import: %w", err)
+	}
+
+	if err := os.WriteFile(cfgFile, updated, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Imported %d actions into %s\n", len(imported), cfgFile)
+	return nil
+}
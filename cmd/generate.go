@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateOutput      string
+	generateForce       bool
+	generateInteractive bool
+)
+
+// envVarPattern matches valid environment variable names
+var envVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// sampleConfig is the configuration written by the non-interactive generate command
+const sampleConfig = `# GXF Discord Bot configuration
+bot:
+  tokenEnvVar: "DISCORD_BOT_TOKEN"
+  prefix: "!"
+  status: "Serving the community"
+  activityType: "playing"
+
+  # Compressing gateway payloads saves bandwidth at the cost of some CPU,
+  # keep it enabled unless the bot runs on a fast link with little CPU
+  gatewayCompression: true
+  # Guilds with more members than this (50-250) are sent without their
+  # offline members, lower values save bandwidth and memory in large guilds
+  largeThreshold: 250
+
+actions:
+  - name: "ping"
+    description: "Responds with pong"
+    type: "command"
+    trigger:
+      command: "ping"
+    response:
+      type: "text"
+      content: "Pong!"
+
+  - name: "help"
+    description: "Shows available commands"
+    type: "command"
+    trigger:
+      command: "help"
+    response:
+      type: "embed"
+      embed:
+        title: "Bot Commands"
+        description: "Available commands"
+        color: 3447003
+        fields:
+          - name: "!ping"
+            value: "Check bot status"
+        footer: "GXF Discord Bot"
+        timestamp: true
+
+  # Let users personalise responses, their preferences are available
+  # to templates as {{.Prefs.<key>}}
+  - name: "setpref"
+    description: "Sets a preference, e.g. !setpref language fr"
+    type: "setpref"
+    trigger:
+      command: "setpref"
+
+  - name: "getpref"
+    description: "Shows a preference, e.g. !getpref language"
+    type: "getpref"
+    trigger:
+      command: "getpref"
+
+  # Remind users by DM, e.g. !remindme 2h do laundry,
+  # pending reminders are managed with !reminders list and !reminders cancel ID
+  - name: "remindme"
+    description: "Sends a reminder after a delay"
+    type: "reminder"
+    trigger:
+      command: "remindme"
+`
+
+// generateCmd generates a configuration file
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a configuration file",
+	Long: `Generate a configuration file, either from a sample or interactively.
+
+Examples:
+  gxf-discord-bot generate --output config.yaml
+  gxf-discord-bot generate --interactive`,
+	RunE: runGenerate,
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+	generateCmd.Flags().StringVarP(&generateOutput, "output", "o", "config.yaml", "output file path")
+	generateCmd.Flags().BoolVarP(&generateForce, "force", "f", false, "overwrite existing file")
+	generateCmd.Flags().BoolVarP(&generateInteractive, "interactive", "i", false, "prompt for configuration values")
+}
+
+func runGenerate(cmd *cobra.Command, args []string) error {
+	if !generateForce {
+		if _, err := os.Stat(generateOutput); err == nil {
+			return fmt.Errorf("file %s already exists (use --force to overwrite)", generateOutput)
+		}
+	}
+
+	data := []byte(sampleConfig)
+	if generateInteractive {
+		cfg, err := promptConfig(cmd.InOrStdin(), cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+
+		data, err = config.Marshal(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(generateOutput, data, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Configuration written to %s\n", generateOutput)
+	return nil
+}
+
+// prompter reads validated answers from an input stream
+type prompter struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+// ask prompts until the answer passes validation, using the default for empty answers
+func (p *prompter) ask(question, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+
+		if !p.scanner.Scan() {
+			if err := p.scanner.Err(); err != nil {
+				return "", fmt.Errorf("failed to read input: %w", err)
+			}
+			return "", fmt.Errorf("unexpected end of input")
+		}
+
+		answer := strings.TrimSpace(p.scanner.Text())
+		if answer == "" {
+			answer = defaultValue
+		}
+
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(p.out, "  invalid value: %v\n", err)
+			continue
+		}
+
+		return answer, nil
+	}
+}
+
+// confirm asks a yes/no question
+func (p *prompter) confirm(question string, defaultYes bool) (bool, error) {
+	defaultValue := "n"
+	if defaultYes {
+		defaultValue = "y"
+	}
+
+	answer, err := p.ask(question+" (y/n)", defaultValue, func(s string) error {
+		switch strings.ToLower(s) {
+		case "y", "yes", "n", "no":
+			return nil
+		default:
+			return fmt.Errorf("answer y or n")
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return strings.HasPrefix(strings.ToLower(answer), "y"), nil
+}
+
+// promptConfig interactively builds a configuration
+func promptConfig(in io.Reader, out io.Writer) (*config.Config, error) {
+	p := &prompter{scanner: bufio.NewScanner(in), out: out}
+	cfg := &config.Config{}
+
+	fmt.Fprintln(out, "GXF Discord Bot configuration wizard")
+
+	var err error
+	cfg.Bot.TokenEnvVar, err = p.ask("Bot token environment variable", "DISCORD_BOT_TOKEN", func(s string) error {
+		if !envVarPattern.MatchString(s) {
+			return fmt.Errorf("must be a valid environment variable name")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Bot.Prefix, err = p.ask("Command prefix", "!", func(s string) error {
+		if s == "" {
+			return fmt.Errorf("bot prefix is required")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		action, err := promptCommandAction(p, cfg)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Actions = append(cfg.Actions, action)
+
+		more, err := p.confirm("Add another command action?", false)
+		if err != nil {
+			return nil, err
+		}
+		if !more {
+			break
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// promptCommandAction prompts for a single command action
+func promptCommandAction(p *prompter, cfg *config.Config) (config.ActionConfig, error) {
+	action := config.ActionConfig{
+		Type:     "command",
+		Response: config.ResponseConfig{Type: "text"},
+	}
+
+	var err error
+	action.Name, err = p.ask("Action name", "", func(s string) error {
+		if s == "" {
+			return fmt.Errorf("action name is required")
+		}
+		for _, existing := range cfg.Actions {
+			if existing.Name == s {
+				return fmt.Errorf("duplicate action name: %q", s)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return action, err
+	}
+
+	action.Trigger.Command, err = p.ask("Command (without prefix)", action.Name, func(s string) error {
+		if s == "" || strings.ContainsAny(s, " \t") {
+			return fmt.Errorf("command must be a single word")
+		}
+		return nil
+	})
+	if err != nil {
+		return action, err
+	}
+
+	action.Response.Content, err = p.ask("Response text", "", func(s string) error {
+		if s == "" {
+			return fmt.Errorf("text response requires non-empty content")
+		}
+		return nil
+	})
+	if err != nil {
+		return action, err
+	}
+
+	return action, nil
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listFormat string
+	listFilter string
+)
+
+// listCmd lists configured actions without starting the bot
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured actions",
+	Long: `List all actions defined in the configuration file without starting the bot.
+
+Examples:
+  gxf-discord-bot list --config config.yaml
+  gxf-discord-bot list --format json
+  gxf-discord-bot list --filter type=command`,
+	RunE: runList,
+}
+
+// actionSummary is a flattened view of an action for listing
+type actionSummary struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Trigger     string `json:"trigger"`
+	Response    string `json:"response"`
+	RateLimit   string `json:"rateLimit,omitempty"`
+	RequireAuth bool   `json:"requireAuth"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "output format (table, json)")
+	listCmd.Flags().StringVar(&listFilter, "filter", "", "filter actions by field (type=<type> or name=<name>)")
+	_ = listCmd.RegisterFlagCompletionFunc("filter", completeActionFilter)
+}
+
+// completeActionFilter suggests filter values from the actions in the config file
+func completeActionFilter(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.Load(cfgFile, loadOptions()...)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	seen := make(map[string]bool)
+	suggestions := make([]string, 0, len(cfg.Actions)*2)
+	for _, action := range cfg.Actions {
+		for _, suggestion := range []string{"type=" + action.Type, "name=" + action.Name} {
+			if !seen[suggestion] && strings.HasPrefix(suggestion, toComplete) {
+				seen[suggestion] = true
+				suggestions = append(suggestions, suggestion)
+			}
+		}
+	}
+
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(cfgFile, loadOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	actions, err := filterActions(cfg.Actions, listFilter)
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]actionSummary, 0, len(actions))
+	for _, action := range actions {
+		summaries = append(summaries, summarizeAction(cfg.Bot.Prefix, action))
+	}
+
+	switch listFormat {
+	case "table":
+		return printActionTable(cmd.OutOrStdout(), summaries)
+	case "json":
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summaries)
+	default:
+		return fmt.Errorf("unsupported format: %s (must be table or json)", listFormat)
+	}
+}
+
+// filterActions filters actions using a key=value expression
+func filterActions(actions []config.ActionConfig, filter string) ([]config.ActionConfig, error) {
+	if filter == "" {
+		return actions, nil
+	}
+
+	key, value, ok := strings.Cut(filter, "=")
+	if !ok || value == "" {
+		return nil, fmt.Errorf("invalid filter %q (expected key=value)", filter)
+	}
+
+	filtered := make([]config.ActionConfig, 0, len(actions))
+	for _, action := range actions {
+		var field string
+		switch key {
+		case "type":
+			field = action.Type
+		case "name":
+			field = action.Name
+		default:
+			return nil, fmt.Errorf("unsupported filter key: %s (must be type or name)", key)
+		}
+
+		if field == value {
+			filtered = append(filtered, action)
+		}
+	}
+
+	return filtered, nil
+}
+
+// summarizeAction builds a one-line summary of an action
+func summarizeAction(prefix string, action config.ActionConfig) actionSummary {
+	return actionSummary{
+		Name:        action.Name,
+		Type:        action.Type,
+		Trigger:     summarizeTrigger(prefix, action),
+		Response:    action.Response.Type,
+		RateLimit:   summarizeRateLimit(action.RateLimit),
+		RequireAuth: action.RequireAuth,
+		Enabled:     action.IsEnabled(),
+	}
+}
+
+// summarizeTrigger describes what triggers an action
+func summarizeTrigger(prefix string, action config.ActionConfig) string {
+	switch action.Type {
+	case "command":
+		return prefix + action.Trigger.Command
+	case "message":
+		return "/" + action.Trigger.Pattern + "/"
+	case "reaction", "reaction_role":
+		return action.Trigger.Emoji
+	case "scheduled", "status_cycle":
+		return action.Trigger.Schedule
+	default:
+		return ""
+	}
+}
+
+// summarizeRateLimit describes an action rate limit
+func summarizeRateLimit(rl *config.RateLimitConfig) string {
+	if rl == nil {
+		return ""
+	}
+
+	scope := rl.Scope
+	if scope == "" {
+		scope = "user"
+	}
+
+	return fmt.Sprintf("%d/%ds per %s", rl.Requests, rl.Window, scope)
+}
+
+// printActionTable prints actions as an aligned table
+func printActionTable(out io.Writer, summaries []actionSummary) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "NAME\tTYPE\tTRIGGER\tRESPONSE\tRATE LIMIT\tAUTH\tENABLED")
+	for _, s := range summaries {
+		rateLimit := s.RateLimit
+		if rateLimit == "" {
+			rateLimit = "-"
+		}
+
+		auth := "no"
+		if s.RequireAuth {
+			auth = "yes"
+		}
+
+		enabled := "yes"
+		if !s.Enabled {
+			enabled = "no"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", s.Name, s.Type, s.Trigger, s.Response, rateLimit, auth, enabled)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config/migrations"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	migrateFromVersion string
+	migrateToVersion   string
+)
+
+// migrateCmd upgrades a configuration file written for an older version
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate a configuration file to a newer version",
+	Long: `Apply the registered migrations between two configuration versions in order.
+Key order and comments are preserved. The original file is kept next to the
+migrated one with a .bak suffix; existing backups are never overwritten, a
+numbered name (config.yaml.1.bak, ...) is used instead.
+
+Example:
+  gxf-discord-bot migrate --config config.yaml --from-version v1 --to-version v2`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringVar(&migrateFromVersion, "from-version", "", "version the config file was written for")
+	migrateCmd.Flags().StringVar(&migrateToVersion, "to-version", "", "version to migrate the config file to")
+	_ = migrateCmd.MarkFlagRequired("from-version")
+	_ = migrateCmd.MarkFlagRequired("to-version")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	if err := migrations.Migrate(&doc, migrateFromVersion, migrateToVersion); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	backup, err := writeBackup(cfgFile, data)
+	if err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	if err := os.WriteFile(cfgFile, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Migrated %s from %s to %s (backup: %s)\n", cfgFile, migrateFromVersion, migrateToVersion, backup)
+	return nil
+}
+
+// writeBackup stores data next to path under the first free .bak name, never
+// overwriting the backup of an earlier migration
+func writeBackup(path string, data []byte) (string, error) {
+	for i := 0; ; i++ {
+		name := path + ".bak"
+		if i > 0 {
+			name = fmt.Sprintf("%s.%d.bak", path, i)
+		}
+
+		file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		_, err = file.Write(data)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		return name, err
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cloudflare/tableflip"
+	"github.com/geekxflood/common/logging"
+)
+
+// watchUpgradeSignal starts a replacement process each time SIGUSR2 is received
+func watchUpgradeSignal(upg *tableflip.Upgrader, logger logging.Logger) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGUSR2)
+
+	go func() {
+		for range sigChan {
+			logger.Info("Upgrade signal received, starting replacement process")
+			if err := upg.Upgrade(); err != nil {
+				logger.Error("Graceful restart failed", "error", err)
+			}
+		}
+	}()
+}
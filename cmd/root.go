@@ -4,20 +4,40 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
-	"github.com/geekxflood/common/logging"
+	"github.com/cloudflare/tableflip"
+	"github.com/geekxflood/gxf-discord-bot/pkg/bot"
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/feature"
+	"github.com/geekxflood/gxf-discord-bot/pkg/logs"
+	"github.com/geekxflood/gxf-discord-bot/pkg/sharding"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
 var (
-	cfgFile string
-	debug   bool
+	cfgFile          string
+	debug            bool
+	dryRun           bool
+	gracefulRestart  bool
+	allShards        bool
+	profile          string
+	storePath        string
+	historyRetention time.Duration
+	noRemote         bool
 )
 
+// runner is a bot or a set of shards that can be started and stopped
+type runner interface {
+	Start(ctx context.Context) error
+	Stop() error
+}
+
 // rootCmd represents the base command when called without subcommands
 var rootCmd = &cobra.Command{
 	Use:   "gxf-discord-bot",
@@ -35,25 +55,20 @@ func Execute() error {
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "config.yaml", "config file path")
+	_ = rootCmd.MarkPersistentFlagFilename("config", "yaml", "yml")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "configuration profile merged from config.<profile>.yaml (development, staging, production)")
+	rootCmd.PersistentFlags().StringVar(&storePath, "store-path", "./bot.db", "SQLite database recording the action execution history")
+	rootCmd.PersistentFlags().BoolVar(&noRemote, "no-remote", false, "reject actions imported from URLs with $url")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug logging")
+	rootCmd.Flags().BoolVar(&dryRun, "dry-run", false, "connect to Discord but only log actions instead of executing them")
+	rootCmd.Flags().BoolVar(&allShards, "all-shards", false, "run every shard of bot.shardCount in this process")
+	rootCmd.Flags().DurationVar(&historyRetention, "history-retention", 30*24*time.Hour, "delete recorded executions older than this duration, 0 keeps them all")
+	rootCmd.Flags().BoolVar(&gracefulRestart, "graceful-restart", false, "replace the running process on SIGUSR2 without dropping in-flight actions")
 }
 
 func runBot(cmd *cobra.Command, args []string) error {
-	// Initialize logger
-	logger, cleanup, err := logging.NewLogger(logging.Config{
-		Level:  getLogLevel(),
-		Format: "json",
-		Output: "stdout",
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create logger: %w", err)
-	}
-	defer cleanup.Close()
-
-	logger.Info("Starting GXF Discord Bot")
-
-	// Load configuration
-	cfg, err := config.Load(cfgFile)
+	// Load configuration, the logger is configured by it
+	cfg, err := loadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -63,30 +78,133 @@ func runBot(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Initialize logger
+	logger, cleanup, err := initLogger(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	if cleanup != nil {
+		defer cleanup.Close()
+	}
+
+	logger.Info("Starting GXF Discord Bot")
+	if profile != "" {
+		logger.Info("Using configuration profile", "profile", profile)
+	}
 	logger.Info("Configuration loaded and validated")
+	if len(cfg.Bot.OwnerIDs) == 0 {
+		logger.Warn("No bot owners configured, owner commands such as reload are disabled", "setting", "bot.ownerIds")
+	}
 
-	// TODO: Initialize and start bot
-	logger.Info("Bot initialization not yet implemented (TDD in progress)")
+	feature.SetGlobal(feature.New(cfg.Bot.Features))
+	if (allShards || cfg.Bot.ShardCount > 1) && !feature.IsEnabled(feature.Sharding) {
+		return fmt.Errorf("sharding is experimental, enable it with bot.features.%s", feature.Sharding)
+	}
 
 	// Setup signal handling for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	_ = ctx // Will be used when bot is implemented
+	// Initialize bot, shard 0 holds the settings shared by all shards
+	var b *bot.Bot
+	var r runner
+	if allShards {
+		shards, err := sharding.NewShardManager(ctx, cfg, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create shards: %w", err)
+		}
+		b, r = shards.Primary(), shards
+	} else {
+		b, err = bot.New(ctx, cfg, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create bot: %w", err)
+		}
+		r = b
+	}
+
+	// The upgrader hands the admin socket over to the replacement process
+	var upg *tableflip.Upgrader
+	if gracefulRestart {
+		upg, err = tableflip.New(tableflip.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to initialize graceful restart: %w", err)
+		}
+		defer upg.Stop()
+
+		b.SetAdminListenFunc(upg.Listen)
+		watchUpgradeSignal(upg, logger)
+	}
+
+	b.SetConfigSource(cfgFile, profile, loadOptions()...)
+
+	store, err := storage.NewSQLiteStore(storePath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	store.SetErrorHandler(func(err error) {
+		logger.Warn("Failed to store action executions", "error", err)
+	})
+	if err := store.SetRetention(historyRetention); err != nil {
+		logger.Warn("Failed to delete old executions", "error", err)
+	}
+	b.SetStore(store)
+
+	if dryRun {
+		logger.Info("Dry-run mode enabled, actions will be logged but not executed")
+		b.SetDryRun(true)
+	}
+
+	if err := r.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start bot: %w", err)
+	}
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Wait for shutdown signal
-	<-sigChan
-	logger.Info("Shutdown signal received, stopping bot...")
+	// Wait for shutdown signal, or for a replacement process to take over
+	var exit <-chan struct{}
+	if upg != nil {
+		if err := upg.Ready(); err != nil {
+			return fmt.Errorf("failed to signal readiness: %w", err)
+		}
+		exit = upg.Exit()
+	}
+
+	select {
+	case <-sigChan:
+		logger.Info("Shutdown signal received, stopping bot...")
+	case <-exit:
+		logger.Info("Replacement process ready, draining and stopping bot...")
+	}
+
+	// Stop drains the worker pool before closing the session
+	return r.Stop()
+}
 
+// loadConfig loads the config file merged with the overlay of the active profile
+// and the actions added at runtime
+func loadConfig() (*config.Config, error) {
+	return config.LoadRuntime(cfgFile, profile, loadOptions()...)
+}
+
+// loadOptions returns the config load options selected by the flags
+func loadOptions() []config.LoadOption {
+	if noRemote {
+		return []config.LoadOption{config.WithoutRemoteActions()}
+	}
 	return nil
 }
 
-func getLogLevel() string {
+// initLogger creates the logger described by the logging section, --debug forces the debug level
+func initLogger(cfg *config.Config) (*logs.Logger, io.Closer, error) {
+	var loggingCfg config.LoggingConfig
+	if cfg.Logging != nil {
+		loggingCfg = *cfg.Logging
+	}
 	if debug {
-		return "debug"
+		loggingCfg.Level = "debug"
 	}
-	return "info"
+
+	return logs.New(&loggingCfg, "stdout")
 }
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var schemaDocsOutput string
+
+// schemaCmd groups configuration schema commands
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Inspect the configuration schema",
+	Long:  `Inspect the configuration schema supported by this version of the bot.`,
+}
+
+// schemaDocsCmd generates the configuration reference
+var schemaDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate the configuration reference as Markdown",
+	Long: `Generate a Markdown reference of every configuration field with its type,
+whether it is required, its default and its description, followed by the
+action types and the trigger and response fields they use.
+
+The reference is generated from the configuration types, so it always
+matches the running version.
+
+Examples:
+  gxf-discord-bot schema docs
+  gxf-discord-bot schema docs --output docs/config.md`,
+	Args: cobra.NoArgs,
+	RunE: runSchemaDocs,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaDocsCmd)
+
+	schemaDocsCmd.Flags().StringVarP(&schemaDocsOutput, "output", "o", "", "output file path (default stdout)")
+}
+
+func runSchemaDocs(cmd *cobra.Command, args []string) error {
+	if schemaDocsOutput == "" {
+		return config.WriteSchemaDocs(cmd.OutOrStdout())
+	}
+
+	var buf bytes.Buffer
+	if err := config.WriteSchemaDocs(&buf); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(schemaDocsOutput, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write schema docs: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Configuration reference written to %s\n", schemaDocsOutput)
+	return nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsAction   string
+	statsSince    time.Duration
+	statsTopUsers int
+)
+
+// statsCmd prints statistics from the action execution history
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show action execution statistics",
+	Long: `Show per-action success rates and average durations, and the users
+triggering the most actions, from the execution history recorded by the bot.
+
+Examples:
+  gxf-discord-bot stats
+  gxf-discord-bot stats --store-path /data/bot.db --since 24h
+  gxf-discord-bot stats --action ping --top-users 10`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVar(&statsAction, "action", "", "only include executions of this action")
+	statsCmd.Flags().DurationVar(&statsSince, "since", 0, "only include executions within this duration (e.g. 24h)")
+	statsCmd.Flags().IntVar(&statsTopUsers, "top-users", 5, "number of top users to show")
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	store, err := storage.NewSQLiteStore(storePath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	filter := storage.ExecutionFilter{ActionName: statsAction}
+	if statsSince > 0 {
+		filter.Since = time.Now().Add(-statsSince)
+	}
+
+	stats, err := store.SummarizeExecutions(filter)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if len(stats) == 0 {
+		fmt.Fprintln(out, "No executions recorded")
+		return nil
+	}
+
+	users, err := store.TopUsers(filter, statsTopUsers)
+	if err != nil {
+		return err
+	}
+
+	if err := printActionStats(out, stats); err != nil {
+		return err
+	}
+	fmt.Fprintln(out)
+	return printTopUsers(out, users)
+}
+
+// printActionStats prints the statistics of each action as a table
+func printActionStats(out io.Writer, stats []storage.ActionStats) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tEXECUTIONS\tFAILURES\tSUCCESS RATE\tAVG DURATION")
+	for _, s := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\t%s\n", s.ActionName, s.Executions, s.Failures, s.SuccessRate()*100, s.AverageDuration.Round(time.Microsecond))
+	}
+	return w.Flush()
+}
+
+// printTopUsers prints the users with the most executions as a table
+func printTopUsers(out io.Writer, users []storage.UserCount) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tEXECUTIONS")
+	for _, user := range users {
+		fmt.Fprintf(w, "%s\t%d\n", user.UserID, user.Executions)
+	}
+	return w.Flush()
+}
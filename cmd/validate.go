@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/feature"
+	"github.com/geekxflood/gxf-discord-bot/pkg/scheduler"
+	"github.com/spf13/cobra"
+)
+
+// validateCmd validates a configuration file
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a configuration file",
+	Long: `Validate a configuration file without starting the bot.
+
+Examples:
+  gxf-discord-bot validate --config config.yaml`,
+	RunE: runValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+// scheduleSummary describes the schedule of a scheduled or status cycle action
+type scheduleSummary struct {
+	Name     string
+	Schedule string
+	Timezone string
+	NextRun  time.Time
+	Err      error
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	schedules := summarizeSchedules(cfg.Actions, time.Now())
+	for _, schedule := range schedules {
+		if schedule.Err != nil {
+			printSchedules(out, schedules)
+			return fmt.Errorf("invalid configuration: action %s: %w", schedule.Name, schedule.Err)
+		}
+	}
+
+	fmt.Fprintf(out, "Configuration %s is valid (%d actions)\n", cfgFile, len(cfg.Actions))
+
+	enabled := feature.New(cfg.Bot.Features).Enabled()
+	if len(enabled) == 0 {
+		fmt.Fprintln(out, "Enabled features: none")
+	} else {
+		fmt.Fprintf(out, "Enabled features: %s\n", strings.Join(enabled, ", "))
+	}
+
+	printSchedules(out, schedules)
+	return nil
+}
+
+// summarizeSchedules computes the next run of the scheduled and status cycle actions
+func summarizeSchedules(actions []config.ActionConfig, now time.Time) []scheduleSummary {
+	var schedules []scheduleSummary
+	for _, action := range actions {
+		if action.Type != "scheduled" && action.Type != "status_cycle" {
+			continue
+		}
+
+		summary := scheduleSummary{Name: action.Name, Schedule: action.Trigger.Schedule, Timezone: action.Trigger.Timezone}
+		summary.NextRun, summary.Err = scheduler.NextRun(action.Trigger.Schedule, action.Trigger.Timezone, now)
+		if loc, err := time.LoadLocation(action.Trigger.Timezone); err == nil && summary.Err == nil {
+			summary.NextRun = summary.NextRun.In(loc)
+		}
+		schedules = append(schedules, summary)
+	}
+	return schedules
+}
+
+// printSchedules lists the schedules with their timezone and next run, or why they are invalid
+func printSchedules(out io.Writer, schedules []scheduleSummary) {
+	if len(schedules) == 0 {
+		fmt.Fprintln(out, "Scheduled actions: none")
+		return
+	}
+
+	fmt.Fprintln(out, "Scheduled actions:")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, schedule := range schedules {
+		timezone := schedule.Timezone
+		if timezone == "" {
+			timezone = "local"
+		}
+
+		status := "next run " + schedule.NextRun.Format(time.RFC3339)
+		if schedule.Err != nil {
+			status = "invalid: " + schedule.Err.Error()
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", schedule.Name, schedule.Schedule, timezone, status)
+	}
+	_ = w.Flush()
+}
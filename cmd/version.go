@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+var versionFormat string
+
+// versionCmd prints build metadata
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version information",
+	RunE:  runVersion,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().StringVar(&versionFormat, "format", "text", "output format (text, json)")
+
+	rootCmd.Version = version.Version
+	rootCmd.SetVersionTemplate(version.Get().String() + "\n")
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	info := version.Get()
+
+	switch versionFormat {
+	case "text":
+		fmt.Fprintln(cmd.OutOrStdout(), info.String())
+		return nil
+	case "json":
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	default:
+		return fmt.Errorf("unsupported format: %s (must be text or json)", versionFormat)
+	}
+}
@@ -0,0 +1,14 @@
+// Command dice is the dice plugin built as a Go plugin shared object:
+//
+//	go build -buildmode=plugin -o dice.so ./examples/plugins/dice
+package main
+
+import (
+	"github.com/geekxflood/gxf-discord-bot/pkg/plugin"
+	"github.com/geekxflood/gxf-discord-bot/pkg/plugin/dice"
+)
+
+// Plugin is the symbol looked up by the plugin loader
+var Plugin plugin.Plugin = dice.New()
+
+func main() {}
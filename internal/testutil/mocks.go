@@ -3,12 +3,17 @@ package testutil
 
 import (
 	"context"
+	"sync"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/geekxflood/common/logging"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
 	"github.com/stretchr/testify/mock"
 )
 
+// MockDiscordSession can replace the Discord session of responses and actions
+var _ response.DiscordSession = &MockDiscordSession{}
+
 // MockDiscordSession is a mock implementation of Discord session for testing
 type MockDiscordSession struct {
 	mock.Mock
@@ -76,6 +81,15 @@ func (m *MockDiscordSession) MessageReactionAdd(channelID, messageID, emojiID st
 	return args.Error(0)
 }
 
+// MessageReactions mocks retrieving the users having reacted with an emoji
+func (m *MockDiscordSession) MessageReactions(channelID, messageID, emojiID string, limit int, beforeID, afterID string, options ...discordgo.RequestOption) ([]*discordgo.User, error) {
+	args := m.Called(channelID, messageID, emojiID, limit, beforeID, afterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*discordgo.User), args.Error(1)
+}
+
 // ChannelMessage mocks retrieving a message from a channel
 func (m *MockDiscordSession) ChannelMessage(channelID, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
 	args := m.Called(channelID, messageID)
@@ -85,29 +99,99 @@ func (m *MockDiscordSession) ChannelMessage(channelID, messageID string, options
 	return args.Get(0).(*discordgo.Message), args.Error(1)
 }
 
+// ChannelMessageDelete mocks deleting a message from a channel
+func (m *MockDiscordSession) ChannelMessageDelete(channelID, messageID string, options ...discordgo.RequestOption) error {
+	args := m.Called(channelID, messageID)
+	return args.Error(0)
+}
+
+// GuildMember mocks retrieving a member of a guild
+func (m *MockDiscordSession) GuildMember(guildID, userID string, options ...discordgo.RequestOption) (*discordgo.Member, error) {
+	args := m.Called(guildID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discordgo.Member), args.Error(1)
+}
+
+// ChannelMessageSendComplex mocks sending a message with attachments such as stickers
+func (m *MockDiscordSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	args := m.Called(channelID, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discordgo.Message), args.Error(1)
+}
+
+// Channel mocks retrieving a channel
+func (m *MockDiscordSession) Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	args := m.Called(channelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discordgo.Channel), args.Error(1)
+}
+
+// Guild mocks retrieving a guild
+func (m *MockDiscordSession) Guild(guildID string, options ...discordgo.RequestOption) (*discordgo.Guild, error) {
+	args := m.Called(guildID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discordgo.Guild), args.Error(1)
+}
+
+// UserChannelPermissions mocks retrieving the permissions of a user in a channel
+func (m *MockDiscordSession) UserChannelPermissions(userID, channelID string, options ...discordgo.RequestOption) (int64, error) {
+	args := m.Called(userID, channelID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GuildMemberRoleAdd mocks adding a role to a guild member
+func (m *MockDiscordSession) GuildMemberRoleAdd(guildID, userID, roleID string, options ...discordgo.RequestOption) error {
+	args := m.Called(guildID, userID, roleID)
+	return args.Error(0)
+}
+
+// GuildMemberRoleRemove mocks removing a role from a guild member
+func (m *MockDiscordSession) GuildMemberRoleRemove(guildID, userID, roleID string, options ...discordgo.RequestOption) error {
+	args := m.Called(guildID, userID, roleID)
+	return args.Error(0)
+}
+
 // MockLogger is a mock implementation of logging.Logger
 type MockLogger struct {
 	mock.Mock
 	InfoMessages  []string
 	ErrorMessages []string
 	DebugMessages []string
+
+	// messagesMu guards the recorded messages, loggers are shared between goroutines
+	messagesMu sync.Mutex
+}
+
+// record appends a message to one of the recorded message lists
+func (m *MockLogger) record(messages *[]string, msg string) {
+	m.messagesMu.Lock()
+	defer m.messagesMu.Unlock()
+	*messages = append(*messages, msg)
 }
 
 // Info logs an info message
 func (m *MockLogger) Info(msg string, keysAndValues ...interface{}) {
-	m.InfoMessages = append(m.InfoMessages, msg)
+	m.record(&m.InfoMessages, msg)
 	m.Called(msg, keysAndValues)
 }
 
 // Error logs an error message
 func (m *MockLogger) Error(msg string, keysAndValues ...interface{}) {
-	m.ErrorMessages = append(m.ErrorMessages, msg)
+	m.record(&m.ErrorMessages, msg)
 	m.Called(msg, keysAndValues)
 }
 
 // Debug logs a debug message
 func (m *MockLogger) Debug(msg string, keysAndValues ...interface{}) {
-	m.DebugMessages = append(m.DebugMessages, msg)
+	m.record(&m.DebugMessages, msg)
 	m.Called(msg, keysAndValues)
 }
 
@@ -124,19 +208,19 @@ func (m *MockLogger) With(keysAndValues ...interface{}) logging.Logger {
 
 // InfoContext logs an info message with context
 func (m *MockLogger) InfoContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
-	m.InfoMessages = append(m.InfoMessages, msg)
+	m.record(&m.InfoMessages, msg)
 	m.Called(ctx, msg, keysAndValues)
 }
 
 // ErrorContext logs an error message with context
 func (m *MockLogger) ErrorContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
-	m.ErrorMessages = append(m.ErrorMessages, msg)
+	m.record(&m.ErrorMessages, msg)
 	m.Called(ctx, msg, keysAndValues)
 }
 
 // DebugContext logs a debug message with context
 func (m *MockLogger) DebugContext(ctx context.Context, msg string, keysAndValues ...interface{}) {
-	m.DebugMessages = append(m.DebugMessages, msg)
+	m.record(&m.DebugMessages, msg)
 	m.Called(ctx, msg, keysAndValues)
 }
 
@@ -0,0 +1,84 @@
+package action
+
+import (
+	"strings"
+	"unicode"
+)
+
+// closingQuotes maps the quotes grouping arguments to their closing quote,
+// mobile keyboards often replace straight quotes with typographic ones
+var closingQuotes = map[rune]rune{
+	'"':  '"',
+	'\'': '\'',
+	'“':  '”',
+	'‘':  '’',
+}
+
+// splitArgs splits command arguments on whitespace, keeping quoted strings together.
+// A quote only opens at the start of an argument, so apostrophes inside words are kept.
+// A backslash escapes a quote or a backslash. An unclosed quote is kept as a literal character.
+func splitArgs(content string) []string {
+	runes := []rune(content)
+	args := []string{}
+
+	var current strings.Builder
+	inArg := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		case r == '\\' && i+1 < len(runes) && isEscapable(runes[i+1]):
+			i++
+			current.WriteRune(runes[i])
+			inArg = true
+		case !inArg && closingQuotes[r] != 0:
+			end, quoted, ok := readQuoted(runes, i)
+			if ok {
+				current.WriteString(quoted)
+				i = end
+			} else {
+				current.WriteRune(r)
+			}
+			inArg = true
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
+
+// readQuoted reads the quoted string opening at runes[start] and returns the index
+// of its closing quote, false when the quote is not closed
+func readQuoted(runes []rune, start int) (int, string, bool) {
+	closing := closingQuotes[runes[start]]
+
+	var quoted strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == closing || runes[i+1] == '\\'):
+			i++
+			quoted.WriteRune(runes[i])
+		case runes[i] == closing:
+			return i, quoted.String(), true
+		default:
+			quoted.WriteRune(runes[i])
+		}
+	}
+
+	return 0, "", false
+}
+
+// isEscapable reports whether a backslash before the character escapes it
+func isEscapable(r rune) bool {
+	return r == '\\' || closingQuotes[r] != 0
+}
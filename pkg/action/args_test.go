@@ -0,0 +1,43 @@
+package action_test
+
+import (
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandHandler_ExtractArgs_Quotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{name: "double quotes", content: `!ban @user "spamming links"`, expected: []string{"@user", "spamming links"}},
+		{name: "single quotes", content: `!ban @user 'spamming links'`, expected: []string{"@user", "spamming links"}},
+		{name: "typographic quotes", content: "!ban @user “spamming links”", expected: []string{"@user", "spamming links"}},
+		{name: "empty quotes", content: `!say "" done`, expected: []string{"", "done"}},
+		{name: "escaped quote inside quotes", content: `!say "she said \"hi\""`, expected: []string{`she said "hi"`}},
+		{name: "escaped backslash", content: `!say "C:\\temp\\" next`, expected: []string{`C:\temp\`, "next"}},
+		{name: "escaped quote outside quotes", content: `!say \"not quoted\"`, expected: []string{`"not`, `quoted"`}},
+		{name: "other backslashes kept", content: `!path C:\temp\logs`, expected: []string{`C:\temp\logs`}},
+		{name: "nested single quotes", content: `!say "it's fine" ok`, expected: []string{"it's fine", "ok"}},
+		{name: "nested double quotes", content: `!say 'the "best" bot'`, expected: []string{`the "best" bot`}},
+		{name: "apostrophe inside word", content: `!say don't stop`, expected: []string{"don't", "stop"}},
+		{name: "quote inside word", content: `!say 5'11" tall`, expected: []string{`5'11"`, "tall"}},
+		{name: "quoted value after flag", content: `!set --name="John Doe" now`, expected: []string{`--name="John`, `Doe"`, "now"}},
+		{name: "text after closing quote", content: `!say "a b"c d`, expected: []string{"a bc", "d"}},
+		{name: "unclosed quote", content: `!ban @user "spamming links`, expected: []string{"@user", `"spamming`, "links"}},
+		{name: "unclosed quote after quoted argument", content: `!say "a b" "c d`, expected: []string{"a b", `"c`, "d"}},
+		{name: "mixed whitespace", content: "!say\tone \n two\t\t\"three\tfour\"", expected: []string{"one", "two", "three\tfour"}},
+		{name: "newline inside quotes", content: "!say \"line one\nline two\"", expected: []string{"line one\nline two"}},
+		{name: "only quotes", content: `!say "   "`, expected: []string{"   "}},
+	}
+
+	handler := action.NewCommandHandler("!", "ban")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, handler.ExtractArgs(tt.content))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package action
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// cacheTTL is how long Discord data fetched for conditions is reused
+const cacheTTL = 5 * time.Minute
+
+// fetchFunc fetches an entry of a cache from the Discord API
+type fetchFunc[T any] func(session response.DiscordSession, id string) (T, error)
+
+// apiCache keeps the entries fetched from the Discord API for cacheTTL.
+// Expired entries are evicted at most once per cacheTTL when entries are added.
+type apiCache[T any] struct {
+	entries   map[string]cacheEntry[T]
+	fetch     fetchFunc[T]
+	now       func() time.Time
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+// cacheEntry is a cached value with its fetch time
+type cacheEntry[T any] struct {
+	value     T
+	fetchedAt time.Time
+}
+
+// newAPICache creates an empty cache fetching its entries with fetch
+func newAPICache[T any](fetch fetchFunc[T]) *apiCache[T] {
+	return &apiCache[T]{
+		entries: make(map[string]cacheEntry[T]),
+		fetch:   fetch,
+		now:     time.Now,
+	}
+}
+
+// newGuildCache creates an empty cache of guilds
+func newGuildCache() *apiCache[*discordgo.Guild] {
+	return newAPICache(func(session response.DiscordSession, guildID string) (*discordgo.Guild, error) {
+		return session.Guild(guildID)
+	})
+}
+
+// newChannelCache creates an empty cache of channels
+func newChannelCache() *apiCache[*discordgo.Channel] {
+	return newAPICache(func(session response.DiscordSession, channelID string) (*discordgo.Channel, error) {
+		return session.Channel(channelID)
+	})
+}
+
+// get returns the cached entry, fetching it when missing or expired
+func (c *apiCache[T]) get(session response.DiscordSession, id string) (T, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[id]
+	c.mu.Unlock()
+
+	if ok && c.now().Sub(entry.fetchedAt) < cacheTTL {
+		return entry.value, nil
+	}
+
+	value, err := c.fetch(session, id)
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.entries[id] = cacheEntry[T]{value: value, fetchedAt: now}
+	if now.Sub(c.lastSweep) >= cacheTTL {
+		c.evictExpired(now)
+	}
+
+	return value, nil
+}
+
+// evictExpired removes the entries older than cacheTTL, the caller holds the lock
+func (c *apiCache[T]) evictExpired(now time.Time) {
+	for id, entry := range c.entries {
+		if now.Sub(entry.fetchedAt) >= cacheTTL {
+			delete(c.entries, id)
+		}
+	}
+	c.lastSweep = now
+}
@@ -0,0 +1,284 @@
+package action
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// permissionFlags maps the permission names accepted by permission conditions to their flag
+var permissionFlags = map[string]int64{
+	"administrator":   discordgo.PermissionAdministrator,
+	"banMembers":      discordgo.PermissionBanMembers,
+	"kickMembers":     discordgo.PermissionKickMembers,
+	"manageChannels":  discordgo.PermissionManageChannels,
+	"manageGuild":     discordgo.PermissionManageGuild,
+	"manageMessages":  discordgo.PermissionManageMessages,
+	"manageRoles":     discordgo.PermissionManageRoles,
+	"manageWebhooks":  discordgo.PermissionManageWebhooks,
+	"mentionEveryone": discordgo.PermissionMentionEveryone,
+	"moderateMembers": discordgo.PermissionModerateMembers,
+}
+
+// conditionMessages are sent to commands when a condition of the type fails and has no message
+var conditionMessages = map[string]string{
+	"boost_level": "This command requires a Nitro-boosted server",
+}
+
+// Failure messages of the threadOnly and noThreads trigger flags
+const (
+	threadOnlyMessage = "This command is only available in threads."
+	noThreadsMessage  = "This command is not available in threads."
+)
+
+// conditionSet holds the compiled conditions of an action
+type conditionSet struct {
+	prefix     string
+	conditions []condition
+}
+
+// condition is a configured condition with its compiled pattern or permission
+type condition struct {
+	config.ConditionConfig
+	pattern    *regexp.Regexp
+	permission int64
+	tier       int
+
+	// start and end of a time_of_day range in minutes since midnight in location
+	start    int
+	end      int
+	location *time.Location
+
+	// defaultMessage is sent to commands when the condition fails and has no message
+	defaultMessage string
+}
+
+// failureMessage returns the message sent to the channel when the condition fails.
+// The default messages are only sent to commands, a message action not running stays silent.
+func (c condition) failureMessage(command bool) string {
+	if c.Message != "" {
+		return c.Message
+	}
+	if !command {
+		return ""
+	}
+	return c.defaultMessage
+}
+
+// compileConditions prepares the conditions of an action, nil when it has none.
+// The threadOnly and noThreads trigger flags are checked as the first conditions.
+func compileConditions(bot config.BotConfig, action config.ActionConfig) (*conditionSet, error) {
+	flags := threadConditions(action.Trigger)
+	if len(flags) == 0 && len(action.Conditions) == 0 {
+		return nil, nil
+	}
+
+	set := &conditionSet{prefix: bot.Prefix, conditions: flags}
+	for _, cfg := range action.Conditions {
+		cond := condition{ConditionConfig: cfg, defaultMessage: conditionMessages[cfg.Type]}
+
+		if cfg.Operator == "matches" {
+			pattern, err := regexp.Compile(cfg.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid condition pattern: %w", err)
+			}
+			cond.pattern = pattern
+		}
+
+		if cfg.Type == "permission" {
+			permission, err := parsePermission(cfg.Value)
+			if err != nil {
+				return nil, err
+			}
+			cond.permission = permission
+		}
+
+		if cfg.Type == "boost_level" {
+			tier, err := strconv.Atoi(cfg.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid boost level: %w", err)
+			}
+			cond.tier = tier
+		}
+
+		if cfg.Type == "time_of_day" {
+			start, end, err := config.ParseTimeRange(cfg.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			timezone := cmp.Or(cfg.Timezone, bot.Timezone, "UTC")
+			location, err := time.LoadLocation(timezone)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timezone %s: %w", timezone, err)
+			}
+			cond.start, cond.end, cond.location = start, end, location
+		}
+
+		set.conditions = append(set.conditions, cond)
+	}
+
+	return set, nil
+}
+
+// threadConditions returns the conditions of the threadOnly and noThreads trigger flags
+func threadConditions(trigger config.TriggerConfig) []condition {
+	var conditions []condition
+	if trigger.ThreadOnly {
+		conditions = append(conditions, condition{
+			ConditionConfig: config.ConditionConfig{Type: "thread"},
+			defaultMessage:  threadOnlyMessage,
+		})
+	}
+	if trigger.NoThreads {
+		conditions = append(conditions, condition{
+			ConditionConfig: config.ConditionConfig{Type: "thread", Operator: "not"},
+			defaultMessage:  noThreadsMessage,
+		})
+	}
+	return conditions
+}
+
+// parsePermission converts a permission name or numeric flag
+func parsePermission(value string) (int64, error) {
+	if permission, ok := permissionFlags[value]; ok {
+		return permission, nil
+	}
+	if permission, err := strconv.ParseInt(value, 10, 64); err == nil && permission > 0 {
+		return permission, nil
+	}
+	return 0, fmt.Errorf("unknown permission: %s", value)
+}
+
+// check returns the first condition the message does not satisfy, nil when all hold
+func (s *conditionSet) check(m *Manager, session response.DiscordSession, message *discordgo.Message) (*condition, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	for i := range s.conditions {
+		ok, err := s.checkCondition(m, session, message, s.conditions[i])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return &s.conditions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// checkCondition reports whether the message satisfies a condition
+func (s *conditionSet) checkCondition(m *Manager, session response.DiscordSession, message *discordgo.Message, cond condition) (bool, error) {
+	switch cond.Type {
+	case "channel":
+		return compareValue(message.ChannelID, cond), nil
+	case "user":
+		if message.Author == nil {
+			return false, nil
+		}
+		return compareValue(message.Author.ID, cond), nil
+	case "content":
+		content := strings.TrimSpace(message.Content)
+		content = strings.TrimSpace(strings.TrimPrefix(content, s.prefix))
+		return compareValue(content, cond), nil
+	case "role":
+		hasRole := message.Member != nil && slices.Contains(message.Member.Roles, cond.Value)
+		return hasRole != (cond.Operator == "not"), nil
+	case "permission":
+		if message.Author == nil {
+			return false, nil
+		}
+		permissions, err := session.UserChannelPermissions(message.Author.ID, message.ChannelID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get permissions: %w", err)
+		}
+		hasPermission := permissions&cond.permission == cond.permission
+		return hasPermission != (cond.Operator == "not"), nil
+	case "boost_level":
+		if message.GuildID == "" {
+			return false, nil
+		}
+		guild, err := m.guilds.get(session, message.GuildID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get guild: %w", err)
+		}
+		boosted := int(guild.PremiumTier) >= cond.tier
+		return boosted != (cond.Operator == "not"), nil
+	case "thread":
+		channel, err := m.channels.get(session, message.ChannelID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get channel: %w", err)
+		}
+		return channel.IsThread() != (cond.Operator == "not"), nil
+	case "word_count":
+		return compareNumeric(len(strings.Fields(message.Content)), cond.Value, cond.Operator), nil
+	case "time_of_day":
+		now := m.clock.Now().In(cond.location)
+		return inTimeRange(now.Hour()*60+now.Minute(), cond.start, cond.end) != (cond.Operator == "not"), nil
+	default:
+		return false, fmt.Errorf("unknown condition type: %s", cond.Type)
+	}
+}
+
+// inTimeRange reports whether minute is within [start, end), ranges with end before start cross midnight
+func inTimeRange(minute, start, end int) bool {
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// compareNumeric compares a number of the message with the expected value of a condition
+func compareNumeric(actual int, expected string, operator string) bool {
+	value, err := strconv.Atoi(expected)
+	if err != nil {
+		return false
+	}
+
+	switch operator {
+	case "", "equals":
+		return actual == value
+	case "not":
+		return actual != value
+	case "lt":
+		return actual < value
+	case "gt":
+		return actual > value
+	case "lte":
+		return actual <= value
+	case "gte":
+		return actual >= value
+	default:
+		return false
+	}
+}
+
+// compareValue compares a value of the message with the condition, ignoring case
+// except for regular expressions
+func compareValue(actual string, cond condition) bool {
+	switch cond.Operator {
+	case "", "equals":
+		return strings.EqualFold(actual, cond.Value)
+	case "not":
+		return !strings.EqualFold(actual, cond.Value)
+	case "contains":
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(cond.Value))
+	case "startsWith":
+		return strings.HasPrefix(strings.ToLower(actual), strings.ToLower(cond.Value))
+	case "endsWith":
+		return strings.HasSuffix(strings.ToLower(actual), strings.ToLower(cond.Value))
+	case "matches":
+		return cond.pattern.MatchString(actual)
+	default:
+		return false
+	}
+}
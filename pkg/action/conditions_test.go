@@ -0,0 +1,293 @@
+package action_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newConditionManager creates a manager with a !ask command restricted by conditions
+func newConditionManager(t *testing.T, conditions ...config.ConditionConfig) *action.Manager {
+	t.Helper()
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(&config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:       "ask",
+				Type:       "command",
+				Trigger:    config.TriggerConfig{Command: "ask"},
+				Response:   config.ResponseConfig{Type: "text", Content: "answered"},
+				Conditions: conditions,
+			},
+		},
+	}, logger)
+	require.NoError(t, err)
+
+	return mgr
+}
+
+// answered reports whether the !ask action responded to the message
+func answered(t *testing.T, mgr *action.Manager, session *testutil.MockDiscordSession, message *discordgo.MessageCreate) bool {
+	t.Helper()
+
+	session.On("ChannelMessageSend", "channel123", "answered").Return(&discordgo.Message{}, nil).Maybe()
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, message))
+	return len(session.Calls) > 0 && session.Calls[len(session.Calls)-1].Method == "ChannelMessageSend"
+}
+
+func TestConditions_Content(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition config.ConditionConfig
+		content   string
+		want      bool
+	}{
+		{name: "equals", condition: config.ConditionConfig{Type: "content", Value: "ask now"}, content: "!ASK now", want: true},
+		{name: "not", condition: config.ConditionConfig{Type: "content", Operator: "not", Value: "ask now"}, content: "!ask now", want: false},
+		{name: "contains", condition: config.ConditionConfig{Type: "content", Operator: "contains", Value: "urgent"}, content: "!ask this is URGENT", want: true},
+		{name: "contains missing", condition: config.ConditionConfig{Type: "content", Operator: "contains", Value: "urgent"}, content: "!ask whenever", want: false},
+		{name: "startsWith after prefix", condition: config.ConditionConfig{Type: "content", Operator: "startsWith", Value: "ask "}, content: "!ask why", want: true},
+		{name: "endsWith", condition: config.ConditionConfig{Type: "content", Operator: "endsWith", Value: "?"}, content: "!ask why", want: false},
+		{name: "matches", condition: config.ConditionConfig{Type: "content", Operator: "matches", Value: `#[0-9]+$`}, content: "!ask about #42", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newConditionManager(t, tt.condition)
+			session := &testutil.MockDiscordSession{}
+
+			assert.Equal(t, tt.want, answered(t, mgr, session, benchMessage(tt.content)))
+		})
+	}
+}
+
+func TestConditions_ChannelUserRole(t *testing.T) {
+	message := benchMessage("!ask")
+	message.Member = &discordgo.Member{Roles: []string{"role1"}}
+
+	tests := []struct {
+		name       string
+		conditions []config.ConditionConfig
+		want       bool
+	}{
+		{name: "channel", conditions: []config.ConditionConfig{{Type: "channel", Value: "channel123"}}, want: true},
+		{name: "other channel", conditions: []config.ConditionConfig{{Type: "channel", Value: "channel456"}}, want: false},
+		{name: "user not", conditions: []config.ConditionConfig{{Type: "user", Operator: "not", Value: "123"}}, want: false},
+		{name: "role", conditions: []config.ConditionConfig{{Type: "role", Value: "role1"}}, want: true},
+		{name: "not role", conditions: []config.ConditionConfig{{Type: "role", Operator: "not", Value: "role1"}}, want: false},
+		{name: "all must hold", conditions: []config.ConditionConfig{{Type: "channel", Value: "channel123"}, {Type: "role", Value: "role2"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newConditionManager(t, tt.conditions...)
+			session := &testutil.MockDiscordSession{}
+
+			assert.Equal(t, tt.want, answered(t, mgr, session, message))
+		})
+	}
+}
+
+func TestConditions_Permission(t *testing.T) {
+	mgr := newConditionManager(t, config.ConditionConfig{Type: "permission", Value: "manageMessages"})
+
+	session := &testutil.MockDiscordSession{}
+	session.On("UserChannelPermissions", "123", "channel123").Return(int64(discordgo.PermissionManageMessages|discordgo.PermissionSendMessages), nil)
+	assert.True(t, answered(t, mgr, session, benchMessage("!ask")))
+
+	session = &testutil.MockDiscordSession{}
+	session.On("UserChannelPermissions", "123", "channel123").Return(int64(discordgo.PermissionSendMessages), nil)
+	assert.False(t, answered(t, mgr, session, benchMessage("!ask")))
+}
+
+func TestNewManager_InvalidConditions(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	for _, condition := range []config.ConditionConfig{
+		{Type: "content", Operator: "matches", Value: "("},
+		{Type: "permission", Value: "flyAround"},
+	} {
+		_, err := action.NewManager(&config.Config{
+			Bot: config.BotConfig{Prefix: "!"},
+			Actions: []config.ActionConfig{
+				{Name: "ask", Type: "command", Trigger: config.TriggerConfig{Command: "ask"}, Conditions: []config.ConditionConfig{condition}},
+			},
+		}, logger)
+		assert.ErrorContains(t, err, "invalid conditions for ask")
+	}
+}
+
+func TestConditions_BoostLevel(t *testing.T) {
+	mgr := newConditionManager(t, config.ConditionConfig{Type: "boost_level", Value: "2"})
+
+	session := &testutil.MockDiscordSession{}
+	session.On("Guild", "guild123").Return(&discordgo.Guild{ID: "guild123", PremiumTier: discordgo.PremiumTier1}, nil).Once()
+	session.On("ChannelMessageSend", "channel123", "This command requires a Nitro-boosted server").Return(&discordgo.Message{}, nil).Twice()
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask")))
+	// The guild is cached, it is fetched once
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask again")))
+	session.AssertExpectations(t)
+
+	boosted := newConditionManager(t, config.ConditionConfig{Type: "boost_level", Value: "2", Message: "unused"})
+	session = &testutil.MockDiscordSession{}
+	session.On("Guild", "guild123").Return(&discordgo.Guild{ID: "guild123", PremiumTier: discordgo.PremiumTier3}, nil)
+	assert.True(t, answered(t, boosted, session, benchMessage("!ask")))
+}
+
+func TestConditions_Message(t *testing.T) {
+	mgr := newConditionManager(t, config.ConditionConfig{Type: "content", Operator: "contains", Value: "?", Message: "Please ask a question"})
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Please ask a question").Return(&discordgo.Message{}, nil).Once()
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask hello")))
+	session.AssertExpectations(t)
+}
+
+// fixedClock is a clock stopped at a time
+type fixedClock time.Time
+
+// Now returns the fixed time
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestConditions_TimeOfDay(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition config.ConditionConfig
+		now       time.Time
+		want      bool
+	}{
+		{name: "within", condition: config.ConditionConfig{Type: "time_of_day", Value: "09:00-17:00"}, now: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), want: true},
+		{name: "end excluded", condition: config.ConditionConfig{Type: "time_of_day", Value: "09:00-17:00"}, now: time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC), want: false},
+		{name: "not", condition: config.ConditionConfig{Type: "time_of_day", Operator: "not", Value: "09:00-17:00"}, now: time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC), want: true},
+		{name: "across midnight", condition: config.ConditionConfig{Type: "time_of_day", Value: "22:00-06:00"}, now: time.Date(2026, 3, 2, 1, 30, 0, 0, time.UTC), want: true},
+		// 08:30 UTC is 17:30 in Tokyo
+		{name: "timezone", condition: config.ConditionConfig{Type: "time_of_day", Value: "09:00-17:00", Timezone: "Asia/Tokyo"}, now: time.Date(2026, 3, 2, 8, 30, 0, 0, time.UTC), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newConditionManager(t, tt.condition)
+			mgr.SetClock(fixedClock(tt.now))
+			session := &testutil.MockDiscordSession{}
+
+			assert.Equal(t, tt.want, answered(t, mgr, session, benchMessage("!ask")))
+		})
+	}
+}
+
+func TestConditions_WordCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator string
+		value    string
+		content  string
+		want     bool
+	}{
+		{name: "gte", operator: "gte", value: "5", content: "!ask how do I reset it", want: true},
+		{name: "gte short", operator: "gte", value: "5", content: "!ask reset?", want: false},
+		{name: "equals", value: "2", content: "!ask   reset\tnow", want: false},
+		{name: "lt", operator: "lt", value: "3", content: "!ask reset", want: true},
+		{name: "gt", operator: "gt", value: "2", content: "!ask reset", want: false},
+		{name: "lte", operator: "lte", value: "2", content: "!ask reset", want: true},
+		{name: "not", operator: "not", value: "2", content: "!ask reset", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newConditionManager(t, config.ConditionConfig{Type: "word_count", Operator: tt.operator, Value: tt.value})
+			session := &testutil.MockDiscordSession{}
+
+			assert.Equal(t, tt.want, answered(t, mgr, session, benchMessage(tt.content)))
+		})
+	}
+}
+
+func TestConditions_Threads(t *testing.T) {
+	newThreadManager := func(trigger config.TriggerConfig) *action.Manager {
+		logger := &testutil.MockLogger{}
+		logger.On("Info", mock.Anything, mock.Anything).Return()
+		logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+		trigger.Command = "ask"
+		mgr, err := action.NewManager(&config.Config{
+			Bot: config.BotConfig{Prefix: "!"},
+			Actions: []config.ActionConfig{
+				{Name: "ask", Type: "command", Trigger: trigger, Response: config.ResponseConfig{Type: "text", Content: "answered"}},
+			},
+		}, logger)
+		require.NoError(t, err)
+		return mgr
+	}
+
+	thread := &discordgo.Channel{ID: "channel123", Type: discordgo.ChannelTypeGuildPublicThread}
+	text := &discordgo.Channel{ID: "channel123", Type: discordgo.ChannelTypeGuildText}
+
+	t.Run("threadOnly", func(t *testing.T) {
+		mgr := newThreadManager(config.TriggerConfig{ThreadOnly: true})
+
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "channel123").Return(text, nil).Once()
+		session.On("ChannelMessageSend", "channel123", "This command is only available in threads.").Return(&discordgo.Message{}, nil).Twice()
+
+		require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask")))
+		// The channel is cached, it is fetched once
+		require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask again")))
+		session.AssertExpectations(t)
+
+		session = &testutil.MockDiscordSession{}
+		session.On("Channel", "channel123").Return(thread, nil)
+		assert.True(t, answered(t, newThreadManager(config.TriggerConfig{ThreadOnly: true}), session, benchMessage("!ask")))
+	})
+
+	t.Run("noThreads", func(t *testing.T) {
+		mgr := newThreadManager(config.TriggerConfig{NoThreads: true})
+
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "channel123").Return(thread, nil).Once()
+		session.On("ChannelMessageSend", "channel123", "This command is not available in threads.").Return(&discordgo.Message{}, nil).Once()
+
+		require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask")))
+		session.AssertExpectations(t)
+
+		session = &testutil.MockDiscordSession{}
+		session.On("Channel", "channel123").Return(text, nil)
+		assert.True(t, answered(t, newThreadManager(config.TriggerConfig{NoThreads: true}), session, benchMessage("!ask")))
+	})
+
+	t.Run("message action", func(t *testing.T) {
+		logger := &testutil.MockLogger{}
+		logger.On("Info", mock.Anything, mock.Anything).Return()
+		logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+		mgr, err := action.NewManager(&config.Config{
+			Bot: config.BotConfig{Prefix: "!"},
+			Actions: []config.ActionConfig{
+				{Name: "hello", Type: "message", Trigger: config.TriggerConfig{Pattern: "hello", ThreadOnly: true}, Response: config.ResponseConfig{Type: "text", Content: "answered"}},
+			},
+		}, logger)
+		require.NoError(t, err)
+
+		// Messages not run outside threads get no reply
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "channel123").Return(text, nil)
+		assert.False(t, answered(t, mgr, session, benchMessage("hello there")))
+		session.AssertNotCalled(t, "ChannelMessageSend", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,90 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// deadLetterColor is the color of dead letter embeds
+const deadLetterColor = 0xED4245
+
+// deadLetterTimeout bounds the delivery of a dead letter report
+const deadLetterTimeout = 10 * time.Second
+
+// maxDeadLetterFieldLength is the Discord limit of an embed field value
+const maxDeadLetterFieldLength = 1024
+
+// deadLetter reports a failed action to its dead letter channel and webhook
+func (m *Manager) deadLetter(session response.DiscordSession, message *discordgo.Message, actionCfg config.ActionConfig, actionErr error) {
+	if actionCfg.DeadLetterChannel == "" && actionCfg.DeadLetterWebhook == "" {
+		return
+	}
+
+	embed := buildDeadLetterEmbed(message, actionCfg, actionErr)
+
+	if actionCfg.DeadLetterChannel != "" {
+		if _, err := session.ChannelMessageSendEmbed(actionCfg.DeadLetterChannel, embed); err != nil {
+			m.logger.Error("Failed to send dead letter to channel", "action", actionCfg.Name, "channelID", actionCfg.DeadLetterChannel, "error", err)
+		}
+	}
+
+	if actionCfg.DeadLetterWebhook == "" {
+		return
+	}
+
+	// The webhook is sent in the background so a slow endpoint does not hold up the message handler
+	err := m.Submit(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), deadLetterTimeout)
+		defer cancel()
+
+		if err := response.SendWebhookEmbed(ctx, actionCfg.DeadLetterWebhook, embed); err != nil {
+			m.logger.Error("Failed to send dead letter to webhook", "action", actionCfg.Name, "error", err)
+		}
+	})
+	if err != nil {
+		m.logger.Error("Failed to queue dead letter webhook", "action", actionCfg.Name, "error", err)
+	}
+}
+
+// buildDeadLetterEmbed formats a failed action as an error embed
+func buildDeadLetterEmbed(message *discordgo.Message, actionCfg config.ActionConfig, actionErr error) *discordgo.MessageEmbed {
+	user := "unknown"
+	if message.Author != nil {
+		user = fmt.Sprintf("<@%s>", message.Author.ID)
+	}
+
+	trigger := message.Content
+	if trigger == "" {
+		trigger = "-"
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: "Action failed: " + actionCfg.Name,
+		Color: deadLetterColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Action", Value: actionCfg.Name, Inline: true},
+			{Name: "User", Value: user, Inline: true},
+			{Name: "Channel", Value: fmt.Sprintf("<#%s>", message.ChannelID), Inline: true},
+			{Name: "Trigger", Value: fitDeadLetterField(trigger, response.InlineCode)},
+			{Name: "Error", Value: fitDeadLetterField(actionErr.Error(), func(text string) string { return response.CodeBlock("", text) })},
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+}
+
+// fitDeadLetterField formats text with format, shortening the text until the value fits in an embed field
+func fitDeadLetterField(text string, format func(string) string) string {
+	value := format(text)
+	runes := []rune(text)
+	for over := utf8.RuneCountInString(value) - maxDeadLetterFieldLength; over > 0; over = utf8.RuneCountInString(value) - maxDeadLetterFieldLength {
+		runes = runes[:max(0, len(runes)-over-1)]
+		value = format(string(runes) + "…")
+	}
+	return value
+}
@@ -0,0 +1,131 @@
+package action
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Size and lifetime of the entries of the response deduplicator
+const (
+	defaultDedupCapacity = 10000
+	defaultDedupTTL      = 5 * time.Minute
+)
+
+// dedupKey identifies the response of an action to a message
+type dedupKey struct {
+	messageID  string
+	actionName string
+}
+
+// dedupEntry is an element of the deduplicator LRU list
+type dedupEntry struct {
+	key       dedupKey
+	expiresAt time.Time
+}
+
+// ResponseDeduplicator remembers the recent responses to messages so that
+// messages delivered twice around a gateway reconnect are answered once.
+// The least recently used entry is evicted when the capacity is reached.
+type ResponseDeduplicator struct {
+	capacity int
+	ttl      time.Duration
+	entries  map[dedupKey]*list.Element
+	order    *list.List
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+// NewResponseDeduplicator creates a deduplicator holding up to capacity entries for ttl
+func NewResponseDeduplicator(capacity int, ttl time.Duration) *ResponseDeduplicator {
+	return &ResponseDeduplicator{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[dedupKey]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Seen reports whether the action already responded to the message within the ttl
+func (d *ResponseDeduplicator) Seen(messageID, actionName string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.seen(dedupKey{messageID, actionName})
+}
+
+// Claim records the response of the action to the message and reports whether
+// it was not already recorded, so only one of concurrent deliveries responds
+func (d *ResponseDeduplicator) Claim(messageID, actionName string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := dedupKey{messageID, actionName}
+	if d.seen(key) {
+		return false
+	}
+	d.record(key)
+	return true
+}
+
+// Forget removes a recorded response, for example when it failed and a redelivery may retry it
+func (d *ResponseDeduplicator) Forget(messageID, actionName string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if element, ok := d.entries[dedupKey{messageID, actionName}]; ok {
+		d.remove(element)
+	}
+}
+
+// seen reports whether the key is recorded and not expired, the caller holds the lock
+func (d *ResponseDeduplicator) seen(key dedupKey) bool {
+	element, ok := d.entries[key]
+	if !ok {
+		return false
+	}
+
+	if d.now().After(element.Value.(*dedupEntry).expiresAt) {
+		d.remove(element)
+		return false
+	}
+
+	d.order.MoveToFront(element)
+	return true
+}
+
+// Record remembers that the action responded to the message
+func (d *ResponseDeduplicator) Record(messageID, actionName string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.record(dedupKey{messageID, actionName})
+}
+
+// record adds or refreshes an entry, the caller holds the lock
+func (d *ResponseDeduplicator) record(key dedupKey) {
+	expiresAt := d.now().Add(d.ttl)
+
+	if element, ok := d.entries[key]; ok {
+		element.Value.(*dedupEntry).expiresAt = expiresAt
+		d.order.MoveToFront(element)
+		return
+	}
+
+	d.entries[key] = d.order.PushFront(&dedupEntry{key: key, expiresAt: expiresAt})
+	for d.order.Len() > d.capacity {
+		d.remove(d.order.Back())
+	}
+}
+
+// Len returns the number of remembered responses
+func (d *ResponseDeduplicator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.order.Len()
+}
+
+// remove deletes an entry, the caller holds the lock
+func (d *ResponseDeduplicator) remove(element *list.Element) {
+	d.order.Remove(element)
+	delete(d.entries, element.Value.(*dedupEntry).key)
+}
@@ -0,0 +1,112 @@
+package action_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseDeduplicator(t *testing.T) {
+	dedup := action.NewResponseDeduplicator(2, 50*time.Millisecond)
+
+	assert.False(t, dedup.Seen("m1", "ping"))
+	dedup.Record("m1", "ping")
+	assert.True(t, dedup.Seen("m1", "ping"))
+	assert.False(t, dedup.Seen("m1", "help"))
+
+	// m1 was used most recently, so m2 is evicted by m3
+	dedup.Record("m2", "ping")
+	assert.True(t, dedup.Seen("m1", "ping"))
+	dedup.Record("m3", "ping")
+	assert.Equal(t, 2, dedup.Len())
+	assert.True(t, dedup.Seen("m1", "ping"))
+	assert.False(t, dedup.Seen("m2", "ping"))
+
+	time.Sleep(60 * time.Millisecond)
+	assert.False(t, dedup.Seen("m1", "ping"))
+	assert.Equal(t, 1, dedup.Len())
+}
+
+func TestResponseDeduplicator_Claim(t *testing.T) {
+	dedup := action.NewResponseDeduplicator(10, time.Minute)
+
+	claims := make(chan bool, 10)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claims <- dedup.Claim("m1", "ping")
+		}()
+	}
+	wg.Wait()
+	close(claims)
+
+	var claimed int
+	for ok := range claims {
+		if ok {
+			claimed++
+		}
+	}
+	assert.Equal(t, 1, claimed)
+
+	dedup.Forget("m1", "ping")
+	assert.True(t, dedup.Claim("m1", "ping"))
+}
+
+func TestManager_HandleMessage_SkipsDuplicates(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	newMessage := func(id string) *discordgo.MessageCreate {
+		return &discordgo.MessageCreate{
+			Message: &discordgo.Message{
+				ID:        id,
+				Content:   "!ping",
+				ChannelID: "channel123",
+				Author:    &discordgo.User{ID: "user123"},
+			},
+		}
+	}
+
+	// A failed response is not recorded, so the redelivered message is retried
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(nil, errors.New("gateway closed")).Once()
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(&discordgo.Message{}, nil).Twice()
+
+	require.Error(t, mgr.HandleMessage(context.Background(), session, newMessage("m1")))
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, newMessage("m1")))
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, newMessage("m1")))
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, newMessage("m2")))
+
+	session.AssertNumberOfCalls(t, "ChannelMessageSend", 3)
+}
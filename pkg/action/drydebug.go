@@ -0,0 +1,87 @@
+package action
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// runDryDebug simulates the handling of content sent by the owner in the same channel
+// and returns the matched action, the result of its conditions and its rendered response.
+// Nothing is sent and no rate limit or execution count is consumed.
+func (m *Manager) runDryDebug(session response.DiscordSession, message *discordgo.Message, prefix, content string) string {
+	if content == "" {
+		return fmt.Sprintf("Usage: %s%s <content>", prefix, dryDebugCommand)
+	}
+
+	simulated := *message
+	simulated.ID = ""
+	simulated.Content = content
+	simulated = *m.resolveMention(&discordgo.MessageCreate{Message: &simulated}).Message
+
+	action, ok := m.matchAction(simulated.Content)
+	if !ok {
+		return fmt.Sprintf("No action matches %q", content)
+	}
+	actionCfg := action.Config.ForGuild(simulated.GuildID)
+	m.logger.Info("Dry debug run by owner", "action", actionCfg.Name, "userID", message.Author.ID)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Action: %s (%s)\n", actionCfg.Name, actionCfg.Type)
+
+	if action.conditions == nil {
+		b.WriteString("Conditions: none\n")
+	} else {
+		b.WriteString("Conditions (bypassed by owners):\n")
+		for _, cond := range action.conditions.conditions {
+			ok, err := action.conditions.checkCondition(m, session, &simulated, cond)
+			switch {
+			case err != nil:
+				fmt.Fprintf(&b, "- error %s: %v\n", describeCondition(cond), err)
+			case ok:
+				fmt.Fprintf(&b, "- passed %s\n", describeCondition(cond))
+			default:
+				fmt.Fprintf(&b, "- failed %s\n", describeCondition(cond))
+			}
+		}
+	}
+
+	if _, ok := action.Handler.(Responder); ok {
+		fmt.Fprintf(&b, "Response: built-in %s handler", actionCfg.Type)
+		return b.String()
+	}
+
+	preview, err := response.Preview(actionCfg.Response, m.TemplateContext(&simulated))
+	if err != nil {
+		fmt.Fprintf(&b, "Response: failed to render: %v", err)
+		return b.String()
+	}
+	b.WriteString("Response:\n" + preview)
+	return b.String()
+}
+
+// matchAction returns the first enabled action matching the content
+func (m *Manager) matchAction(content string) (Action, bool) {
+	actions, index := m.dispatchActions()
+	for _, i := range index.candidates(content) {
+		action := actions[i]
+		if m.IsEnabled(action.Config.Name) && action.Handler.Matches(content) {
+			return action, true
+		}
+	}
+	return Action{}, false
+}
+
+// describeCondition formats a condition as its type, operator and value
+func describeCondition(cond condition) string {
+	parts := []string{cond.Type}
+	if cond.Operator != "" {
+		parts = append(parts, cond.Operator)
+	}
+	if cond.Value != "" {
+		parts = append(parts, fmt.Sprintf("%q", cond.Value))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,145 @@
+package action
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+)
+
+// SetDynamicActionsPath sets the file persisting the actions added and removed at runtime,
+// they are kept in memory when no path is set
+func (m *Manager) SetDynamicActionsPath(path string) {
+	m.dynamicMu.Lock()
+	defer m.dynamicMu.Unlock()
+	m.dynamicPath = path
+}
+
+// scheduledTypes need jobs registered when the bot starts, so they cannot be added at runtime
+var scheduledTypes = []string{"scheduled", "status_cycle", reminderType}
+
+// AddAction validates an action against the running configuration and loads it.
+// The action is persisted to the dynamic actions file when one is set.
+func (m *Manager) AddAction(actionCfg config.ActionConfig) error {
+	if err := m.loadAction(actionCfg); err != nil {
+		return err
+	}
+	return m.saveDynamicAction(actionCfg)
+}
+
+// loadAction validates an action against the running configuration and appends it to the actions
+func (m *Manager) loadAction(actionCfg config.ActionConfig) error {
+	if slices.Contains(scheduledTypes, actionCfg.Type) {
+		return fmt.Errorf("%s actions cannot be added at runtime, add them to the config file", actionCfg.Type)
+	}
+
+	m.actionsMu.Lock()
+
+	if slices.ContainsFunc(m.actions, func(action Action) bool { return action.Config.Name == actionCfg.Name }) {
+		m.actionsMu.Unlock()
+		return fmt.Errorf("action %s already exists", actionCfg.Name)
+	}
+
+	cfg := *m.cfg
+	cfg.Actions = append(slices.Clone(m.cfg.Actions), actionCfg)
+	if err := cfg.Validate(); err != nil {
+		m.actionsMu.Unlock()
+		return err
+	}
+
+	built, err := m.buildActions(&config.Config{Bot: cfg.Bot, Actions: []config.ActionConfig{actionCfg}})
+	if err != nil {
+		m.actionsMu.Unlock()
+		return err
+	}
+	if len(built) == 0 {
+		m.actionsMu.Unlock()
+		return fmt.Errorf("unsupported action type: %s", actionCfg.Type)
+	}
+
+	// The actions are replaced, messages being dispatched keep the previous list
+	m.cfg = &cfg
+	m.actions = append(slices.Clone(m.actions), built...)
+	m.index = newActionIndex(cfg.Bot.Prefix, m.actions)
+	m.actionsMu.Unlock()
+
+	if !actionCfg.IsEnabled() {
+		m.disabled.Store(actionCfg.Name, true)
+	}
+	m.logger.Info("Action added", "action", actionCfg.Name, "type", actionCfg.Type)
+	return nil
+}
+
+// saveDynamicAction adds an action to the dynamic actions file
+func (m *Manager) saveDynamicAction(actionCfg config.ActionConfig) error {
+	return m.updateDynamicActions(func(actions []config.ActionConfig) ([]config.ActionConfig, error) {
+		return config.MergeActions(actions, []config.ActionConfig{actionCfg}), nil
+	})
+}
+
+// RemoveAction deletes an action added at runtime and disables it until the next reload drops it.
+// Actions of the config file are not removed, a reload would bring them back.
+func (m *Manager) RemoveAction(name string) error {
+	if !m.hasAction(name) {
+		return boterrors.ActionNotFoundError{Name: name}
+	}
+
+	err := m.updateDynamicActions(func(actions []config.ActionConfig) ([]config.ActionConfig, error) {
+		if !slices.ContainsFunc(actions, func(action config.ActionConfig) bool { return action.Name == name }) {
+			return nil, fmt.Errorf("action %s is defined in the config file, disable it or remove it from the file", name)
+		}
+		return slices.DeleteFunc(actions, func(action config.ActionConfig) bool { return action.Name == name }), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return m.DisableAction(name)
+}
+
+// withDynamicActions returns a copy of the configuration with the actions added at runtime merged in
+func (m *Manager) withDynamicActions(cfg *config.Config) (*config.Config, error) {
+	m.dynamicMu.Lock()
+	dynamic, path := slices.Clone(m.dynamic), m.dynamicPath
+	m.dynamicMu.Unlock()
+
+	if path != "" {
+		var err error
+		if dynamic, err = config.LoadDynamicActions(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(dynamic) == 0 {
+		return cfg, nil
+	}
+
+	merged := *cfg
+	merged.Actions = config.MergeActions(cfg.Actions, dynamic)
+	return &merged, nil
+}
+
+// updateDynamicActions rewrites the dynamic actions with the result of update,
+// in the dynamic actions file or in memory when no file is set
+func (m *Manager) updateDynamicActions(update func([]config.ActionConfig) ([]config.ActionConfig, error)) error {
+	m.dynamicMu.Lock()
+	defer m.dynamicMu.Unlock()
+
+	if m.dynamicPath == "" {
+		actions, err := update(slices.Clone(m.dynamic))
+		if err != nil {
+			return err
+		}
+		m.dynamic = actions
+		return nil
+	}
+
+	actions, err := config.LoadDynamicActions(m.dynamicPath)
+	if err != nil {
+		return err
+	}
+	if actions, err = update(actions); err != nil {
+		return err
+	}
+	return config.SaveDynamicActions(m.dynamicPath, actions)
+}
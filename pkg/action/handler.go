@@ -6,24 +6,93 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
 
+	"github.com/alitto/pond/v2"
 	"github.com/bwmarrin/discordgo"
 	"github.com/geekxflood/common/logging"
+	"github.com/geekxflood/gxf-discord-bot/pkg/audit"
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/logs"
+	"github.com/geekxflood/gxf-discord-bot/pkg/ratelimit"
 	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/geekxflood/gxf-discord-bot/pkg/scheduler"
+	"github.com/geekxflood/gxf-discord-bot/pkg/stats"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
 )
 
 // Manager manages all bot actions
 type Manager struct {
-	actions []Action
-	cfg     *config.Config
-	logger  logging.Logger
+	actions     []Action
+	index       *actionIndex
+	cfg         *config.Config
+	actionsMu   sync.RWMutex
+	limitLocks  sync.Map
+	logger      logging.Logger
+	sampled     *logs.SampledLogger
+	disabled    sync.Map
+	auditLog    audit.AuditLog
+	store       storage.Store
+	prefs       storage.UserPrefs
+	reminders   storage.Reminders
+	counts      storage.ExecutionCounts
+	usage       *stats.CommandStats
+	rateLimiter *ratelimit.Limiter
+	scheduler   *scheduler.Scheduler
+	webhooks    webhookDeliveries
+	pool        pond.Pool
+	events      *EventBuffer
+	dedup       *ResponseDeduplicator
+	queue       *response.Queue
+	emojis      response.EmojiLookup
+	stickers    response.StickerLookup
+	metrics     *actionMetrics
+	guilds      *apiCache[*discordgo.Guild]
+	channels    *apiCache[*discordgo.Channel]
+	reload      ReloadFunc
+	dynamicPath string
+	dynamic     []config.ActionConfig
+	baseDir     string
+	dynamicMu   sync.Mutex
+	httpPolicy  atomic.Pointer[response.HTTPPolicy]
+	botUserID   atomic.Value
+	clock       Clock
+
+	listeners      map[int]EventListener
+	nextListenerID int
+	listenersMu    sync.RWMutex
+
+	// customTypes is guarded by actionsMu
+	customTypes map[string]HandlerFactory
+
+	// DryRun logs matched actions instead of executing their responses
+	DryRun bool
 }
 
+// Event describes a Discord event processed by the manager
+type Event struct {
+	Action    string
+	Trigger   string
+	UserID    string
+	ChannelID string
+	Matched   bool
+	Duration  time.Duration
+	Error     error
+}
+
+// EventListener receives processed events, it must not block
+type EventListener func(event Event)
+
 // Action represents a bot action
 type Action struct {
 	Config  config.ActionConfig
 	Handler Handler
+
+	conditions *conditionSet
 }
 
 // Handler is an interface for action handlers
@@ -32,6 +101,15 @@ type Handler interface {
 	Execute(ctx context.Context, session *discordgo.Session, message *discordgo.Message) error
 }
 
+// Responder is implemented by handlers that send their own response
+// instead of the configured one
+type Responder interface {
+	Respond(ctx context.Context, session response.DiscordSession, message *discordgo.Message) error
+}
+
+// HandlerFactory creates the handler of a custom action type
+type HandlerFactory func(prefix string, cfg config.ActionConfig) (Handler, error)
+
 // CommandHandler handles command-based actions
 type CommandHandler struct {
 	prefix  string
@@ -48,21 +126,138 @@ type ReactionHandler struct {
 	emoji string
 }
 
+// StatusCycleHandler rotates through a list of bot statuses
+type StatusCycleHandler struct {
+	statuses []string
+	index    int
+	mu       sync.Mutex
+}
+
+// snowflakePattern matches Discord snowflake IDs
+var snowflakePattern = regexp.MustCompile(`^[0-9]{17,20}$`)
+
 // NewManager creates a new action manager
 func NewManager(cfg *config.Config, logger logging.Logger) (*Manager, error) {
 	logger.Info("Initializing action manager", "actionCount", len(cfg.Actions))
 
 	mgr := &Manager{
-		actions: make([]Action, 0),
-		cfg:     cfg,
-		logger:  logger,
+		actions:     make([]Action, 0),
+		cfg:         cfg,
+		logger:      logger,
+		sampled:     logs.NewSampledLogger(logger, sampleRate(cfg)),
+		auditLog:    audit.NoopAuditLog{},
+		store:       storage.NoopStore{},
+		prefs:       storage.NewMemoryPrefs(),
+		reminders:   storage.NewMemoryReminders(),
+		counts:      storage.NewMemoryExecutionCounts(),
+		usage:       stats.New(),
+		listeners:   make(map[int]EventListener),
+		customTypes: make(map[string]HandlerFactory),
+		pool:        newWorkerPool(cfg.Bot.Workers),
+		dedup:       NewResponseDeduplicator(defaultDedupCapacity, defaultDedupTTL),
+		metrics:     newActionMetrics(),
+		guilds:      newGuildCache(),
+		channels:    newChannelCache(),
+		clock:       systemClock{},
+	}
+
+	if cfg.Bot.ReplayBuffer > 0 {
+		mgr.events = NewEventBuffer(cfg.Bot.ReplayBuffer)
 	}
 
-	// Initialize actions
+	policy, err := response.NewHTTPPolicy(cfg.Bot.HTTPAllowlist, cfg.Bot.HTTPDenylist)
+	if err != nil {
+		return nil, err
+	}
+	mgr.httpPolicy.Store(policy)
+
+	actions, err := mgr.buildActions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	mgr.actions = actions
+	mgr.index = newActionIndex(cfg.Bot.Prefix, actions)
+	mgr.applyConfigDisabled(cfg)
+
+	logger.Info("Action manager initialized", "loadedActions", len(mgr.actions))
+	return mgr, nil
+}
+
+// sampleRate returns the sampling of the logs written for every message
+func sampleRate(cfg *config.Config) int {
+	if cfg.Logging == nil {
+		return 0
+	}
+	return cfg.Logging.SampleRate
+}
+
+// DroppedLogs returns the number of per-message logs dropped by sampling
+func (m *Manager) DroppedLogs() int64 {
+	return m.sampled.DroppedCount()
+}
+
+// Reload replaces the actions with the ones of a new configuration.
+// Actions are enabled or disabled as configured, runtime changes are discarded.
+// Actions added at runtime are kept until they are removed with RemoveAction.
+func (m *Manager) Reload(cfg *config.Config) error {
+	cfg, err := m.withDynamicActions(cfg)
+	if err != nil {
+		return err
+	}
+
+	policy, err := response.NewHTTPPolicy(cfg.Bot.HTTPAllowlist, cfg.Bot.HTTPDenylist)
+	if err != nil {
+		return err
+	}
+
+	// The custom action types are read while building, RegisterHandler writes them under the same lock
+	m.actionsMu.Lock()
+	actions, err := m.buildActions(cfg)
+	if err != nil {
+		m.actionsMu.Unlock()
+		return err
+	}
+	m.httpPolicy.Store(policy)
+
+	// Actions disabled by the new configuration never run with it
+	m.applyConfigDisabled(cfg)
+
+	m.cfg = cfg
+	m.actions = actions
+	m.index = newActionIndex(cfg.Bot.Prefix, actions)
+	m.actionsMu.Unlock()
+
+	m.logger.Info("Actions reloaded", "loadedActions", len(actions))
+	return nil
+}
+
+// buildActions creates the handlers of the configured actions
+func (m *Manager) buildActions(cfg *config.Config) ([]Action, error) {
+	actions := make([]Action, 0, len(cfg.Actions))
+	seen := make(map[string]bool, len(cfg.Actions))
+
 	for _, actionCfg := range cfg.Actions {
 		var handler Handler
 		var err error
 
+		// Rate limits, execution counts and usage are kept by action name
+		if seen[actionCfg.Name] {
+			return nil, fmt.Errorf("duplicate action name: %q", actionCfg.Name)
+		}
+		seen[actionCfg.Name] = true
+
+		if embed := actionCfg.Response.Embed; embed != nil && embed.ColorName != "" {
+			if _, err := response.ParseColor(embed.ColorName); err != nil {
+				return nil, fmt.Errorf("invalid embed color for %s: %w", actionCfg.Name, err)
+			}
+		}
+
+		for guildID := range actionCfg.GuildOverrides {
+			if !snowflakePattern.MatchString(guildID) {
+				return nil, fmt.Errorf("invalid guild ID %q in overrides for %s", guildID, actionCfg.Name)
+			}
+		}
+
 		switch actionCfg.Type {
 		case "command":
 			handler = NewCommandHandler(cfg.Bot.Prefix, actionCfg.Trigger.Command)
@@ -73,36 +268,149 @@ func NewManager(cfg *config.Config, logger logging.Logger) (*Manager, error) {
 			}
 		case "reaction":
 			handler = NewReactionHandler(actionCfg.Trigger.Emoji)
+		case reactionRoleType:
+			handler, err = NewReactionRoleHandler(actionCfg.Trigger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create reaction role handler for %s: %w", actionCfg.Name, err)
+			}
+		case setPrefType, getPrefType:
+			command := actionCfg.Trigger.Command
+			if command == "" {
+				command = actionCfg.Type
+			}
+			handler = newPrefsHandler(m, cfg.Bot.Prefix, command, actionCfg.Type == setPrefType)
+		case reminderType:
+			command := actionCfg.Trigger.Command
+			if command == "" {
+				command = "remindme"
+			}
+			handler = newReminderHandler(m, cfg.Bot.Prefix, command)
+		case voteTallyType:
+			command := actionCfg.Trigger.Command
+			if command == "" {
+				command = "tally"
+			}
+			handler = newVoteTallyHandler(cfg.Bot.Prefix, command, actionCfg.Trigger.MaxEmojis)
+		case "status_cycle":
+			handler, err = NewStatusCycleHandler(actionCfg.Trigger.Statuses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create status cycle handler for %s: %w", actionCfg.Name, err)
+			}
 		default:
-			logger.Debug("Unsupported action type", "type", actionCfg.Type, "name", actionCfg.Name)
-			continue
+			factory, ok := m.customTypes[actionCfg.Type]
+			if !ok {
+				m.logger.Debug("Unsupported action type", "type", actionCfg.Type, "name", actionCfg.Name)
+				continue
+			}
+			handler, err = factory(cfg.Bot.Prefix, actionCfg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create %s handler for %s: %w", actionCfg.Type, actionCfg.Name, err)
+			}
 		}
 
-		mgr.actions = append(mgr.actions, Action{
-			Config:  actionCfg,
-			Handler: handler,
+		conditions, err := compileConditions(cfg.Bot, actionCfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid conditions for %s: %w", actionCfg.Name, err)
+		}
+
+		actions = append(actions, Action{
+			Config:     actionCfg,
+			Handler:    handler,
+			conditions: conditions,
 		})
 	}
 
-	logger.Info("Action manager initialized", "loadedActions", len(mgr.actions))
-	return mgr, nil
+	return actions, nil
+}
+
+// applyConfigDisabled resets the runtime state of actions to the configuration.
+// Disabled actions are loaded so they can be enabled at runtime.
+func (m *Manager) applyConfigDisabled(cfg *config.Config) {
+	disabled := make(map[string]bool)
+	for _, actionCfg := range cfg.Actions {
+		if !actionCfg.IsEnabled() {
+			disabled[actionCfg.Name] = true
+			m.disabled.Store(actionCfg.Name, true)
+			m.logger.Debug("Action disabled by configuration", "name", actionCfg.Name)
+		}
+	}
+
+	// The other actions are enabled after the disabled ones are stored, so none runs in between
+	m.disabled.Range(func(name, _ any) bool {
+		if !disabled[name.(string)] {
+			m.disabled.Delete(name)
+		}
+		return true
+	})
+}
+
+// loadedActions returns the current actions
+func (m *Manager) loadedActions() []Action {
+	m.actionsMu.RLock()
+	defer m.actionsMu.RUnlock()
+	return m.actions
+}
+
+// dispatchActions returns the current actions with their index
+func (m *Manager) dispatchActions() ([]Action, *actionIndex) {
+	m.actionsMu.RLock()
+	defer m.actionsMu.RUnlock()
+	return m.actions, m.index
 }
 
 // HandleMessage handles incoming messages
 func (m *Manager) HandleMessage(ctx context.Context, session response.DiscordSession, message *discordgo.MessageCreate) error {
-	for _, action := range m.actions {
+	// Replayed messages may already have been handled before a reconnect
+	if m.events != nil && message.ID != "" && !m.events.MarkProcessed(message.ID) {
+		return nil
+	}
+
+	message = m.resolveMention(message)
+
+	if handled, err := m.handleOwnerCommand(session, message.Message); handled {
+		return err
+	}
+
+	start := time.Now()
+	event := Event{
+		Trigger:   "message",
+		UserID:    message.Author.ID,
+		ChannelID: message.ChannelID,
+	}
+
+	actions, index := m.dispatchActions()
+	for _, i := range index.candidates(message.Content) {
+		action := actions[i]
+		if !m.IsEnabled(action.Config.Name) {
+			continue
+		}
 		if action.Handler.Matches(message.Content) {
-			m.logger.Debug("Action matched", "action", action.Config.Name, "content", message.Content)
+			m.sampled.Debug("Action matched", "action", action.Config.Name, "content", message.Content)
 
-			// Execute response
-			if err := response.Execute(ctx, session, message.Message, action.Config.Response, m.logger); err != nil {
-				m.logger.Error("Failed to execute response", "action", action.Config.Name, "error", err)
-				return fmt.Errorf("failed to execute response for action %s: %w", action.Config.Name, err)
+			// Messages can be delivered twice around a gateway reconnect, the response
+			// is claimed before executing so concurrent deliveries respond once
+			if message.ID != "" && !m.dedup.Claim(message.ID, action.Config.Name) {
+				m.sampled.Debug("Skipping duplicate message", "action", action.Config.Name, "messageID", message.ID)
+				return nil
 			}
 
-			return nil
+			err := m.executeAction(ctx, session, message.Message, action)
+			if err != nil && message.ID != "" {
+				m.dedup.Forget(message.ID, action.Config.Name)
+			}
+
+			event.Action = action.Config.Name
+			event.Matched = true
+			event.Duration = time.Since(start)
+			event.Error = err
+			m.publish(event)
+
+			return err
 		}
 	}
+
+	event.Duration = time.Since(start)
+	m.publish(event)
 	return nil
 }
 
@@ -114,8 +422,34 @@ type DiscordSessionExtended interface {
 
 // HandleReaction handles reaction events
 func (m *Manager) HandleReaction(ctx context.Context, session DiscordSessionExtended, reaction *discordgo.MessageReactionAdd) error {
+	start := time.Now()
+	event := Event{
+		Trigger:   "reaction",
+		UserID:    reaction.UserID,
+		ChannelID: reaction.ChannelID,
+	}
+
 	emojiName := reaction.Emoji.Name
-	for _, action := range m.actions {
+	for _, action := range m.loadedActions() {
+		if !m.IsEnabled(action.Config.Name) {
+			continue
+		}
+		if action.Config.Type == reactionRoleType {
+			if !action.Handler.(*ReactionRoleHandler).matchesReaction(reaction.MessageID, reaction.Emoji) {
+				continue
+			}
+
+			m.logger.Debug("Reaction role added", "action", action.Config.Name, "emoji", emojiName)
+			err := m.handleReactionRole(session, action, reaction.GuildID, reaction.UserID, true)
+
+			event.Action = action.Config.Name
+			event.Matched = true
+			event.Duration = time.Since(start)
+			event.Error = err
+			m.publish(event)
+
+			return err
+		}
 		if action.Config.Type == "reaction" && action.Handler.Matches(emojiName) {
 			m.logger.Debug("Reaction action matched", "action", action.Config.Name, "emoji", emojiName)
 
@@ -126,27 +460,391 @@ func (m *Manager) HandleReaction(ctx context.Context, session DiscordSessionExte
 				return fmt.Errorf("failed to get message: %w", err)
 			}
 
-			// Execute response
-			if err := response.Execute(ctx, session, msg, action.Config.Response, m.logger); err != nil {
-				m.logger.Error("Failed to execute response", "action", action.Config.Name, "error", err)
-				return fmt.Errorf("failed to execute response for action %s: %w", action.Config.Name, err)
+			err = m.executeAction(ctx, session, msg, action)
+
+			event.Action = action.Config.Name
+			event.Matched = true
+			event.Duration = time.Since(start)
+			event.Error = err
+			m.publish(event)
+
+			return err
+		}
+	}
+
+	event.Duration = time.Since(start)
+	m.publish(event)
+	return nil
+}
+
+// AddListener registers a listener for processed events and returns a function removing it
+func (m *Manager) AddListener(listener EventListener) func() {
+	m.listenersMu.Lock()
+	defer m.listenersMu.Unlock()
+
+	id := m.nextListenerID
+	m.nextListenerID++
+	m.listeners[id] = listener
+
+	return func() {
+		m.listenersMu.Lock()
+		defer m.listenersMu.Unlock()
+		delete(m.listeners, id)
+	}
+}
+
+// publish sends an event to all registered listeners
+func (m *Manager) publish(event Event) {
+	m.listenersMu.RLock()
+	defer m.listenersMu.RUnlock()
+
+	for _, listener := range m.listeners {
+		listener(event)
+	}
+}
+
+// executeAction executes the response of a matched action
+func (m *Manager) executeAction(ctx context.Context, session response.DiscordSession, message *discordgo.Message, action Action) error {
+	actionCfg := action.Config.ForGuild(message.GuildID)
+
+	executionStart := time.Now()
+	defer func() {
+		m.metrics.observe(actionCfg.Name, actionCfg.Response.Type, time.Since(executionStart))
+	}()
+
+	if m.queue != nil {
+		session = m.queue.Wrap(session)
+	}
+
+	if helpRequested(action, message.Content) {
+		return m.sendHelp(session, message, actionCfg)
+	}
+
+	// Bot owners bypass the conditions and rate limits of every action
+	owner := m.isOwnerMessage(message)
+
+	var failed *condition
+	var err error
+	if !owner {
+		failed, err = action.conditions.check(m, session, message)
+		if err != nil {
+			return fmt.Errorf("failed to check conditions for action %s: %w", actionCfg.Name, err)
+		}
+	}
+	if failed != nil {
+		m.sampled.Debug("Action conditions not met", "action", actionCfg.Name, "condition", failed.Type, "channelID", message.ChannelID)
+		if reply := failed.failureMessage(actionCfg.Type == "command"); reply != "" && !m.DryRun {
+			if _, err := session.ChannelMessageSend(message.ChannelID, reply); err != nil {
+				return fmt.Errorf("failed to send condition message: %w", boterrors.FromDiscord(err))
 			}
+		}
+		return nil
+	}
+
+	if owner {
+		m.sampled.Debug("Owner bypassing conditions and rate limits", "action", actionCfg.Name)
+	} else if err := m.checkRateLimit(actionCfg, message); err != nil {
+		m.sampled.Debug("Action rate limited", "action", actionCfg.Name, "channelID", message.ChannelID)
+		return err
+	}
 
+	// Executions of a limited action are serialized so the limit is never exceeded,
+	// other actions are not held up
+	limited := actionCfg.MaxExecutions > 0
+	if limited {
+		lock := m.limitLock(actionCfg.Name)
+		lock.Lock()
+		defer lock.Unlock()
+
+		if m.executionLimitReached(actionCfg) {
+			m.sampled.Debug("Action reached its execution limit", "action", actionCfg.Name)
 			return nil
 		}
 	}
+
+	if m.DryRun {
+		m.logger.Info("DRY RUN: would execute action", "action", actionCfg.Name, "response", actionCfg.Response.Type)
+		return nil
+	}
+
+	start := time.Now()
+	if responder, ok := action.Handler.(Responder); ok {
+		err = responder.Respond(ctx, session, message)
+	} else {
+		err = response.ExecuteWithContext(ctx, session, message, actionCfg.Response, m.TemplateContext(message), m.logger)
+	}
+
+	entry := audit.AuditEntry{
+		Timestamp:      start,
+		ActionName:     actionCfg.Name,
+		GuildID:        message.GuildID,
+		ChannelID:      message.ChannelID,
+		TriggerContent: message.Content,
+		ResponseType:   actionCfg.Response.Type,
+		Duration:       time.Since(start),
+	}
+	if message.Author != nil {
+		entry.UserID = message.Author.ID
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	m.auditLog.Log(entry)
+
+	if entry.UserID != "" {
+		if usageErr := m.usage.Record(actionCfg.Name, entry.UserID); usageErr != nil {
+			m.logger.Warn("Failed to store action usage", "action", actionCfg.Name, "error", usageErr)
+		}
+	}
+
+	if storeErr := m.store.SaveExecution(storage.ActionExecution{
+		Timestamp:    entry.Timestamp,
+		ActionName:   entry.ActionName,
+		UserID:       entry.UserID,
+		GuildID:      entry.GuildID,
+		ChannelID:    entry.ChannelID,
+		ResponseType: entry.ResponseType,
+		Duration:     entry.Duration,
+		Error:        entry.Error,
+	}); storeErr != nil {
+		m.logger.Warn("Failed to store action execution", "action", actionCfg.Name, "error", storeErr)
+	}
+
+	if err != nil {
+		retrying := actionCfg.Response.Type == "webhook" && m.trackWebhookFailure(session, message, actionCfg, err)
+		if !retrying {
+			m.deadLetter(session, message, actionCfg, err)
+		}
+		m.logger.Error("Failed to execute response", "action", actionCfg.Name, "error", err)
+		return fmt.Errorf("failed to execute response for action %s: %w", actionCfg.Name, err)
+	}
+
+	if limited {
+		return m.recordExecution(session, message, actionCfg)
+	}
 	return nil
 }
 
-// GetActions returns all registered actions
+// TemplateContext creates the template context of a response to the message
+func (m *Manager) TemplateContext(message *discordgo.Message) *response.TemplateContext {
+	data := response.NewTemplateContext(message)
+	data.Prefs = m.prefs.Prefs(data.UserID)
+	data.Emojis = m.emojis
+	data.Stickers = m.stickers
+	data.HTTPPolicy = m.httpPolicy.Load()
+	data.BaseDir = m.baseDir
+	data.BotUserID, _ = m.botUserID.Load().(string)
+	return data
+}
+
+// checkRateLimit returns a RateLimitedError when the message exceeds the per-action rate limit
+func (m *Manager) checkRateLimit(actionCfg config.ActionConfig, message *discordgo.Message) error {
+	limit := actionCfg.RateLimit
+	if m.rateLimiter == nil || limit == nil {
+		return nil
+	}
+
+	var key string
+	switch limit.Scope {
+	case "channel":
+		key = message.ChannelID
+	case "guild":
+		key = message.GuildID
+	case "global":
+		key = ""
+	case "thread":
+		// Messages sent in a thread carry the thread as their channel, message.Thread is a thread started from the message
+		key = message.ChannelID
+	case "user+channel":
+		if message.Author != nil {
+			key = message.Author.ID + ":" + message.ChannelID
+		}
+	default:
+		if message.Author != nil {
+			key = message.Author.ID
+		}
+	}
+
+	if m.rateLimiter.AllowAction(actionCfg.Name, key, limit.Requests, time.Duration(limit.Window)*time.Second) {
+		return nil
+	}
+	return boterrors.RateLimitedError{
+		Action:     actionCfg.Name,
+		RetryAfter: m.rateLimiter.ActionRetryAfter(actionCfg.Name, key),
+	}
+}
+
+// SetRateLimiter sets the limiter enforcing per-action rate limits
+func (m *Manager) SetRateLimiter(limiter *ratelimit.Limiter) {
+	m.rateLimiter = limiter
+}
+
+// SetAuditLog sets the audit log recording action executions
+func (m *Manager) SetAuditLog(auditLog audit.AuditLog) {
+	m.auditLog = auditLog
+}
+
+// SetStore sets the store persisting the execution history
+func (m *Manager) SetStore(store storage.Store) {
+	m.store = store
+}
+
+// SetQueue sets the queue pacing the messages sent by actions per channel
+func (m *Manager) SetQueue(queue *response.Queue) {
+	m.queue = queue
+}
+
+// SetEmojis sets the registry resolving guild emojis in templates
+func (m *Manager) SetEmojis(emojis response.EmojiLookup) {
+	m.emojis = emojis
+}
+
+// Clock returns the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock returning the system time
+type systemClock struct{}
+
+// Now returns the system time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SetClock sets the clock of time conditions, the system time is used by default
+func (m *Manager) SetClock(clock Clock) {
+	m.clock = clock
+}
+
+// SetStickers sets the registry resolving guild stickers in templates
+func (m *Manager) SetStickers(stickers response.StickerLookup) {
+	m.stickers = stickers
+}
+
+// SetBaseDir sets the directory resolving the relative file paths of responses
+func (m *Manager) SetBaseDir(dir string) {
+	m.baseDir = dir
+}
+
+// SetPrefs sets the store of user preferences, they are kept in memory by default
+func (m *Manager) SetPrefs(prefs storage.UserPrefs) {
+	m.prefs = prefs
+}
+
+// Stats returns the usage statistics of the actions
+func (m *Manager) Stats() *stats.CommandStats {
+	return m.usage
+}
+
+// RegisterHandler registers a custom action type and loads the configured actions using it
+func (m *Manager) RegisterHandler(actionType string, factory HandlerFactory) error {
+	switch actionType {
+	case "", "command", "message", "reaction", reactionRoleType, "status_cycle", setPrefType, getPrefType, reminderType, voteTallyType:
+		return fmt.Errorf("cannot register built-in action type: %q", actionType)
+	}
+
+	m.actionsMu.Lock()
+	defer m.actionsMu.Unlock()
+
+	if _, exists := m.customTypes[actionType]; exists {
+		return fmt.Errorf("action type already registered: %s", actionType)
+	}
+	m.customTypes[actionType] = factory
+
+	for _, actionCfg := range m.cfg.Actions {
+		if actionCfg.Type != actionType {
+			continue
+		}
+
+		handler, err := factory(m.cfg.Bot.Prefix, actionCfg)
+		if err != nil {
+			return fmt.Errorf("failed to create %s handler for %s: %w", actionType, actionCfg.Name, err)
+		}
+
+		conditions, err := compileConditions(m.cfg.Bot, actionCfg)
+		if err != nil {
+			return fmt.Errorf("invalid conditions for %s: %w", actionCfg.Name, err)
+		}
+
+		m.actions = append(m.actions, Action{
+			Config:     actionCfg,
+			Handler:    handler,
+			conditions: conditions,
+		})
+	}
+	m.index = newActionIndex(m.cfg.Bot.Prefix, m.actions)
+
+	m.logger.Info("Action type registered", "type", actionType)
+	return nil
+}
+
+// GetActions returns all registered actions with Enabled reflecting their runtime state
 func (m *Manager) GetActions() []config.ActionConfig {
-	actions := make([]config.ActionConfig, len(m.actions))
-	for i, action := range m.actions {
+	loaded := m.loadedActions()
+	actions := make([]config.ActionConfig, len(loaded))
+	for i, action := range loaded {
+		enabled := m.IsEnabled(action.Config.Name)
 		actions[i] = action.Config
+		actions[i].Enabled = &enabled
 	}
 	return actions
 }
 
+// NextStatus returns the next status of a status_cycle action in round-robin order
+func (m *Manager) NextStatus(name string) (string, error) {
+	for _, action := range m.loadedActions() {
+		if action.Config.Name != name {
+			continue
+		}
+
+		handler, ok := action.Handler.(*StatusCycleHandler)
+		if !ok {
+			return "", fmt.Errorf("action %s is not a status cycle action", name)
+		}
+		return handler.Next(), nil
+	}
+	return "", boterrors.ActionNotFoundError{Name: name}
+}
+
+// EnableAction re-enables a previously disabled action at runtime
+func (m *Manager) EnableAction(name string) error {
+	if !m.hasAction(name) {
+		return boterrors.ActionNotFoundError{Name: name}
+	}
+
+	m.disabled.Delete(name)
+	m.logger.Info("Action enabled", "action", name)
+	return nil
+}
+
+// DisableAction disables an action at runtime without removing it
+func (m *Manager) DisableAction(name string) error {
+	if !m.hasAction(name) {
+		return boterrors.ActionNotFoundError{Name: name}
+	}
+
+	m.disabled.Store(name, true)
+	m.logger.Info("Action disabled", "action", name)
+	return nil
+}
+
+// IsEnabled returns whether the named action is currently enabled
+func (m *Manager) IsEnabled(name string) bool {
+	_, disabled := m.disabled.Load(name)
+	return !disabled
+}
+
+// hasAction checks if an action with the given name is registered
+func (m *Manager) hasAction(name string) bool {
+	for _, action := range m.loadedActions() {
+		if action.Config.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // NewCommandHandler creates a new command handler
 func NewCommandHandler(prefix, command string) *CommandHandler {
 	return &CommandHandler{
@@ -176,18 +874,20 @@ func (h *CommandHandler) Matches(content string) bool {
 	return cmd == h.command
 }
 
-// ExtractArgs extracts arguments from the command
+// ExtractArgs extracts arguments from the command.
+// Quoted arguments such as "spamming links" are returned as one argument without the quotes.
 func (h *CommandHandler) ExtractArgs(content string) []string {
 	content = strings.TrimSpace(content)
 	content = strings.TrimPrefix(content, h.prefix)
 	content = strings.TrimSpace(content)
 
-	parts := strings.Fields(content)
-	if len(parts) <= 1 {
+	// Skip the command, the first word
+	i := strings.IndexFunc(content, unicode.IsSpace)
+	if i < 0 {
 		return []string{}
 	}
 
-	return parts[1:]
+	return splitArgs(content[i:])
 }
 
 // Execute executes the command handler
@@ -236,3 +936,35 @@ func (h *ReactionHandler) Execute(ctx context.Context, session *discordgo.Sessio
 	// TODO: Implement reaction execution
 	return nil
 }
+
+// NewStatusCycleHandler creates a new status cycle handler
+func NewStatusCycleHandler(statuses []string) (*StatusCycleHandler, error) {
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("status cycle requires at least one status")
+	}
+
+	return &StatusCycleHandler{
+		statuses: statuses,
+	}, nil
+}
+
+// Matches never matches messages, status cycles are driven by the scheduler
+func (h *StatusCycleHandler) Matches(content string) bool {
+	return false
+}
+
+// Next returns the next status and advances the cycle
+func (h *StatusCycleHandler) Next() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	status := h.statuses[h.index]
+	h.index = (h.index + 1) % len(h.statuses)
+	return status
+}
+
+// Execute executes the status cycle handler
+func (h *StatusCycleHandler) Execute(ctx context.Context, session *discordgo.Session, message *discordgo.Message) error {
+	// Status updates are applied by the bot on each scheduler tick
+	return nil
+}
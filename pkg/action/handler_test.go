@@ -2,12 +2,28 @@ package action_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/common/logging"
 	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
 	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/audit"
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/ratelimit"
+	"github.com/geekxflood/gxf-discord-bot/pkg/scheduler"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -230,6 +246,30 @@ func TestMessageHandler_InvalidPattern(t *testing.T) {
 	assert.Nil(t, handler)
 }
 
+func TestNewManager_InvalidRegex(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "greeting",
+				Type:     "message",
+				Trigger:  config.TriggerConfig{Pattern: "[invalid("},
+				Response: config.ResponseConfig{Type: "text", Content: "Hello!"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+
+	require.Error(t, err)
+	assert.Nil(t, mgr)
+	assert.ErrorContains(t, err, "invalid regex pattern")
+	assert.ErrorContains(t, err, "greeting")
+}
+
 func TestReactionHandler_Match(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -383,3 +423,1071 @@ func TestManager_GetActions(t *testing.T) {
 	actions := mgr.GetActions()
 	assert.Len(t, actions, 2)
 }
+
+func TestManager_EnableDisableAction(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "ping",
+				Type: "command",
+				Trigger: config.TriggerConfig{
+					Command: "ping",
+				},
+				Response: config.ResponseConfig{
+					Type:    "text",
+					Content: "Pong!",
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	require.NoError(t, mgr.DisableAction("ping"))
+	assert.False(t, mgr.IsEnabled("ping"))
+
+	// Disabled actions do not respond
+	session := &testutil.MockDiscordSession{}
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "!ping",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+	err = mgr.HandleMessage(context.Background(), session, message)
+	assert.NoError(t, err)
+	session.AssertNotCalled(t, "ChannelMessageSend", mock.Anything, mock.Anything)
+
+	require.NoError(t, mgr.EnableAction("ping"))
+	assert.True(t, mgr.IsEnabled("ping"))
+
+	assert.ErrorAs(t, mgr.DisableAction("unknown"), &boterrors.ActionNotFoundError{})
+}
+
+func TestManager_NextStatus(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "rotate",
+				Type: "status_cycle",
+				Trigger: config.TriggerConfig{
+					Schedule: "0 */5 * * * *",
+					Statuses: []string{"one", "two", "three"},
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	for _, expected := range []string{"one", "two", "three", "one"} {
+		status, err := mgr.NextStatus("rotate")
+		require.NoError(t, err)
+		assert.Equal(t, expected, status)
+	}
+
+	_, err = mgr.NextStatus("unknown")
+	assert.Error(t, err)
+}
+
+func TestNewManager_StatusCycleWithoutStatuses(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "rotate",
+				Type: "status_cycle",
+				Trigger: config.TriggerConfig{
+					Schedule: "0 */5 * * * *",
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	assert.Error(t, err)
+	assert.Nil(t, mgr)
+}
+
+func TestManager_HandleMessage_DryRun(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "ping",
+				Type: "command",
+				Trigger: config.TriggerConfig{
+					Command: "ping",
+				},
+				Response: config.ResponseConfig{
+					Type:    "text",
+					Content: "Pong!",
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+	mgr.DryRun = true
+
+	session := &testutil.MockDiscordSession{}
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "!ping",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+
+	err = mgr.HandleMessage(context.Background(), session, message)
+
+	assert.NoError(t, err)
+	session.AssertNotCalled(t, "ChannelMessageSend", mock.Anything, mock.Anything)
+	assert.Contains(t, logger.InfoMessages, "DRY RUN: would execute action")
+}
+
+// recordingAuditLog captures audit entries for assertions
+type recordingAuditLog struct {
+	entries []audit.AuditEntry
+}
+
+func (r *recordingAuditLog) Log(entry audit.AuditEntry) {
+	r.entries = append(r.entries, entry)
+}
+
+func (r *recordingAuditLog) Close() error {
+	return nil
+}
+
+func TestManager_HandleMessage_AuditLog(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "ping",
+				Type: "command",
+				Trigger: config.TriggerConfig{
+					Command: "ping",
+				},
+				Response: config.ResponseConfig{
+					Type:    "text",
+					Content: "Pong!",
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	auditLog := &recordingAuditLog{}
+	mgr.SetAuditLog(auditLog)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(&discordgo.Message{}, nil)
+
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "!ping",
+			GuildID:   "guild123",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+
+	err = mgr.HandleMessage(context.Background(), session, message)
+	require.NoError(t, err)
+
+	require.Len(t, auditLog.entries, 1)
+	entry := auditLog.entries[0]
+	assert.Equal(t, "ping", entry.ActionName)
+	assert.Equal(t, "123", entry.UserID)
+	assert.Equal(t, "guild123", entry.GuildID)
+	assert.Equal(t, "channel123", entry.ChannelID)
+	assert.Equal(t, "!ping", entry.TriggerContent)
+	assert.Equal(t, "text", entry.ResponseType)
+	assert.Empty(t, entry.Error)
+}
+
+func TestManager_HandleMessage_Store(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	store, err := storage.NewSQLiteStore(filepath.Join(t.TempDir(), "bot.db"))
+	require.NoError(t, err)
+	defer store.Close()
+	mgr.SetStore(store)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(&discordgo.Message{}, nil)
+
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "!ping",
+			GuildID:   "guild123",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, message))
+
+	executions, err := store.QueryExecutions(storage.ExecutionFilter{ActionName: "ping"})
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.Equal(t, "123", executions[0].UserID)
+	assert.Equal(t, "guild123", executions[0].GuildID)
+	assert.Equal(t, "text", executions[0].ResponseType)
+	assert.Empty(t, executions[0].Error)
+}
+
+func TestManager_HandleMessage_GuildOverride(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "ping",
+				Type: "command",
+				Trigger: config.TriggerConfig{
+					Command: "ping",
+				},
+				Response: config.ResponseConfig{
+					Type:    "text",
+					Content: "Pong!",
+				},
+				GuildOverrides: map[string]config.ActionOverride{
+					"123456789012345678": {Content: "Pong from guild!"},
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong from guild!").Return(&discordgo.Message{}, nil)
+
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "!ping",
+			GuildID:   "123456789012345678",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+
+	err = mgr.HandleMessage(context.Background(), session, message)
+
+	assert.NoError(t, err)
+	session.AssertExpectations(t)
+}
+
+func TestManager_HandleMessage_RateLimited(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "ping",
+				Type: "command",
+				Trigger: config.TriggerConfig{
+					Command: "ping",
+				},
+				Response: config.ResponseConfig{
+					Type:    "text",
+					Content: "Pong!",
+				},
+				RateLimit: &config.RateLimitConfig{Requests: 1, Window: 60, Scope: "user"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+	mgr.SetRateLimiter(ratelimit.New(logger))
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(&discordgo.Message{}, nil).Once()
+
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "!ping",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, message))
+
+	err = mgr.HandleMessage(context.Background(), session, message)
+	var limited boterrors.RateLimitedError
+	require.ErrorAs(t, err, &limited)
+	assert.Equal(t, "ping", limited.Action)
+	assert.Greater(t, limited.RetryAfter, 59*time.Second)
+
+	session.AssertNumberOfCalls(t, "ChannelMessageSend", 1)
+}
+
+func TestManager_HandleMessage_RateLimitScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   string
+		second  *discordgo.Message
+		limited bool
+	}{
+		{
+			name:    "user+channel same user and channel",
+			scope:   "user+channel",
+			second:  &discordgo.Message{ChannelID: "channel123", Author: &discordgo.User{ID: "123"}},
+			limited: true,
+		},
+		{
+			name:   "user+channel other channel",
+			scope:  "user+channel",
+			second: &discordgo.Message{ChannelID: "channel456", Author: &discordgo.User{ID: "123"}},
+		},
+		{
+			name:   "user+channel other user",
+			scope:  "user+channel",
+			second: &discordgo.Message{ChannelID: "channel123", Author: &discordgo.User{ID: "456"}},
+		},
+		{
+			name:    "thread same channel",
+			scope:   "thread",
+			second:  &discordgo.Message{ChannelID: "channel123", Author: &discordgo.User{ID: "456"}},
+			limited: true,
+		},
+		{
+			name:   "thread other thread",
+			scope:  "thread",
+			second: &discordgo.Message{ChannelID: "thread789", Author: &discordgo.User{ID: "123"}},
+		},
+		{
+			name:    "thread started from the message",
+			scope:   "thread",
+			second:  &discordgo.Message{ChannelID: "channel123", Thread: &discordgo.Channel{ID: "thread789"}, Author: &discordgo.User{ID: "123"}},
+			limited: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Bot: config.BotConfig{Prefix: "!"},
+				Actions: []config.ActionConfig{
+					{
+						Name:      "roll",
+						Type:      "command",
+						Trigger:   config.TriggerConfig{Command: "roll"},
+						Response:  config.ResponseConfig{Type: "text", Content: "4"},
+						RateLimit: &config.RateLimitConfig{Requests: 1, Window: 60, Scope: tt.scope},
+					},
+				},
+			}
+
+			logger := &testutil.MockLogger{}
+			logger.On("Info", mock.Anything, mock.Anything).Return()
+			logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+			mgr, err := action.NewManager(cfg, logger)
+			require.NoError(t, err)
+			mgr.SetRateLimiter(ratelimit.New(logger))
+
+			session := &testutil.MockDiscordSession{}
+			session.On("ChannelMessageSend", mock.Anything, "4").Return(&discordgo.Message{}, nil)
+
+			first := &discordgo.Message{Content: "!roll", ChannelID: "channel123", Author: &discordgo.User{ID: "123"}}
+			require.NoError(t, mgr.HandleMessage(context.Background(), session, &discordgo.MessageCreate{Message: first}))
+
+			tt.second.Content = "!roll"
+			err = mgr.HandleMessage(context.Background(), session, &discordgo.MessageCreate{Message: tt.second})
+			if !tt.limited {
+				assert.NoError(t, err)
+				return
+			}
+			var limited boterrors.RateLimitedError
+			assert.ErrorAs(t, err, &limited)
+		})
+	}
+}
+
+func TestNewManager_InvalidGuildOverride(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+				GuildOverrides: map[string]config.ActionOverride{
+					"my-guild": {Content: "Pong!"},
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+
+	assert.Error(t, err)
+	assert.Nil(t, mgr)
+	assert.Contains(t, err.Error(), "invalid guild ID")
+}
+
+func TestManager_WebhookDeliveryTracking(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "alert",
+				Type: "command",
+				Trigger: config.TriggerConfig{
+					Command: "alert",
+				},
+				Response: config.ResponseConfig{
+					Type:       "webhook",
+					WebhookURL: server.URL,
+					Content:    "Alert!",
+					MaxRetries: 2,
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	sched := scheduler.New(logger)
+	mgr.SetScheduler(sched)
+	require.NoError(t, sched.Start())
+	defer func() { _ = sched.Stop() }()
+
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "!alert",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+
+	err = mgr.HandleMessage(context.Background(), &testutil.MockDiscordSession{}, message)
+	require.Error(t, err)
+
+	stats := mgr.WebhookDeliveryStats()
+	require.Len(t, stats, 1)
+	for _, attempt := range stats {
+		assert.Equal(t, "alert", attempt.Action)
+		assert.Equal(t, 1, attempt.Attempts)
+		assert.Contains(t, attempt.LastError, "status 502")
+		assert.True(t, attempt.NextRetry.After(time.Now()))
+	}
+}
+
+func TestNewManager_InvalidEmbedColor(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:    "info",
+				Type:    "command",
+				Trigger: config.TriggerConfig{Command: "info"},
+				Response: config.ResponseConfig{
+					Type:  "embed",
+					Embed: &config.EmbedConfig{Title: "Info", ColorName: "rainbow"},
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+
+	assert.Error(t, err)
+	assert.Nil(t, mgr)
+	assert.Contains(t, err.Error(), "invalid embed color")
+}
+
+func TestNewManager_DuplicateActionName(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{Name: "ping", Type: "command", Trigger: config.TriggerConfig{Command: "ping"}, Response: config.ResponseConfig{Type: "text", Content: "pong"}},
+			{Name: "ping", Type: "command", Trigger: config.TriggerConfig{Command: "p"}, Response: config.ResponseConfig{Type: "text", Content: "pong"}},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+
+	assert.EqualError(t, err, `duplicate action name: "ping"`)
+	assert.Nil(t, mgr)
+}
+
+func TestNewManager_DisabledByConfig(t *testing.T) {
+	disabled := false
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+				Enabled:  &disabled,
+			},
+			{
+				Name:     "help",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "help"},
+				Response: config.ResponseConfig{Type: "text", Content: "Help"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	assert.False(t, mgr.IsEnabled("ping"))
+	assert.True(t, mgr.IsEnabled("help"))
+
+	actions := mgr.GetActions()
+	require.Len(t, actions, 2)
+	require.NotNil(t, actions[0].Enabled)
+	assert.False(t, *actions[0].Enabled)
+	require.NotNil(t, actions[1].Enabled)
+	assert.True(t, *actions[1].Enabled)
+
+	// Actions disabled in config can be enabled at runtime
+	require.NoError(t, mgr.EnableAction("ping"))
+	assert.True(t, *mgr.GetActions()[0].Enabled)
+}
+
+func TestManager_HandleMessage_DeadLetter(t *testing.T) {
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "ping",
+				Type: "command",
+				Trigger: config.TriggerConfig{
+					Command: "ping",
+				},
+				Response: config.ResponseConfig{
+					Type:    "text",
+					Content: "Pong!",
+				},
+				DeadLetterChannel: "deadletters",
+				DeadLetterWebhook: server.URL,
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	var embed *discordgo.MessageEmbed
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(nil, errors.New("missing permissions "+strings.Repeat("x", 2000)))
+	session.On("ChannelMessageSendEmbed", "deadletters", mock.Anything).Run(func(args mock.Arguments) {
+		embed = args.Get(1).(*discordgo.MessageEmbed)
+	}).Return(&discordgo.Message{}, nil)
+
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "!ping",
+			ChannelID: "channel123",
+			Author: &discordgo.User{
+				ID:       "123",
+				Username: "testuser",
+			},
+		},
+	}
+
+	_ = mgr.HandleMessage(context.Background(), session, message)
+
+	session.AssertExpectations(t)
+	require.NotNil(t, embed)
+	assert.Equal(t, "Action failed: ping", embed.Title)
+	require.Len(t, embed.Fields, 5)
+	assert.Equal(t, "<@123>", embed.Fields[1].Value)
+	assert.Equal(t, "`!ping`", embed.Fields[3].Value)
+	assert.Contains(t, embed.Fields[4].Value, "missing permissions")
+	assert.Equal(t, 1024, utf8.RuneCountInString(embed.Fields[4].Value))
+
+	select {
+	case body := <-received:
+		assert.Contains(t, string(body), "Action failed: ping")
+	case <-time.After(5 * time.Second):
+		t.Fatal("dead letter webhook not sent")
+	}
+}
+
+func TestManager_Submit(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix:  "!",
+			Workers: &config.WorkersConfig{PoolSize: 1, QueueCapacity: 1},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	require.NoError(t, mgr.Submit(func() { <-release }))
+	require.Eventually(t, func() bool {
+		return mgr.WorkerStats().RunningWorkers == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, mgr.Submit(func() {}))
+	assert.Error(t, mgr.Submit(func() {}), "queue is full")
+
+	close(release)
+	mgr.StopWorkers()
+}
+
+func TestManager_Submit_RecoversPanic(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix:  "!",
+			Workers: &config.WorkersConfig{PoolSize: 1, QueueCapacity: 2},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Error", "Recovered from panic in worker", mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	require.NoError(t, mgr.Submit(func() { panic("boom") }))
+	require.NoError(t, mgr.Submit(func() { close(done) }))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("task after the panic did not run")
+	}
+	mgr.StopWorkers()
+
+	logger.AssertCalled(t, "Error", "Recovered from panic in worker", mock.Anything)
+}
+
+func TestEventBuffer(t *testing.T) {
+	buffer := action.NewEventBuffer(2)
+
+	for _, id := range []string{"1", "2", "3"} {
+		buffer.Add(&discordgo.MessageCreate{Message: &discordgo.Message{ID: id}})
+	}
+
+	// The oldest message is evicted
+	pending := buffer.Pending()
+	require.Len(t, pending, 2)
+	assert.Equal(t, "2", pending[0].ID)
+	assert.Equal(t, "3", pending[1].ID)
+
+	assert.True(t, buffer.MarkProcessed("2"))
+	assert.False(t, buffer.MarkProcessed("2"))
+
+	pending = buffer.Pending()
+	require.Len(t, pending, 1)
+	assert.Equal(t, "3", pending[0].ID)
+}
+
+func TestEventBuffer_ForgetsOldProcessed(t *testing.T) {
+	buffer := action.NewEventBuffer(1)
+
+	// Two processed IDs are remembered per buffered message
+	for _, id := range []string{"1", "2", "3"} {
+		assert.True(t, buffer.MarkProcessed(id))
+	}
+	assert.False(t, buffer.MarkProcessed("3"))
+	assert.False(t, buffer.MarkProcessed("2"))
+	assert.True(t, buffer.MarkProcessed("1"))
+}
+
+func TestManager_HandleMessage_Replay(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix:       "!",
+			ReplayBuffer: 10,
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "ping",
+				Type: "command",
+				Trigger: config.TriggerConfig{
+					Command: "ping",
+				},
+				Response: config.ResponseConfig{
+					Type:    "text",
+					Content: "Pong!",
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(&discordgo.Message{}, nil)
+
+	handled := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			ID:        "1",
+			Content:   "!ping",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+	missed := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			ID:        "2",
+			Content:   "!ping",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+
+	mgr.RecordMessage(handled)
+	mgr.RecordMessage(missed)
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, handled))
+
+	pending := mgr.PendingMessages()
+	require.Len(t, pending, 1)
+	assert.Equal(t, "2", pending[0].ID)
+
+	// Replaying a message twice only handles it once
+	for _, message := range []*discordgo.MessageCreate{handled, missed, missed} {
+		require.NoError(t, mgr.HandleMessage(context.Background(), session, message))
+	}
+
+	session.AssertNumberOfCalls(t, "ChannelMessageSend", 2)
+	assert.Empty(t, mgr.PendingMessages())
+}
+
+func TestManager_Reload(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	disabled := false
+	err = mgr.Reload(&config.Config{
+		Bot: config.BotConfig{Prefix: "?"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong again!"},
+			},
+			{
+				Name:     "hello",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "hello"},
+				Response: config.ResponseConfig{Type: "text", Content: "Hi!"},
+				Enabled:  &disabled,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	actions := mgr.GetActions()
+	require.Len(t, actions, 2)
+	assert.False(t, mgr.IsEnabled("hello"))
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong again!").Return(&discordgo.Message{}, nil)
+
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "?ping",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "123"},
+		},
+	}
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, message))
+	session.AssertExpectations(t)
+
+	// An invalid configuration keeps the loaded actions
+	err = mgr.Reload(&config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{Name: "broken", Type: "message", Trigger: config.TriggerConfig{Pattern: "("}},
+		},
+	})
+	assert.Error(t, err)
+	assert.Len(t, mgr.GetActions(), 2)
+}
+
+func TestManager_RegisterHandlerDuringReload(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "roll",
+				Type:     "dice",
+				Trigger:  config.TriggerConfig{Command: "roll"},
+				Response: config.ResponseConfig{Type: "text", Content: "rolled"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	factory := func(prefix string, actionCfg config.ActionConfig) (action.Handler, error) {
+		return action.NewCommandHandler(prefix, actionCfg.Trigger.Command), nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, mgr.Reload(cfg))
+		}()
+	}
+	require.NoError(t, mgr.RegisterHandler("dice", factory))
+	wg.Wait()
+
+	assert.Error(t, mgr.RegisterHandler("dice", factory))
+}
+
+// benchSession answers sent messages without recording them, so benchmarks measure the dispatch
+type benchSession struct {
+	testutil.MockDiscordSession
+}
+
+// ChannelMessageSend returns an empty message
+func (s *benchSession) ChannelMessageSend(channelID, content string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return &discordgo.Message{}, nil
+}
+
+// newBenchManager creates a manager for the actions with a logger discarding debug and info messages
+func newBenchManager(b *testing.B, actions []config.ActionConfig) *action.Manager {
+	b.Helper()
+
+	logger, _, err := logging.NewLogger(logging.Config{Level: "error", Format: logging.FormatJSON, Output: "stderr"})
+	require.NoError(b, err)
+
+	mgr, err := action.NewManager(&config.Config{
+		Bot:     config.BotConfig{Prefix: "!"},
+		Actions: actions,
+	}, logger)
+	require.NoError(b, err)
+
+	return mgr
+}
+
+// benchMessage creates a guild message from a regular user
+func benchMessage(content string) *discordgo.MessageCreate {
+	return &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   content,
+			ChannelID: "channel123",
+			GuildID:   "guild123",
+			Author: &discordgo.User{
+				ID:       "123",
+				Username: "testuser",
+			},
+		},
+	}
+}
+
+// commandAction creates a text command action
+func commandAction(name string) config.ActionConfig {
+	return config.ActionConfig{
+		Name:     name,
+		Type:     "command",
+		Trigger:  config.TriggerConfig{Command: name},
+		Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+	}
+}
+
+// runHandleMessage calls HandleMessage in the benchmark loop
+func runHandleMessage(b *testing.B, mgr *action.Manager, message *discordgo.MessageCreate) {
+	b.Helper()
+
+	ctx := context.Background()
+	session := &benchSession{}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := mgr.HandleMessage(ctx, session, message); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHandleMessage_NoMatch(b *testing.B) {
+	mgr := newBenchManager(b, []config.ActionConfig{commandAction("ping")})
+	runHandleMessage(b, mgr, benchMessage("just chatting about the weather"))
+}
+
+func BenchmarkHandleMessage_CommandMatch(b *testing.B) {
+	mgr := newBenchManager(b, []config.ActionConfig{commandAction("ping")})
+	runHandleMessage(b, mgr, benchMessage("!ping"))
+}
+
+func BenchmarkHandleMessage_RegexMatch(b *testing.B) {
+	mgr := newBenchManager(b, []config.ActionConfig{
+		{
+			Name:     "greeting",
+			Type:     "message",
+			Trigger:  config.TriggerConfig{Pattern: `(?i)\b(hello|hi|hey)\b`},
+			Response: config.ResponseConfig{Type: "text", Content: "Hello {{.Username}}!"},
+		},
+	})
+	runHandleMessage(b, mgr, benchMessage("hey everyone, how is it going?"))
+}
+
+// BenchmarkHandleMessage_1000Actions matches the last of 1000 commands,
+// measuring how dispatch scales with the number of loaded actions
+func BenchmarkHandleMessage_1000Actions(b *testing.B) {
+	actions := make([]config.ActionConfig, 0, 1000)
+	for i := range 1000 {
+		actions = append(actions, commandAction(fmt.Sprintf("cmd%d", i)))
+	}
+	mgr := newBenchManager(b, actions)
+	runHandleMessage(b, mgr, benchMessage("!cmd999"))
+}
@@ -0,0 +1,78 @@
+package action
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// helpArgs are the arguments asking a command for its help, e.g. !ping --help
+var helpArgs = []string{"help", "--help"}
+
+// helpRequested reports whether the message asks a command action for its help
+func helpRequested(action Action, content string) bool {
+	handler, ok := action.Handler.(*CommandHandler)
+	if !ok {
+		return false
+	}
+	args := handler.ExtractArgs(content)
+	return len(args) == 1 && slices.Contains(helpArgs, strings.ToLower(args[0]))
+}
+
+// sendHelp sends the help generated for a command action
+func (m *Manager) sendHelp(session response.DiscordSession, message *discordgo.Message, actionCfg config.ActionConfig) error {
+	m.actionsMu.RLock()
+	prefix := m.cfg.Bot.Prefix
+	m.actionsMu.RUnlock()
+
+	if m.DryRun {
+		m.logger.Info("DRY RUN: would send help", "action", actionCfg.Name)
+		return nil
+	}
+
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, commandHelp(prefix, actionCfg)); err != nil {
+		return fmt.Errorf("failed to send help for action %s: %w", actionCfg.Name, boterrors.FromDiscord(err))
+	}
+	return nil
+}
+
+// commandHelp builds the help embed of a command from its description and arguments
+func commandHelp(prefix string, actionCfg config.ActionConfig) *discordgo.MessageEmbed {
+	command := prefix + actionCfg.Trigger.Command
+
+	usage := []string{command}
+	var fields []*discordgo.MessageEmbedField
+	for _, spec := range actionCfg.Trigger.ArgSpecs {
+		if spec.HelpHidden {
+			continue
+		}
+
+		if spec.Required {
+			usage = append(usage, "<"+spec.Name+">")
+		} else {
+			usage = append(usage, "["+spec.Name+"]")
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s (%s)", spec.Name, cmp.Or(spec.Type, "string")),
+			Value: cmp.Or(spec.Description, "-"),
+		})
+	}
+
+	description := fmt.Sprintf("Usage: `%s`", strings.Join(usage, " "))
+	if actionCfg.Description != "" {
+		description = actionCfg.Description + "\n\n" + description
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       command,
+		Description: description,
+		Fields:      fields,
+	}
+}
@@ -0,0 +1,68 @@
+package action_test
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_CommandHelp(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(&config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:        "ban",
+				Description: "Bans a member from the server",
+				Type:        "command",
+				Trigger: config.TriggerConfig{
+					Command: "ban",
+					ArgSpecs: []config.ArgSpec{
+						{Name: "member", Type: "user", Description: "Member to ban", Required: true},
+						{Name: "reason", Description: "Reason shown in the audit log"},
+						{Name: "silent", Type: "bool", HelpHidden: true},
+					},
+				},
+				Response: config.ResponseConfig{Type: "text", Content: "banned"},
+			},
+		},
+	}, logger)
+	require.NoError(t, err)
+
+	for _, content := range []string{"!ban --help", "!ban HELP"} {
+		t.Run(content, func(t *testing.T) {
+			var embed *discordgo.MessageEmbed
+			session := &testutil.MockDiscordSession{}
+			session.On("ChannelMessageSendEmbed", "channel123", mock.Anything).Run(func(args mock.Arguments) {
+				embed = args.Get(1).(*discordgo.MessageEmbed)
+			}).Return(&discordgo.Message{}, nil).Once()
+
+			require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage(content)))
+			session.AssertExpectations(t)
+			session.AssertNotCalled(t, "ChannelMessageSend", mock.Anything, mock.Anything)
+
+			require.NotNil(t, embed)
+			assert.Equal(t, "!ban", embed.Title)
+			assert.Equal(t, "Bans a member from the server\n\nUsage: `!ban <member> [reason]`", embed.Description)
+			require.Len(t, embed.Fields, 2)
+			assert.Equal(t, "member (user)", embed.Fields[0].Name)
+			assert.Equal(t, "Member to ban", embed.Fields[0].Value)
+			assert.Equal(t, "reason (string)", embed.Fields[1].Name)
+		})
+	}
+
+	// Other arguments run the command
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "banned").Return(&discordgo.Message{}, nil).Once()
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ban @spammer help")))
+	session.AssertExpectations(t)
+}
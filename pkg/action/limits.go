@@ -0,0 +1,64 @@
+package action
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+)
+
+// SetExecutionCounts sets the store of the execution counts of actions with maxExecutions,
+// they are kept in memory by default
+func (m *Manager) SetExecutionCounts(counts storage.ExecutionCounts) {
+	m.counts = counts
+}
+
+// limitLock returns the lock serializing the executions of an action with maxExecutions
+func (m *Manager) limitLock(name string) *sync.Mutex {
+	lock, _ := m.limitLocks.LoadOrStore(name, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// executionLimitReached reports whether the action already ran maxExecutions times,
+// disabling it when it did, for example after a restart
+func (m *Manager) executionLimitReached(actionCfg config.ActionConfig) bool {
+	count, err := m.counts.ExecutionCount(actionCfg.Name)
+	if err != nil {
+		m.logger.Warn("Failed to read execution count", "action", actionCfg.Name, "error", err)
+		return false
+	}
+	if count < int64(actionCfg.MaxExecutions) {
+		return false
+	}
+
+	_ = m.DisableAction(actionCfg.Name)
+	return true
+}
+
+// recordExecution counts a successful execution of an action with maxExecutions.
+// The action is disabled, and the limit announced, when the count reaches the limit.
+func (m *Manager) recordExecution(session response.DiscordSession, message *discordgo.Message, actionCfg config.ActionConfig) error {
+	count, err := m.counts.IncrementExecutionCount(actionCfg.Name)
+	if err != nil {
+		m.logger.Warn("Failed to save execution count", "action", actionCfg.Name, "error", err)
+		return nil
+	}
+	if count < int64(actionCfg.MaxExecutions) {
+		return nil
+	}
+
+	_ = m.DisableAction(actionCfg.Name)
+	m.logger.Info("Action reached its execution limit", "action", actionCfg.Name, "maxExecutions", actionCfg.MaxExecutions)
+
+	if actionCfg.MaxReachedMessage == "" {
+		return nil
+	}
+	if _, err := session.ChannelMessageSend(message.ChannelID, actionCfg.MaxReachedMessage); err != nil {
+		return fmt.Errorf("failed to send max reached message: %w", boterrors.FromDiscord(err))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package action_test
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newGiveawayManager creates a manager with a !claim command limited to two executions
+func newGiveawayManager(t *testing.T, counts storage.ExecutionCounts) *action.Manager {
+	t.Helper()
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(&config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:              "claim",
+				Type:              "command",
+				Trigger:           config.TriggerConfig{Command: "claim"},
+				Response:          config.ResponseConfig{Type: "text", Content: "You won!"},
+				MaxExecutions:     2,
+				MaxReachedMessage: "The giveaway is over",
+			},
+		},
+	}, logger)
+	require.NoError(t, err)
+	mgr.SetExecutionCounts(counts)
+
+	return mgr
+}
+
+func TestManager_MaxExecutions(t *testing.T) {
+	counts := storage.NewMemoryExecutionCounts()
+	mgr := newGiveawayManager(t, counts)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "You won!").Return(&discordgo.Message{}, nil).Twice()
+	session.On("ChannelMessageSend", "channel123", "The giveaway is over").Return(&discordgo.Message{}, nil).Once()
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!claim")))
+	assert.True(t, mgr.IsEnabled("claim"))
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!claim now")))
+	assert.False(t, mgr.IsEnabled("claim"))
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!claim again")))
+	session.AssertExpectations(t)
+
+	count, err := counts.ExecutionCount("claim")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestManager_MaxExecutions_Restart(t *testing.T) {
+	counts := storage.NewMemoryExecutionCounts()
+	for range 2 {
+		_, err := counts.IncrementExecutionCount("claim")
+		require.NoError(t, err)
+	}
+
+	// The counts of a previous run disable the action on its next match
+	mgr := newGiveawayManager(t, counts)
+	session := &testutil.MockDiscordSession{}
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!claim")))
+	assert.False(t, mgr.IsEnabled("claim"))
+	session.AssertNotCalled(t, "ChannelMessageSend", mock.Anything, mock.Anything)
+}
+
+func TestManager_MaxExecutions_FailuresNotCounted(t *testing.T) {
+	counts := storage.NewMemoryExecutionCounts()
+	mgr := newGiveawayManager(t, counts)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "You won!").Return(nil, assert.AnError).Once()
+
+	assert.Error(t, mgr.HandleMessage(t.Context(), session, benchMessage("!claim")))
+
+	count, err := counts.ExecutionCount("claim")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.True(t, mgr.IsEnabled("claim"))
+}
+
+func TestManager_MaxExecutions_ActionsNotSerialized(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(&config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{Name: "claim", Type: "command", Trigger: config.TriggerConfig{Command: "claim"}, Response: config.ResponseConfig{Type: "text", Content: "You won!"}, MaxExecutions: 5},
+			{Name: "raffle", Type: "command", Trigger: config.TriggerConfig{Command: "raffle"}, Response: config.ResponseConfig{Type: "text", Content: "Entered"}, MaxExecutions: 5},
+		},
+	}, logger)
+	require.NoError(t, err)
+
+	// The claim response blocks until the raffle response was sent
+	sending, release := make(chan struct{}), make(chan struct{})
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "You won!").Run(func(mock.Arguments) {
+		close(sending)
+		<-release
+	}).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSend", "channel123", "Entered").Run(func(mock.Arguments) {
+		close(release)
+	}).Return(&discordgo.Message{}, nil).Once()
+
+	done := make(chan error, 1)
+	go func() { done <- mgr.HandleMessage(t.Context(), session, benchMessage("!claim")) }()
+
+	<-sending
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!raffle")))
+	require.NoError(t, <-done)
+	session.AssertExpectations(t)
+}
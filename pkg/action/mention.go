@@ -0,0 +1,46 @@
+package action
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// SetBotUserID sets the user ID of the bot, used to recognize commands starting with a mention of the bot
+func (m *Manager) SetBotUserID(id string) {
+	m.botUserID.Store(id)
+}
+
+// resolveMention rewrites a message starting with a mention of the bot, such as "@Bot ping",
+// to the prefixed command "!ping" when bot.mentionTrigger is set
+func (m *Manager) resolveMention(message *discordgo.MessageCreate) *discordgo.MessageCreate {
+	m.actionsMu.RLock()
+	enabled, prefix := m.cfg.Bot.MentionTrigger, m.cfg.Bot.Prefix
+	m.actionsMu.RUnlock()
+
+	botUserID, _ := m.botUserID.Load().(string)
+	if !enabled || botUserID == "" {
+		return message
+	}
+
+	command, ok := stripMention(message.Content, botUserID)
+	if !ok {
+		return message
+	}
+
+	rewritten := *message.Message
+	rewritten.Content = prefix + command
+	return &discordgo.MessageCreate{Message: &rewritten}
+}
+
+// stripMention returns the content following a leading <@ID> or <@!ID> mention of the user
+func stripMention(content, userID string) (string, bool) {
+	content = strings.TrimSpace(content)
+	for _, mention := range []string{"<@" + userID + ">", "<@!" + userID + ">"} {
+		if rest, ok := strings.CutPrefix(content, mention); ok {
+			rest = strings.TrimSpace(rest)
+			return rest, rest != ""
+		}
+	}
+	return "", false
+}
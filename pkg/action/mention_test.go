@@ -0,0 +1,77 @@
+package action_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMentionManager(t *testing.T, mentionTrigger bool) *action.Manager {
+	t.Helper()
+
+	cfg := &config.Config{
+		Bot: config.BotConfig{Prefix: "!", MentionTrigger: mentionTrigger},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+	mgr.SetBotUserID("999")
+	return mgr
+}
+
+func TestManager_HandleMessage_MentionTrigger(t *testing.T) {
+	for _, content := range []string{"<@999> ping", "<@!999> ping", "  <@999>ping", "!ping"} {
+		t.Run(content, func(t *testing.T) {
+			mgr := newMentionManager(t, true)
+
+			session := &testutil.MockDiscordSession{}
+			session.On("ChannelMessageSend", "channel123", "Pong!").Return(&discordgo.Message{}, nil).Once()
+
+			require.NoError(t, mgr.HandleMessage(context.Background(), session, benchMessage(content)))
+
+			session.AssertExpectations(t)
+		})
+	}
+}
+
+func TestManager_HandleMessage_MentionTriggerIgnored(t *testing.T) {
+	tests := []struct {
+		name           string
+		mentionTrigger bool
+		content        string
+	}{
+		{name: "disabled", content: "<@999> ping"},
+		{name: "other user", mentionTrigger: true, content: "<@123> ping"},
+		{name: "mention only", mentionTrigger: true, content: "<@999>"},
+		{name: "mention not first", mentionTrigger: true, content: "hey <@999> ping"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newMentionManager(t, tt.mentionTrigger)
+			session := &testutil.MockDiscordSession{}
+
+			require.NoError(t, mgr.HandleMessage(context.Background(), session, benchMessage(tt.content)))
+
+			session.AssertNotCalled(t, "ChannelMessageSend", mock.Anything, mock.Anything)
+		})
+	}
+}
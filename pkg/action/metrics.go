@@ -0,0 +1,139 @@
+package action
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// durationBuckets are the upper bounds of the action duration histogram
+var durationBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// Metrics reports the execution latency of actions and the depth of the worker queue
+type Metrics struct {
+	ActionDurationHistogram []ActionDurations `json:"actionDurationHistogram"`
+
+	// ActionQueueDepth counts the tasks submitted to the worker pool and not completed yet
+	ActionQueueDepth int64 `json:"actionQueueDepth"`
+}
+
+// ActionDurations is the execution duration histogram of an action and response type
+type ActionDurations struct {
+	Action       string  `json:"action"`
+	ResponseType string  `json:"responseType"`
+	Count        int64   `json:"count"`
+	SumSeconds   float64 `json:"sumSeconds"`
+
+	// Buckets counts the executions by duration, each bucket includes the
+	// executions of the lower buckets
+	Buckets map[string]int64 `json:"buckets"`
+}
+
+// durationKey labels the executions of the duration histogram
+type durationKey struct {
+	action       string
+	responseType string
+}
+
+// durationSeries holds the observations of one label set
+type durationSeries struct {
+	count   int64
+	sum     time.Duration
+	buckets []int64
+}
+
+// actionMetrics collects the duration histogram and the queue depth
+type actionMetrics struct {
+	series     map[durationKey]*durationSeries
+	mu         sync.Mutex
+	queueDepth atomic.Int64
+}
+
+// newActionMetrics creates empty action metrics
+func newActionMetrics() *actionMetrics {
+	return &actionMetrics{series: make(map[durationKey]*durationSeries)}
+}
+
+// observe records the duration of an action execution
+func (a *actionMetrics) observe(action, responseType string, duration time.Duration) {
+	bucket := len(durationBuckets)
+	for i, bound := range durationBuckets {
+		if duration <= bound {
+			bucket = i
+			break
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := durationKey{action: action, responseType: responseType}
+	series, ok := a.series[key]
+	if !ok {
+		series = &durationSeries{buckets: make([]int64, len(durationBuckets)+1)}
+		a.series[key] = series
+	}
+	series.count++
+	series.sum += duration
+	series.buckets[bucket]++
+}
+
+// snapshot returns the current metrics, sorted by action and response type
+func (a *actionMetrics) snapshot() Metrics {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	metrics := Metrics{
+		ActionDurationHistogram: make([]ActionDurations, 0, len(a.series)),
+		ActionQueueDepth:        a.queueDepth.Load(),
+	}
+
+	for key, series := range a.series {
+		durations := ActionDurations{
+			Action:       key.action,
+			ResponseType: key.responseType,
+			Count:        series.count,
+			SumSeconds:   series.sum.Seconds(),
+			Buckets:      make(map[string]int64, len(series.buckets)),
+		}
+
+		var cumulative int64
+		for i, count := range series.buckets {
+			cumulative += count
+			bound := "+Inf"
+			if i < len(durationBuckets) {
+				bound = durationBuckets[i].String()
+			}
+			durations.Buckets[bound] = cumulative
+		}
+
+		metrics.ActionDurationHistogram = append(metrics.ActionDurationHistogram, durations)
+	}
+
+	slices.SortFunc(metrics.ActionDurationHistogram, func(a, b ActionDurations) int {
+		if c := strings.Compare(a.Action, b.Action); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ResponseType, b.ResponseType)
+	})
+
+	return metrics
+}
+
+// Metrics returns the action duration histogram and the worker queue depth
+func (m *Manager) Metrics() Metrics {
+	return m.metrics.snapshot()
+}
@@ -0,0 +1,65 @@
+package action_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Metrics(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix:  "!",
+			Workers: &config.WorkersConfig{PoolSize: 1, QueueCapacity: 1},
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(&discordgo.Message{}, nil)
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ping")))
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ping now")))
+
+	metrics := mgr.Metrics()
+	require.Len(t, metrics.ActionDurationHistogram, 1)
+
+	durations := metrics.ActionDurationHistogram[0]
+	assert.Equal(t, "ping", durations.Action)
+	assert.Equal(t, "text", durations.ResponseType)
+	assert.Equal(t, int64(2), durations.Count)
+	assert.Equal(t, int64(2), durations.Buckets["5s"])
+	assert.Equal(t, int64(2), durations.Buckets["+Inf"])
+	assert.Contains(t, durations.Buckets, "2.5s")
+
+	release := make(chan struct{})
+	require.NoError(t, mgr.Submit(func() { <-release }))
+	assert.Equal(t, int64(1), mgr.Metrics().ActionQueueDepth)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return mgr.Metrics().ActionQueueDepth == 0
+	}, time.Second, 10*time.Millisecond)
+	mgr.StopWorkers()
+}
@@ -0,0 +1,222 @@
+package action
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/geekxflood/gxf-discord-bot/pkg/stats"
+)
+
+// Built-in owner commands
+const (
+	reloadCommand       = "reload"
+	addActionCommand    = "addaction"
+	removeActionCommand = "removeaction"
+	statsCommand        = "stats"
+	dryDebugCommand     = "drydebug"
+)
+
+// statsTopCount is the number of actions listed by the stats owner command
+const statsTopCount = 10
+
+// codeFence delimits the code block holding the action of the addaction command
+const codeFence = "```"
+
+// ReloadFunc reloads the configuration for the reload owner command
+type ReloadFunc func() error
+
+// SetReloader sets the function run by the reload owner command,
+// the command is ignored when no reloader is set
+func (m *Manager) SetReloader(reload ReloadFunc) {
+	m.actionsMu.Lock()
+	defer m.actionsMu.Unlock()
+	m.reload = reload
+}
+
+// IsOwner reports whether the user is one of the configured bot owners
+func (m *Manager) IsOwner(userID string) bool {
+	m.actionsMu.RLock()
+	defer m.actionsMu.RUnlock()
+	return m.cfg.Bot.IsOwner(userID)
+}
+
+// isOwnerMessage reports whether the message was sent by a bot owner
+func (m *Manager) isOwnerMessage(message *discordgo.Message) bool {
+	return message.Author != nil && m.IsOwner(message.Author.ID)
+}
+
+// handleOwnerCommand runs the built-in owner commands and reports whether the message was one.
+// Messages of other users are left to the configured actions.
+func (m *Manager) handleOwnerCommand(session response.DiscordSession, message *discordgo.Message) (bool, error) {
+	m.actionsMu.RLock()
+	prefix, reload := m.cfg.Bot.Prefix, m.reload
+	m.actionsMu.RUnlock()
+
+	rest, ok := strings.CutPrefix(strings.TrimSpace(message.Content), prefix)
+	if !ok {
+		return false, nil
+	}
+	command, args := rest, ""
+	if i := strings.IndexFunc(rest, unicode.IsSpace); i >= 0 {
+		command, args = rest[:i], strings.TrimSpace(rest[i:])
+	}
+
+	var run func() string
+	var embed func() *discordgo.MessageEmbed
+	direct := false
+	switch strings.ToLower(command) {
+	case reloadCommand:
+		if reload == nil {
+			return false, nil
+		}
+		run = func() string { return m.runReload(reload, message.Author.ID) }
+	case addActionCommand:
+		run = func() string { return m.runAddAction(prefix, args, message.Author.ID) }
+	case removeActionCommand:
+		run = func() string { return m.runRemoveAction(prefix, args, message.Author.ID) }
+	case statsCommand:
+		embed = func() *discordgo.MessageEmbed { return m.statsEmbed(args) }
+	case dryDebugCommand:
+		run = func() string { return m.runDryDebug(session, message, prefix, args) }
+		direct = true
+	default:
+		return false, nil
+	}
+
+	if !m.isOwnerMessage(message) {
+		return false, nil
+	}
+
+	if m.queue != nil {
+		session = m.queue.Wrap(session)
+	}
+
+	// Direct replies are sent to the owner by DM
+	channelID := message.ChannelID
+	if direct {
+		channel, err := session.UserChannelCreate(message.Author.ID)
+		if err != nil {
+			return true, fmt.Errorf("failed to create %s DM channel: %w", strings.ToLower(command), boterrors.FromDiscord(err))
+		}
+		channelID = channel.ID
+	}
+
+	var err error
+	if embed != nil {
+		_, err = session.ChannelMessageSendEmbed(channelID, embed())
+	} else {
+		for _, chunk := range response.SplitMessage(run(), response.MaxMessageLength) {
+			if _, err = session.ChannelMessageSend(channelID, chunk); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to send %s reply: %w", strings.ToLower(command), boterrors.FromDiscord(err))
+	}
+	return true, nil
+}
+
+// runReload reloads the configuration and returns the reply
+func (m *Manager) runReload(reload ReloadFunc, userID string) string {
+	if err := reload(); err != nil {
+		m.logger.Warn("Owner reload failed", "userID", userID, "error", err)
+		return fmt.Sprintf("Reload failed: %v", err)
+	}
+	m.logger.Info("Configuration reloaded by owner", "userID", userID)
+	return "Configuration reloaded"
+}
+
+// runAddAction adds the action of the YAML code block and returns the reply
+func (m *Manager) runAddAction(prefix, args, userID string) string {
+	block, ok := codeBlock(args)
+	if !ok {
+		return fmt.Sprintf("Usage: %s%s followed by the action in a YAML code block", prefix, addActionCommand)
+	}
+
+	actionCfg, err := config.ParseAction([]byte(block))
+	if err != nil {
+		return fmt.Sprintf("Invalid action: %v", err)
+	}
+
+	if err := m.loadAction(actionCfg); err != nil {
+		return fmt.Sprintf("Invalid action: %v", err)
+	}
+	if err := m.saveDynamicAction(actionCfg); err != nil {
+		m.logger.Warn("Failed to save dynamic action", "action", actionCfg.Name, "error", err)
+		return fmt.Sprintf("Action %s added but not saved: %v", actionCfg.Name, err)
+	}
+
+	m.logger.Info("Action added by owner", "action", actionCfg.Name, "userID", userID)
+	return fmt.Sprintf("Action %s added", actionCfg.Name)
+}
+
+// runRemoveAction disables the named action and returns the reply
+func (m *Manager) runRemoveAction(prefix, name, userID string) string {
+	if name == "" || strings.ContainsFunc(name, unicode.IsSpace) {
+		return fmt.Sprintf("Usage: %s%s <name>", prefix, removeActionCommand)
+	}
+
+	if err := m.RemoveAction(name); err != nil {
+		return fmt.Sprintf("Failed to remove action %s: %v", name, err)
+	}
+
+	m.logger.Info("Action removed by owner", "action", name, "userID", userID)
+	return fmt.Sprintf("Action %s removed", name)
+}
+
+// statsEmbed builds the usage of an action, or of the most used actions when name is empty
+func (m *Manager) statsEmbed(name string) *discordgo.MessageEmbed {
+	if name != "" {
+		usage, ok := m.usage.Get(name)
+		if !ok {
+			return &discordgo.MessageEmbed{Title: "Usage of " + name, Description: "No usage recorded"}
+		}
+		return &discordgo.MessageEmbed{Title: "Usage of " + name, Description: formatUsage(usage)}
+	}
+
+	top := m.usage.Top(statsTopCount)
+	if len(top) == 0 {
+		return &discordgo.MessageEmbed{Title: "Command usage", Description: "No usage recorded"}
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, len(top))
+	for i, usage := range top {
+		fields[i] = &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%d. %s", i+1, usage.Action),
+			Value: formatUsage(usage),
+		}
+	}
+	return &discordgo.MessageEmbed{Title: "Command usage", Fields: fields}
+}
+
+// formatUsage describes the usage of an action
+func formatUsage(usage stats.Usage) string {
+	return fmt.Sprintf("Uses: %d · Users: %d · Last used %s",
+		usage.InvocationCount, usage.UniqueUsers, response.DiscordTimestamp(usage.LastUsedAt, "R"))
+}
+
+// codeBlock returns the content of the first code block of a message,
+// without the language tag of its first line
+func codeBlock(text string) (string, bool) {
+	_, after, ok := strings.Cut(text, codeFence)
+	if !ok {
+		return "", false
+	}
+	block, _, ok := strings.Cut(after, codeFence)
+	if !ok {
+		return "", false
+	}
+
+	if first, rest, found := strings.Cut(block, "\n"); found && !strings.ContainsAny(first, ": ") {
+		block = rest
+	}
+
+	block = strings.TrimSpace(block)
+	return block, block != ""
+}
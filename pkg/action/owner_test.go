@@ -0,0 +1,318 @@
+package action_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ownerConfig is owned by user 123 with a rate limited !ask command restricted to another channel
+func ownerConfig() *config.Config {
+	return &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!", OwnerIDs: []string{"123"}},
+		Actions: []config.ActionConfig{
+			{
+				Name:       "ask",
+				Type:       "command",
+				Trigger:    config.TriggerConfig{Command: "ask"},
+				Response:   config.ResponseConfig{Type: "text", Content: "answered"},
+				RateLimit:  &config.RateLimitConfig{Requests: 1, Window: 60},
+				Conditions: []config.ConditionConfig{{Type: "channel", Value: "other"}},
+			},
+		},
+	}
+}
+
+// newOwnerManager creates a manager running ownerConfig
+func newOwnerManager(t *testing.T) *action.Manager {
+	t.Helper()
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Warn", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(ownerConfig(), logger)
+	require.NoError(t, err)
+	mgr.SetRateLimiter(ratelimit.New(logger))
+
+	return mgr
+}
+
+func TestManager_OwnerBypass(t *testing.T) {
+	mgr := newOwnerManager(t)
+	assert.True(t, mgr.IsOwner("123"))
+	assert.False(t, mgr.IsOwner("456"))
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "answered").Return(&discordgo.Message{}, nil).Twice()
+
+	// Neither the channel condition nor the rate limit apply to owners
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask")))
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask again")))
+	session.AssertExpectations(t)
+
+	other := benchMessage("!ask")
+	other.Author = &discordgo.User{ID: "456"}
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, other))
+	session.AssertNumberOfCalls(t, "ChannelMessageSend", 2)
+}
+
+func TestManager_ReloadCommand(t *testing.T) {
+	t.Run("owner", func(t *testing.T) {
+		mgr := newOwnerManager(t)
+		reloads := 0
+		mgr.SetReloader(func() error {
+			reloads++
+			return nil
+		})
+
+		session := &testutil.MockDiscordSession{}
+		session.On("ChannelMessageSend", "channel123", "Configuration reloaded").Return(&discordgo.Message{}, nil).Once()
+
+		require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!reload")))
+		assert.Equal(t, 1, reloads)
+		session.AssertExpectations(t)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mgr := newOwnerManager(t)
+		mgr.SetReloader(func() error {
+			return errors.New("invalid configuration: bot prefix is required")
+		})
+
+		session := &testutil.MockDiscordSession{}
+		session.On("ChannelMessageSend", "channel123", "Reload failed: invalid configuration: bot prefix is required").Return(&discordgo.Message{}, nil).Once()
+
+		require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!reload")))
+		session.AssertExpectations(t)
+	})
+
+	t.Run("not owner", func(t *testing.T) {
+		mgr := newOwnerManager(t)
+		mgr.SetReloader(func() error {
+			t.Fatal("reload run for a user who is not an owner")
+			return nil
+		})
+
+		message := benchMessage("!reload")
+		message.Author = &discordgo.User{ID: "456"}
+
+		session := &testutil.MockDiscordSession{}
+		require.NoError(t, mgr.HandleMessage(t.Context(), session, message))
+		session.AssertNotCalled(t, "ChannelMessageSend", mock.Anything, mock.Anything)
+	})
+}
+
+func TestManager_AddActionCommand(t *testing.T) {
+	mgr := newOwnerManager(t)
+	path := filepath.Join(t.TempDir(), config.DynamicActionsFile)
+	mgr.SetDynamicActionsPath(path)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Action hello added").Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSend", "channel123", "Hello there!").Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSend", "channel123", "Action hello removed").Return(&discordgo.Message{}, nil).Once()
+
+	add := "!addaction\n```yaml\nname: hello\ntype: command\ntrigger:\n  command: hello\nresponse:\n  type: text\n  content: Hello there!\n```"
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage(add)))
+
+	other := benchMessage("!hello")
+	other.Author = &discordgo.User{ID: "456"}
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, other))
+
+	saved, err := config.LoadDynamicActions(path)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "hello", saved[0].Name)
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!removeaction hello")))
+	assert.False(t, mgr.IsEnabled("hello"))
+	session.AssertExpectations(t)
+
+	saved, err = config.LoadDynamicActions(path)
+	require.NoError(t, err)
+	assert.Empty(t, saved)
+}
+
+func TestManager_AddActionCommand_KeptOnConfigChange(t *testing.T) {
+	mgr := newOwnerManager(t)
+	mgr.SetDynamicActionsPath(filepath.Join(t.TempDir(), config.DynamicActionsFile))
+
+	// Runtime config changes reload the actions from the configuration without the added action
+	cfgMgr := config.NewManager("", ownerConfig())
+	cfgMgr.SetApplyFunc(mgr.Reload)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Action hello added").Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSend", "channel123", "Hello there!").Return(&discordgo.Message{}, nil).Once()
+
+	add := "!addaction\n```yaml\nname: hello\ntype: command\ntrigger:\n  command: hello\nresponse:\n  type: text\n  content: Hello there!\n```"
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage(add)))
+
+	require.NoError(t, cfgMgr.SetString("bot.status", "Maintenance"))
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!hello")))
+	session.AssertExpectations(t)
+}
+
+func TestManager_AddActionCommand_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		reply   string
+	}{
+		{
+			name:    "no code block",
+			content: "!addaction name: hello",
+			reply:   "Usage: !addaction followed by the action in a YAML code block",
+		},
+		{
+			name:    "unknown field",
+			content: "!addaction ```\nname: hello\ntype: command\ncolour: red\n```",
+			reply:   "Invalid action: failed to parse action: yaml: unmarshal errors:\n  line 3: field colour not found in type config.ActionConfig",
+		},
+		{
+			name:    "invalid action",
+			content: "!addaction ```yaml\nname: hello\ntype: command\ntrigger:\n  command: hello\nresponse:\n  type: forward\n```",
+			reply:   "Invalid action: action hello: forward response requires a sourceChannelId and a sourceMessageId",
+		},
+		{
+			name:    "duplicate name",
+			content: "!addaction ```yaml\nname: ask\ntype: command\ntrigger:\n  command: ask\nresponse:\n  type: text\n  content: again\n```",
+			reply:   "Invalid action: action ask already exists",
+		},
+		{
+			name:    "scheduled action",
+			content: "!addaction ```yaml\nname: daily\ntype: scheduled\ntrigger:\n  schedule: 0 0 9 * * *\nresponse:\n  type: text\n  content: Good morning\n```",
+			reply:   "Invalid action: scheduled actions cannot be added at runtime, add them to the config file",
+		},
+		{
+			name:    "remove static action",
+			content: "!removeaction ask",
+			reply:   "Failed to remove action ask: action ask is defined in the config file, disable it or remove it from the file",
+		},
+		{
+			name:    "remove unknown action",
+			content: "!removeaction missing",
+			reply:   "Failed to remove action missing: action not found: missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newOwnerManager(t)
+
+			session := &testutil.MockDiscordSession{}
+			session.On("ChannelMessageSend", "channel123", tt.reply).Return(&discordgo.Message{}, nil).Once()
+
+			require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage(tt.content)))
+			session.AssertExpectations(t)
+		})
+	}
+}
+
+func TestManager_AddActionCommand_NotOwner(t *testing.T) {
+	mgr := newOwnerManager(t)
+
+	message := benchMessage("!addaction ```yaml\nname: hello\ntype: command\ntrigger:\n  command: hello\nresponse:\n  type: text\n  content: hi\n```")
+	message.Author = &discordgo.User{ID: "456"}
+
+	session := &testutil.MockDiscordSession{}
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, message))
+	session.AssertNotCalled(t, "ChannelMessageSend", mock.Anything, mock.Anything)
+	assert.Len(t, mgr.GetActions(), 1)
+}
+
+func TestManager_StatsCommand(t *testing.T) {
+	mgr := newOwnerManager(t)
+
+	var embeds []*discordgo.MessageEmbed
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "answered").Return(&discordgo.Message{}, nil)
+	session.On("ChannelMessageSendEmbed", "channel123", mock.Anything).Run(func(args mock.Arguments) {
+		embeds = append(embeds, args.Get(1).(*discordgo.MessageEmbed))
+	}).Return(&discordgo.Message{}, nil)
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask")))
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!ask again")))
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!stats")))
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!stats ask")))
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!stats missing")))
+	require.Len(t, embeds, 3)
+
+	assert.Equal(t, "Command usage", embeds[0].Title)
+	require.Len(t, embeds[0].Fields, 1)
+	assert.Equal(t, "1. ask", embeds[0].Fields[0].Name)
+	assert.Contains(t, embeds[0].Fields[0].Value, "Uses: 2 · Users: 1")
+
+	assert.Equal(t, "Usage of ask", embeds[1].Title)
+	assert.Contains(t, embeds[1].Description, "Uses: 2 · Users: 1")
+	assert.Equal(t, "No usage recorded", embeds[2].Description)
+
+	// The stats command is not counted as an action
+	assert.Len(t, mgr.Stats().Top(0), 1)
+}
+
+func TestManager_DryDebugCommand(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(&config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!", OwnerIDs: []string{"123"}},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "greet",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "greet"},
+				Response: config.ResponseConfig{Type: "text", Content: "Hello <@{{.UserID}}>"},
+				Conditions: []config.ConditionConfig{
+					{Type: "channel", Value: "other"},
+					{Type: "content", Operator: "contains", Value: "please"},
+				},
+			},
+		},
+	}, logger)
+	require.NoError(t, err)
+
+	var replies []string
+	session := &testutil.MockDiscordSession{}
+	session.On("UserChannelCreate", "123").Return(&discordgo.Channel{ID: "dm123"}, nil)
+	session.On("ChannelMessageSend", "dm123", mock.Anything).Run(func(args mock.Arguments) {
+		replies = append(replies, args.String(1))
+	}).Return(&discordgo.Message{}, nil)
+
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!drydebug !greet please")))
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!drydebug !unknown")))
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!drydebug")))
+
+	require.Len(t, replies, 3)
+	assert.Equal(t, "Action: greet (command)\n"+
+		"Conditions (bypassed by owners):\n"+
+		"- failed channel \"other\"\n"+
+		"- passed content contains \"please\"\n"+
+		"Response:\n"+
+		"Type: text\n"+
+		"Content: Hello <@123>", replies[0])
+	assert.Equal(t, `No action matches "!unknown"`, replies[1])
+	assert.Equal(t, "Usage: !drydebug <content>", replies[2])
+
+	// Nothing is sent to the channel and non-owners cannot run the command
+	session.AssertNotCalled(t, "ChannelMessageSend", "channel123", mock.Anything)
+
+	other := benchMessage("!drydebug !greet")
+	other.Author = &discordgo.User{ID: "456"}
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, other))
+	session.AssertNumberOfCalls(t, "UserChannelCreate", 3)
+}
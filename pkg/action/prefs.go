@@ -0,0 +1,91 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// Built-in action types letting users manage their preferences
+const (
+	setPrefType = "setpref"
+	getPrefType = "getpref"
+)
+
+// maxPrefValueLength is the maximum length of a preference value
+const maxPrefValueLength = 100
+
+// prefKeyPattern matches preference keys usable in templates as {{.Prefs.key}}
+var prefKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,31}$`)
+
+// PrefsHandler sets or shows a preference of the user, e.g. !setpref language fr
+type PrefsHandler struct {
+	*CommandHandler
+	mgr *Manager
+	set bool
+}
+
+// newPrefsHandler creates the handler of a setpref or getpref action
+func newPrefsHandler(mgr *Manager, prefix, command string, set bool) *PrefsHandler {
+	return &PrefsHandler{
+		CommandHandler: NewCommandHandler(prefix, command),
+		mgr:            mgr,
+		set:            set,
+	}
+}
+
+// Respond updates or looks up the preference and replies in the channel
+func (h *PrefsHandler) Respond(ctx context.Context, session response.DiscordSession, message *discordgo.Message) error {
+	if message.Author == nil {
+		return nil
+	}
+
+	reply, err := h.reply(message.Author.ID, h.ExtractArgs(message.Content))
+	if err != nil {
+		return err
+	}
+
+	// Preference values are user input, mentions in them do not notify anyone
+	if _, err := session.ChannelMessageSendComplex(message.ChannelID, &discordgo.MessageSend{
+		Content:         reply,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}); err != nil {
+		return fmt.Errorf("failed to send preference reply: %w", err)
+	}
+	return nil
+}
+
+// reply applies the command arguments and returns the message sent back
+func (h *PrefsHandler) reply(userID string, args []string) (string, error) {
+	if !h.set {
+		if len(args) != 1 {
+			return fmt.Sprintf("usage: %s KEY", h.command), nil
+		}
+		value, ok := h.mgr.prefs.GetPref(userID, args[0])
+		if !ok {
+			return fmt.Sprintf("%s is not set", args[0]), nil
+		}
+		return fmt.Sprintf("%s: %s", args[0], value), nil
+	}
+
+	if len(args) < 2 {
+		return fmt.Sprintf("usage: %s KEY VALUE", h.command), nil
+	}
+
+	key, value := args[0], strings.Join(args[1:], " ")
+	if !prefKeyPattern.MatchString(key) {
+		return "keys start with a letter and contain up to 32 letters, digits or underscores", nil
+	}
+	if len(value) > maxPrefValueLength {
+		return fmt.Sprintf("values are limited to %d characters", maxPrefValueLength), nil
+	}
+
+	if err := h.mgr.prefs.SetPref(userID, key, value); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s set to %s", key, value), nil
+}
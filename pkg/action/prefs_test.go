@@ -0,0 +1,80 @@
+package action_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_UserPrefs(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{Name: "setpref", Type: "setpref", Trigger: config.TriggerConfig{Command: "setpref"}},
+			{Name: "getpref", Type: "getpref", Trigger: config.TriggerConfig{Command: "getpref"}},
+			{
+				Name:     "hello",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "hello"},
+				Response: config.ResponseConfig{Type: "text", Content: "{{if eq .Prefs.language \"fr\"}}Bonjour{{else}}Hello{{end}}"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	prefs := storage.NewMemoryPrefs()
+	mgr.SetPrefs(prefs)
+
+	session := &testutil.MockDiscordSession{}
+	send := func(content string) {
+		t.Helper()
+		require.NoError(t, mgr.HandleMessage(context.Background(), session, &discordgo.MessageCreate{
+			Message: &discordgo.Message{
+				ID:        content,
+				Content:   content,
+				ChannelID: "channel123",
+				Author:    &discordgo.User{ID: "user123"},
+			},
+		}))
+	}
+
+	session.On("ChannelMessageSend", "channel123", "Hello").Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReply("language is not set")).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReply("language set to fr")).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReply("language: fr")).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSend", "channel123", "Bonjour").Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReply("usage: setpref KEY VALUE")).Return(&discordgo.Message{}, nil).Once()
+
+	send("!hello")
+	send("!getpref language")
+	send("!setpref language fr")
+	send("!getpref  language")
+	send("!hello ")
+	send("!setpref language")
+
+	session.AssertExpectations(t)
+
+	value, ok := prefs.GetPref("user123", "language")
+	require.True(t, ok)
+	require.Equal(t, "fr", value)
+}
+
+// quietReply is a reply sent without notifying the mentioned users
+func quietReply(content string) *discordgo.MessageSend {
+	return &discordgo.MessageSend{Content: content, AllowedMentions: &discordgo.MessageAllowedMentions{}}
+}
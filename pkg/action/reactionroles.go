@@ -0,0 +1,144 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// reactionRoleType is the action type granting a role to the users reacting with an emoji
+const reactionRoleType = "reaction_role"
+
+// What reaction_role actions do when the reaction is removed
+const (
+	OnRemoveRemove = "remove"
+	OnRemoveKeep   = "keep"
+)
+
+// ReactionRoleHandler grants a role on reaction add and, unless configured
+// to keep it, removes the role on reaction remove
+type ReactionRoleHandler struct {
+	emoji     string
+	emojiID   string
+	roleID    string
+	messageID string
+	onRemove  string
+}
+
+// NewReactionRoleHandler creates a reaction role handler from the trigger of the action
+func NewReactionRoleHandler(trigger config.TriggerConfig) (*ReactionRoleHandler, error) {
+	if trigger.Emoji == "" || trigger.Role == "" || trigger.MessageID == "" {
+		return nil, fmt.Errorf("reaction role requires an emoji, a role and a message")
+	}
+
+	onRemove := trigger.OnRemove
+	if onRemove == "" {
+		onRemove = OnRemoveRemove
+	}
+
+	return &ReactionRoleHandler{
+		emoji:     trigger.Emoji,
+		emojiID:   customEmojiID(trigger.Emoji),
+		roleID:    trigger.Role,
+		messageID: trigger.MessageID,
+		onRemove:  onRemove,
+	}, nil
+}
+
+// customEmojiID returns the ID of a custom emoji given as <:name:id>, <a:name:id>, name:id or id,
+// and an empty string for a unicode emoji
+func customEmojiID(emoji string) string {
+	emoji = strings.TrimSuffix(strings.TrimPrefix(emoji, "<"), ">")
+	id := emoji[strings.LastIndex(emoji, ":")+1:]
+	if id == "" || strings.Trim(id, "0123456789") != "" {
+		return ""
+	}
+	return id
+}
+
+// Matches checks if the reaction matches the emoji
+func (h *ReactionRoleHandler) Matches(reaction string) bool {
+	return h.emoji == reaction
+}
+
+// matchesReaction checks the message and the emoji of a reaction, custom emojis are matched by ID
+// as their names are not unique across guilds
+func (h *ReactionRoleHandler) matchesReaction(messageID string, emoji discordgo.Emoji) bool {
+	if h.messageID != messageID {
+		return false
+	}
+	if h.emojiID != "" {
+		return h.emojiID == emoji.ID
+	}
+	return emoji.ID == "" && h.Matches(emoji.Name)
+}
+
+// Execute does nothing, roles are changed when reactions are handled
+func (h *ReactionRoleHandler) Execute(ctx context.Context, session *discordgo.Session, message *discordgo.Message) error {
+	return nil
+}
+
+// handleReactionRole adds or removes the role of a matched reaction_role action
+func (m *Manager) handleReactionRole(session response.DiscordSession, action Action, guildID, userID string, added bool) error {
+	handler := action.Handler.(*ReactionRoleHandler)
+
+	if m.DryRun {
+		m.logger.Info("DRY RUN: would change reaction role", "action", action.Config.Name, "role", handler.roleID, "added", added)
+		return nil
+	}
+
+	if added {
+		if err := session.GuildMemberRoleAdd(guildID, userID, handler.roleID); err != nil {
+			return fmt.Errorf("failed to add role for action %s: %w", action.Config.Name, boterrors.FromDiscord(err))
+		}
+		return nil
+	}
+
+	if handler.onRemove == OnRemoveKeep {
+		return nil
+	}
+	if err := session.GuildMemberRoleRemove(guildID, userID, handler.roleID); err != nil {
+		return fmt.Errorf("failed to remove role for action %s: %w", action.Config.Name, boterrors.FromDiscord(err))
+	}
+	return nil
+}
+
+// HandleReactionRemove handles reaction remove events of reaction_role actions
+func (m *Manager) HandleReactionRemove(ctx context.Context, session response.DiscordSession, reaction *discordgo.MessageReactionRemove) error {
+	start := time.Now()
+	event := Event{
+		Trigger:   "reaction_remove",
+		UserID:    reaction.UserID,
+		ChannelID: reaction.ChannelID,
+	}
+
+	for _, action := range m.loadedActions() {
+		if action.Config.Type != reactionRoleType || !m.IsEnabled(action.Config.Name) {
+			continue
+		}
+		if !action.Handler.(*ReactionRoleHandler).matchesReaction(reaction.MessageID, reaction.Emoji) {
+			continue
+		}
+
+		m.logger.Debug("Reaction role removed", "action", action.Config.Name, "emoji", reaction.Emoji.Name)
+		err := m.handleReactionRole(session, action, reaction.GuildID, reaction.UserID, false)
+
+		event.Action = action.Config.Name
+		event.Matched = true
+		event.Duration = time.Since(start)
+		event.Error = err
+		m.publish(event)
+
+		return err
+	}
+
+	event.Duration = time.Since(start)
+	m.publish(event)
+	return nil
+}
@@ -0,0 +1,115 @@
+package action_test
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newReactionRoleManager creates a manager with a reaction_role action for the 🎮 emoji on msg1,
+// unless the trigger sets another emoji
+func newReactionRoleManager(t *testing.T, trigger config.TriggerConfig) *action.Manager {
+	t.Helper()
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	if trigger.Emoji == "" {
+		trigger.Emoji = "🎮"
+	}
+	trigger.Role = "role123"
+	trigger.MessageID = "msg1"
+	mgr, err := action.NewManager(&config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{Name: "gamer-role", Type: "reaction_role", Trigger: trigger},
+		},
+	}, logger)
+	require.NoError(t, err)
+
+	return mgr
+}
+
+// reactionEvent creates the reaction of a user to a message
+func reactionEvent(messageID, emoji string) *discordgo.MessageReaction {
+	return &discordgo.MessageReaction{
+		UserID:    "user123",
+		MessageID: messageID,
+		ChannelID: "channel123",
+		GuildID:   "guild123",
+		Emoji:     discordgo.Emoji{Name: emoji},
+	}
+}
+
+func TestManager_ReactionRole(t *testing.T) {
+	mgr := newReactionRoleManager(t, config.TriggerConfig{})
+
+	session := &testutil.MockDiscordSession{}
+	session.On("GuildMemberRoleAdd", "guild123", "user123", "role123").Return(nil).Once()
+	session.On("GuildMemberRoleRemove", "guild123", "user123", "role123").Return(nil).Once()
+
+	require.NoError(t, mgr.HandleReaction(t.Context(), session, &discordgo.MessageReactionAdd{MessageReaction: reactionEvent("msg1", "🎮")}))
+	require.NoError(t, mgr.HandleReaction(t.Context(), session, &discordgo.MessageReactionAdd{MessageReaction: reactionEvent("msg1", "👍")}))
+	require.NoError(t, mgr.HandleReactionRemove(t.Context(), session, &discordgo.MessageReactionRemove{MessageReaction: reactionEvent("msg1", "🎮")}))
+
+	session.AssertExpectations(t)
+}
+
+func TestManager_ReactionRole_KeepOnRemove(t *testing.T) {
+	mgr := newReactionRoleManager(t, config.TriggerConfig{OnRemove: action.OnRemoveKeep})
+
+	session := &testutil.MockDiscordSession{}
+	require.NoError(t, mgr.HandleReactionRemove(t.Context(), session, &discordgo.MessageReactionRemove{MessageReaction: reactionEvent("msg1", "🎮")}))
+
+	session.AssertNotCalled(t, "GuildMemberRoleRemove", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestManager_ReactionRole_Message(t *testing.T) {
+	mgr := newReactionRoleManager(t, config.TriggerConfig{})
+
+	session := &testutil.MockDiscordSession{}
+	session.On("GuildMemberRoleAdd", "guild123", "user123", "role123").Return(nil).Once()
+
+	require.NoError(t, mgr.HandleReaction(t.Context(), session, &discordgo.MessageReactionAdd{MessageReaction: reactionEvent("msg2", "🎮")}))
+	require.NoError(t, mgr.HandleReaction(t.Context(), session, &discordgo.MessageReactionAdd{MessageReaction: reactionEvent("msg1", "🎮")}))
+
+	session.AssertExpectations(t)
+}
+
+func TestManager_ReactionRole_CustomEmoji(t *testing.T) {
+	mgr := newReactionRoleManager(t, config.TriggerConfig{Emoji: "<:gamer:111>"})
+
+	session := &testutil.MockDiscordSession{}
+	session.On("GuildMemberRoleAdd", "guild123", "user123", "role123").Return(nil).Once()
+
+	// An emoji of another server with the same name does not match
+	other := reactionEvent("msg1", "gamer")
+	other.Emoji.ID = "222"
+	require.NoError(t, mgr.HandleReaction(t.Context(), session, &discordgo.MessageReactionAdd{MessageReaction: other}))
+
+	custom := reactionEvent("msg1", "gamer")
+	custom.Emoji.ID = "111"
+	require.NoError(t, mgr.HandleReaction(t.Context(), session, &discordgo.MessageReactionAdd{MessageReaction: custom}))
+
+	session.AssertExpectations(t)
+}
+
+func TestNewManager_ReactionRoleWithoutRole(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	_, err := action.NewManager(&config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{Name: "gamer-role", Type: "reaction_role", Trigger: config.TriggerConfig{Emoji: "🎮", MessageID: "msg1"}},
+		},
+	}, logger)
+	assert.ErrorContains(t, err, "requires an emoji, a role and a message")
+}
@@ -0,0 +1,227 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+)
+
+// Built-in action type letting users schedule reminders, e.g. !remindme 2h do laundry
+const reminderType = "reminder"
+
+// remindersCommand lists and cancels the pending reminders of the user
+const remindersCommand = "reminders"
+
+// Limits of a reminder
+const (
+	maxReminderDelay         = 365 * 24 * time.Hour
+	maxReminderMessageLength = 500
+	maxPendingReminders      = 25
+)
+
+// errReminderTooFar is returned for delays past maxReminderDelay that cannot be represented
+var errReminderTooFar = errors.New("reminder delay is too long")
+
+// ReminderHandler schedules reminders sent to the user by DM once due,
+// and lists or cancels them with !reminders list and !reminders cancel ID
+type ReminderHandler struct {
+	*CommandHandler
+	reminders *CommandHandler
+	mgr       *Manager
+}
+
+// newReminderHandler creates the handler of a reminder action
+func newReminderHandler(mgr *Manager, prefix, command string) *ReminderHandler {
+	return &ReminderHandler{
+		CommandHandler: NewCommandHandler(prefix, command),
+		reminders:      NewCommandHandler(prefix, remindersCommand),
+		mgr:            mgr,
+	}
+}
+
+// Matches checks if the content is a reminder or a reminders command
+func (h *ReminderHandler) Matches(content string) bool {
+	return h.CommandHandler.Matches(content) || h.reminders.Matches(content)
+}
+
+// Respond schedules, lists or cancels reminders and replies in the channel
+func (h *ReminderHandler) Respond(ctx context.Context, session response.DiscordSession, message *discordgo.Message) error {
+	if message.Author == nil {
+		return nil
+	}
+
+	var reply string
+	var err error
+	if h.CommandHandler.Matches(message.Content) {
+		reply, err = h.remind(message.Author.ID, h.ExtractArgs(message.Content), time.Now())
+	} else {
+		reply, err = h.manage(message.Author.ID, h.reminders.ExtractArgs(message.Content))
+	}
+	if err != nil {
+		return err
+	}
+
+	// Listed reminder messages are user input, mentions in them do not notify anyone
+	if _, err := session.ChannelMessageSendComplex(message.ChannelID, &discordgo.MessageSend{
+		Content:         reply,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}); err != nil {
+		return fmt.Errorf("failed to send reminder reply: %w", err)
+	}
+	return nil
+}
+
+// remind stores a reminder and returns the confirmation
+func (h *ReminderHandler) remind(userID string, args []string, now time.Time) (string, error) {
+	if len(args) < 2 {
+		return fmt.Sprintf("usage: %s DURATION MESSAGE, e.g. %s 2h do laundry", h.command, h.command), nil
+	}
+
+	delay, err := parseReminderDelay(args[0])
+	if err != nil && !errors.Is(err, errReminderTooFar) {
+		return fmt.Sprintf("invalid duration %q, use e.g. 30m, 2h or 1d", args[0]), nil
+	}
+	if err != nil || delay > maxReminderDelay {
+		return "reminders are limited to one year ahead", nil
+	}
+
+	text := strings.Join(args[1:], " ")
+	if len(text) > maxReminderMessageLength {
+		return fmt.Sprintf("reminder messages are limited to %d characters", maxReminderMessageLength), nil
+	}
+
+	pending, err := h.mgr.reminders.PendingReminders(userID)
+	if err != nil {
+		return "", err
+	}
+	if len(pending) >= maxPendingReminders {
+		return fmt.Sprintf("you already have %d pending reminders", maxPendingReminders), nil
+	}
+
+	fireAt := now.Add(delay)
+	id, err := h.mgr.reminders.AddReminder(storage.Reminder{UserID: userID, Message: text, FireAt: fireAt})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("⏰ I will remind you %s (reminder %d)", response.DiscordTimestamp(fireAt, "R"), id), nil
+}
+
+// manage lists or cancels the reminders of the user
+func (h *ReminderHandler) manage(userID string, args []string) (string, error) {
+	usage := fmt.Sprintf("usage: %s list, or %s cancel ID", remindersCommand, remindersCommand)
+
+	switch {
+	case len(args) == 1 && args[0] == "list":
+		pending, err := h.mgr.reminders.PendingReminders(userID)
+		if err != nil {
+			return "", err
+		}
+		if len(pending) == 0 {
+			return "you have no pending reminders", nil
+		}
+
+		lines := make([]string, 0, len(pending)+1)
+		lines = append(lines, "Pending reminders:")
+		for _, reminder := range pending {
+			lines = append(lines, fmt.Sprintf("`%d` %s %s", reminder.ID, response.DiscordTimestamp(reminder.FireAt, "R"), reminder.Message))
+		}
+		return strings.Join(lines, "\n"), nil
+
+	case len(args) == 2 && args[0] == "cancel":
+		id, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return usage, nil
+		}
+
+		deleted, err := h.mgr.reminders.DeleteReminder(userID, id)
+		if err != nil {
+			return "", err
+		}
+		if !deleted {
+			return fmt.Sprintf("reminder %d not found", id), nil
+		}
+		return fmt.Sprintf("reminder %d cancelled", id), nil
+	}
+
+	return usage, nil
+}
+
+// parseReminderDelay parses a Go duration such as 90m or 2h30m, or a number of days such as 3d
+func parseReminderDelay(value string) (time.Duration, error) {
+	var delay time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		count, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		// Bounded before multiplying so a huge count cannot overflow into a valid delay
+		if count > int(maxReminderDelay/(24*time.Hour)) {
+			return 0, errReminderTooFar
+		}
+		delay = time.Duration(count) * 24 * time.Hour
+	} else {
+		var err error
+		if delay, err = time.ParseDuration(value); err != nil {
+			return 0, err
+		}
+	}
+
+	if delay <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %s", value)
+	}
+	return delay, nil
+}
+
+// SetReminders sets the store of reminders, they are kept in memory by default
+func (m *Manager) SetReminders(reminders storage.Reminders) {
+	m.reminders = reminders
+}
+
+// DeliverReminders sends the due reminders to their users by DM and deletes them.
+// Reminders that could not be delivered are deleted too so they are not retried forever.
+func (m *Manager) DeliverReminders(ctx context.Context, session response.DiscordSession) error {
+	due, err := m.reminders.DueReminders(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if m.queue != nil {
+		session = m.queue.Wrap(session)
+	}
+
+	var errs []error
+	for _, reminder := range due {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		if err := sendReminder(session, reminder); err != nil {
+			errs = append(errs, fmt.Errorf("reminder %d: %w", reminder.ID, err))
+		}
+		if _, err := m.reminders.DeleteReminder(reminder.UserID, reminder.ID); err != nil {
+			errs = append(errs, fmt.Errorf("reminder %d: %w", reminder.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sendReminder sends a reminder to its user by DM
+func sendReminder(session response.DiscordSession, reminder storage.Reminder) error {
+	channel, err := session.UserChannelCreate(reminder.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to open DM channel: %w", err)
+	}
+
+	if _, err := session.ChannelMessageSend(channel.ID, "⏰ Reminder: "+reminder.Message); err != nil {
+		return fmt.Errorf("failed to send reminder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package action_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Reminders(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{Name: "remindme", Type: "reminder", Trigger: config.TriggerConfig{Command: "remindme"}},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	reminders := storage.NewMemoryReminders()
+	mgr.SetReminders(reminders)
+
+	session := &testutil.MockDiscordSession{}
+	send := func(content string) {
+		t.Helper()
+		require.NoError(t, mgr.HandleMessage(context.Background(), session, &discordgo.MessageCreate{
+			Message: &discordgo.Message{
+				ID:        content,
+				Content:   content,
+				ChannelID: "channel123",
+				Author:    &discordgo.User{ID: "user123"},
+			},
+		}))
+	}
+
+	confirmation := regexp.MustCompile(`^⏰ I will remind you <t:[0-9]+:R> \(reminder 1\)$`)
+	listing := regexp.MustCompile("^Pending reminders:\n`1` <t:[0-9]+:R> do laundry$")
+
+	session.On("ChannelMessageSendComplex", "channel123", quietReplyMatching(confirmation)).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReplyMatching(listing)).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReply(`invalid duration "soon", use e.g. 30m, 2h or 1d`)).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReply("reminder 2 not found")).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReply("reminder 1 cancelled")).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReply("you have no pending reminders")).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendComplex", "channel123", quietReply("reminders are limited to one year ahead")).Return(&discordgo.Message{}, nil).Once()
+
+	send("!remindme 2h do laundry")
+	send("!reminders list")
+	send("!remindme soon do laundry")
+	send("!reminders cancel 2")
+	send("!reminders cancel 1")
+	send("!reminders  list")
+	send("!remindme 106751991167301d later")
+
+	session.AssertExpectations(t)
+}
+
+func TestManager_DeliverReminders(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(&config.Config{}, logger)
+	require.NoError(t, err)
+
+	reminders := storage.NewMemoryReminders()
+	mgr.SetReminders(reminders)
+
+	_, err = reminders.AddReminder(storage.Reminder{UserID: "user123", Message: "do laundry", FireAt: time.Now().Add(-time.Second)})
+	require.NoError(t, err)
+	_, err = reminders.AddReminder(storage.Reminder{UserID: "user123", Message: "later", FireAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("UserChannelCreate", "user123").Return(&discordgo.Channel{ID: "dm123"}, nil).Once()
+	session.On("ChannelMessageSend", "dm123", "⏰ Reminder: do laundry").Return(&discordgo.Message{}, nil).Once()
+
+	require.NoError(t, mgr.DeliverReminders(context.Background(), session))
+	session.AssertExpectations(t)
+
+	// Delivered reminders are removed, future ones are kept
+	pending, err := reminders.PendingReminders("user123")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "later", pending[0].Message)
+}
+
+// quietReplyMatching matches a reply sent without notifying mentions whose content matches pattern
+func quietReplyMatching(pattern *regexp.Regexp) any {
+	return mock.MatchedBy(func(data *discordgo.MessageSend) bool {
+		return pattern.MatchString(data.Content) && data.AllowedMentions != nil && len(data.AllowedMentions.Parse) == 0
+	})
+}
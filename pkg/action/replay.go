@@ -0,0 +1,96 @@
+package action
+
+import (
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// processedFactor is how many processed message IDs are remembered per buffered message,
+// messages handled without being buffered also take a slot
+const processedFactor = 2
+
+// EventBuffer keeps the last received messages so that the ones not
+// processed before a gateway reconnect can be replayed
+type EventBuffer struct {
+	mu     sync.Mutex
+	events []*discordgo.MessageCreate
+	next   int
+
+	// processed holds the IDs of the last processed messages, the oldest is forgotten when order is full
+	processed     map[string]struct{}
+	order         []string
+	nextProcessed int
+}
+
+// NewEventBuffer creates a buffer holding up to size messages
+func NewEventBuffer(size int) *EventBuffer {
+	return &EventBuffer{
+		events:    make([]*discordgo.MessageCreate, size),
+		processed: make(map[string]struct{}, size*processedFactor),
+		order:     make([]string, size*processedFactor),
+	}
+}
+
+// Add stores a received message, evicting the oldest one when the buffer is full
+func (b *EventBuffer) Add(message *discordgo.MessageCreate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events[b.next] = message
+	b.next = (b.next + 1) % len(b.events)
+}
+
+// MarkProcessed records a message as processed.
+// It returns false if the message was already processed.
+func (b *EventBuffer) MarkProcessed(messageID string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, done := b.processed[messageID]; done {
+		return false
+	}
+
+	if forgotten := b.order[b.nextProcessed]; forgotten != "" {
+		delete(b.processed, forgotten)
+	}
+	b.order[b.nextProcessed] = messageID
+	b.nextProcessed = (b.nextProcessed + 1) % len(b.order)
+	b.processed[messageID] = struct{}{}
+
+	return true
+}
+
+// Pending returns the buffered messages not processed yet, oldest first
+func (b *EventBuffer) Pending() []*discordgo.MessageCreate {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var pending []*discordgo.MessageCreate
+	for i := range b.events {
+		message := b.events[(b.next+i)%len(b.events)]
+		if message == nil {
+			continue
+		}
+		if _, done := b.processed[message.ID]; !done {
+			pending = append(pending, message)
+		}
+	}
+
+	return pending
+}
+
+// RecordMessage adds a received message to the replay buffer when one is configured
+func (m *Manager) RecordMessage(message *discordgo.MessageCreate) {
+	if m.events != nil && message.ID != "" {
+		m.events.Add(message)
+	}
+}
+
+// PendingMessages returns the recorded messages that were not handled yet
+func (m *Manager) PendingMessages() []*discordgo.MessageCreate {
+	if m.events == nil {
+		return nil
+	}
+	return m.events.Pending()
+}
@@ -0,0 +1,172 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// Built-in action type counting the reactions of a message as votes, e.g. !tally 123456789
+const voteTallyType = "vote_tally"
+
+// reactionsPageSize is the maximum number of users returned by a page of reactions
+const reactionsPageSize = 100
+
+// tallyBarWidth is the number of characters of the longest bar of the tally
+const tallyBarWidth = 10
+
+// tallyBarParts are the characters drawing a bar, from a full cell down to a quarter of a cell
+var tallyBarParts = []string{"█", "▓", "▒", "░"}
+
+// emojiVotes is the number of votes for an emoji
+type emojiVotes struct {
+	emoji string
+	votes int
+}
+
+// VoteTallyHandler counts the reactions of a message as votes and posts them as a bar chart,
+// the message is given by ID in the current channel or by link
+type VoteTallyHandler struct {
+	*CommandHandler
+	maxEmojis int
+}
+
+// newVoteTallyHandler creates the handler of a vote_tally action showing up to maxEmojis emojis, all of them if 0
+func newVoteTallyHandler(prefix, command string, maxEmojis int) *VoteTallyHandler {
+	return &VoteTallyHandler{
+		CommandHandler: NewCommandHandler(prefix, command),
+		maxEmojis:      maxEmojis,
+	}
+}
+
+// Respond counts the votes on the message and replies with the tally
+func (h *VoteTallyHandler) Respond(ctx context.Context, session response.DiscordSession, message *discordgo.Message) error {
+	args := h.ExtractArgs(message.Content)
+	if len(args) != 1 {
+		if _, err := session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("usage: %s MESSAGE_ID", h.command)); err != nil {
+			return fmt.Errorf("failed to send tally usage: %w", err)
+		}
+		return nil
+	}
+
+	channelID, messageID := parseMessageRef(message.ChannelID, args[0])
+	votes, err := countVotes(session, channelID, messageID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := session.ChannelMessageSendEmbed(message.ChannelID, h.tallyEmbed(votes)); err != nil {
+		return fmt.Errorf("failed to send tally: %w", err)
+	}
+	return nil
+}
+
+// parseMessageRef returns the channel and message of a message ID or link,
+// a bare ID refers to a message of the current channel
+func parseMessageRef(channelID, ref string) (string, string) {
+	ref = strings.Trim(ref, "<>")
+	if _, path, ok := strings.Cut(ref, "/channels/"); ok {
+		if parts := strings.Split(path, "/"); len(parts) == 3 {
+			return parts[1], parts[2]
+		}
+	}
+	return channelID, ref
+}
+
+// countVotes returns the votes per emoji on the message sorted by count, ignoring the reactions of bots
+func countVotes(session response.DiscordSession, channelID, messageID string) ([]emojiVotes, error) {
+	msg, err := session.ChannelMessage(channelID, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch message %s: %w", messageID, err)
+	}
+
+	votes := make([]emojiVotes, 0, len(msg.Reactions))
+	for _, reaction := range msg.Reactions {
+		if reaction.Emoji == nil {
+			continue
+		}
+
+		count, err := countReactionUsers(session, channelID, messageID, reaction.Emoji.APIName())
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, emojiVotes{emoji: reaction.Emoji.MessageFormat(), votes: count})
+	}
+
+	sort.SliceStable(votes, func(i, j int) bool {
+		return votes[i].votes > votes[j].votes
+	})
+	return votes, nil
+}
+
+// countReactionUsers counts the users other than bots having reacted with the emoji, page by page
+func countReactionUsers(session response.DiscordSession, channelID, messageID, emojiID string) (int, error) {
+	var count int
+	var after string
+	for {
+		users, err := session.MessageReactions(channelID, messageID, emojiID, reactionsPageSize, "", after)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch %s reactions: %w", emojiID, err)
+		}
+
+		for _, user := range users {
+			if !user.Bot {
+				count++
+			}
+		}
+		if len(users) < reactionsPageSize {
+			return count, nil
+		}
+		after = users[len(users)-1].ID
+	}
+}
+
+// tallyEmbed renders the votes as a bar chart
+func (h *VoteTallyHandler) tallyEmbed(votes []emojiVotes) *discordgo.MessageEmbed {
+	var total int
+	for _, v := range votes {
+		total += v.votes
+	}
+
+	if h.maxEmojis > 0 && len(votes) > h.maxEmojis {
+		votes = votes[:h.maxEmojis]
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  "Vote results",
+		Footer: &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("%d votes", total)},
+	}
+	if total == 0 {
+		embed.Description = "No votes yet"
+		return embed
+	}
+
+	lines := make([]string, 0, len(votes))
+	for _, v := range votes {
+		lines = append(lines, fmt.Sprintf("%s `%s` %d (%d%%)", v.emoji, tallyBar(v.votes, votes[0].votes), v.votes, v.votes*100/total))
+	}
+	embed.Description = strings.Join(lines, "\n")
+	return embed
+}
+
+// tallyBar draws a bar of the count relative to the highest count, padded to tallyBarWidth
+func tallyBar(count, highest int) string {
+	if highest <= 0 {
+		return strings.Repeat(" ", tallyBarWidth)
+	}
+
+	quarters := count * tallyBarWidth * 4 / highest
+	full, rest := quarters/4, quarters%4
+
+	bar := strings.Repeat(tallyBarParts[0], full)
+	cells := full
+	if rest > 0 {
+		bar += tallyBarParts[4-rest]
+		cells++
+	}
+	return bar + strings.Repeat(" ", tallyBarWidth-cells)
+}
@@ -0,0 +1,100 @@
+package action_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// reactionUsers returns count users with IDs starting at from
+func reactionUsers(from, count int) []*discordgo.User {
+	users := make([]*discordgo.User, count)
+	for i := range users {
+		users[i] = &discordgo.User{ID: fmt.Sprint(from + i)}
+	}
+	return users
+}
+
+func TestManager_VoteTally(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{Name: "tally", Type: "vote_tally", Trigger: config.TriggerConfig{MaxEmojis: 2}},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessage", "channel123", "poll1").Return(&discordgo.Message{
+		Reactions: []*discordgo.MessageReactions{
+			{Emoji: &discordgo.Emoji{Name: "👎"}, Count: 2},
+			{Emoji: &discordgo.Emoji{Name: "👍"}, Count: 105},
+			{Emoji: &discordgo.Emoji{Name: "party", ID: "42"}, Count: 50},
+		},
+	}, nil)
+
+	// 👍 has more than a page of reactions, the reactions of bots are not votes
+	session.On("MessageReactions", "channel123", "poll1", "👍", 100, "", "").Return(reactionUsers(1, 100), nil).Once()
+	session.On("MessageReactions", "channel123", "poll1", "👍", 100, "", "100").Return(reactionUsers(101, 5), nil).Once()
+	session.On("MessageReactions", "channel123", "poll1", "👎", 100, "", "").Return([]*discordgo.User{{ID: "1"}, {ID: "bot", Bot: true}}, nil).Once()
+	session.On("MessageReactions", "channel123", "poll1", "party:42", 100, "", "").Return(reactionUsers(1, 50), nil).Once()
+
+	var tally *discordgo.MessageEmbed
+	session.On("ChannelMessageSendEmbed", "channel123", mock.Anything).Run(func(args mock.Arguments) {
+		tally = args.Get(1).(*discordgo.MessageEmbed)
+	}).Return(&discordgo.Message{}, nil).Once()
+
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, benchMessage("!tally poll1")))
+
+	require.NotNil(t, tally)
+	assert.Equal(t, "Vote results", tally.Title)
+	assert.Equal(t, "156 votes", tally.Footer.Text)
+	assert.Equal(t, "👍 `██████████` 105 (67%)\n<:party:42> `████▓     ` 50 (32%)", tally.Description)
+	session.AssertExpectations(t)
+}
+
+func TestManager_VoteTallyLink(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{Name: "votes", Type: "vote_tally", Trigger: config.TriggerConfig{Command: "votes"}},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessage", "polls", "poll2").Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSendEmbed", "channel123", mock.MatchedBy(func(embed *discordgo.MessageEmbed) bool {
+		return embed.Description == "No votes yet"
+	})).Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSend", "channel123", "usage: votes MESSAGE_ID").Return(&discordgo.Message{}, nil).Once()
+
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, benchMessage("!votes https://discord.com/channels/guild123/polls/poll2")))
+	require.NoError(t, mgr.HandleMessage(context.Background(), session, benchMessage("!votes")))
+
+	session.AssertExpectations(t)
+}
@@ -0,0 +1,121 @@
+package action
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CommandTrie maps prefix+command keys to the indexes of the actions handling them,
+// so commands are found in the length of the key instead of scanning all actions
+type CommandTrie struct {
+	root *trieNode
+}
+
+// trieNode is a node of the command trie
+type trieNode struct {
+	children map[rune]*trieNode
+	actions  []int
+}
+
+// NewCommandTrie creates an empty command trie
+func NewCommandTrie() *CommandTrie {
+	return &CommandTrie{root: &trieNode{}}
+}
+
+// Insert adds the index of an action handling key, indexes are kept in insertion order
+func (t *CommandTrie) Insert(key string, index int) {
+	node := t.root
+	for _, r := range key {
+		child, ok := node.children[r]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+			child = &trieNode{}
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.actions = append(node.actions, index)
+}
+
+// Lookup returns the indexes of the actions handling key
+func (t *CommandTrie) Lookup(key string) []int {
+	node := t.root
+	for _, r := range key {
+		child, ok := node.children[r]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+	return node.actions
+}
+
+// commandKey returns the prefix+command key of a message, the command is its first word after the prefix
+func commandKey(prefix, content string) (string, bool) {
+	content, ok := strings.CutPrefix(strings.TrimSpace(content), prefix)
+	if !ok {
+		return "", false
+	}
+
+	command := strings.TrimSpace(content)
+	if end := strings.IndexFunc(command, unicode.IsSpace); end >= 0 {
+		command = command[:end]
+	}
+	if command == "" {
+		return "", false
+	}
+	return prefix + strings.ToLower(command), true
+}
+
+// actionIndex selects the actions that may match a message: commands through
+// the trie, the other actions, such as patterns and reactions, from a list
+type actionIndex struct {
+	prefix   string
+	commands *CommandTrie
+	others   []int
+}
+
+// newActionIndex indexes actions by their position
+func newActionIndex(prefix string, actions []Action) *actionIndex {
+	index := &actionIndex{prefix: prefix, commands: NewCommandTrie()}
+	for i, action := range actions {
+		if handler, ok := action.Handler.(*CommandHandler); ok && handler.prefix == prefix {
+			index.commands.Insert(prefix+handler.command, i)
+			continue
+		}
+		index.others = append(index.others, i)
+	}
+	return index
+}
+
+// candidates returns the indexes of the actions to check for content, in configuration order
+func (idx *actionIndex) candidates(content string) []int {
+	key, ok := commandKey(idx.prefix, content)
+	if !ok {
+		return idx.others
+	}
+
+	commands := idx.commands.Lookup(key)
+	if len(commands) == 0 {
+		return idx.others
+	}
+	if len(idx.others) == 0 {
+		return commands
+	}
+
+	merged := make([]int, 0, len(commands)+len(idx.others))
+	i, j := 0, 0
+	for i < len(commands) && j < len(idx.others) {
+		if commands[i] < idx.others[j] {
+			merged = append(merged, commands[i])
+			i++
+		} else {
+			merged = append(merged, idx.others[j])
+			j++
+		}
+	}
+	merged = append(merged, commands[i:]...)
+	return append(merged, idx.others[j:]...)
+}
@@ -0,0 +1,108 @@
+package action_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandTrie(t *testing.T) {
+	trie := action.NewCommandTrie()
+	trie.Insert("!ping", 0)
+	trie.Insert("!pin", 1)
+	trie.Insert("!ping", 2)
+
+	assert.Equal(t, []int{0, 2}, trie.Lookup("!ping"))
+	assert.Equal(t, []int{1}, trie.Lookup("!pin"))
+	assert.Empty(t, trie.Lookup("!pi"))
+	assert.Empty(t, trie.Lookup("!pings"))
+	assert.Empty(t, trie.Lookup(""))
+}
+
+func TestManager_HandleMessage_ConfigurationOrder(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "any-help",
+				Type:     "message",
+				Trigger:  config.TriggerConfig{Pattern: "help"},
+				Response: config.ResponseConfig{Type: "text", Content: "pattern"},
+			},
+			{
+				Name:     "help",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "help"},
+				Response: config.ResponseConfig{Type: "text", Content: "command"},
+			},
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "Ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "pattern").Return(&discordgo.Message{}, nil).Once()
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(&discordgo.Message{}, nil).Once()
+
+	// The pattern action comes first in the configuration and wins over the command
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!help")))
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("  !PING\tnow")))
+	require.NoError(t, mgr.HandleMessage(t.Context(), session, benchMessage("!pingpong")))
+
+	session.AssertExpectations(t)
+}
+
+// BenchmarkCommandDispatch_Trie looks up the last of 500 commands in the trie
+func BenchmarkCommandDispatch_Trie(b *testing.B) {
+	trie := action.NewCommandTrie()
+	for i := range 500 {
+		trie.Insert(fmt.Sprintf("!cmd%d", i), i)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if len(trie.Lookup("!cmd499")) == 0 {
+			b.Fatal("command not found")
+		}
+	}
+}
+
+// BenchmarkCommandDispatch_List scans 500 command handlers for the last one
+func BenchmarkCommandDispatch_List(b *testing.B) {
+	handlers := make([]*action.CommandHandler, 0, 500)
+	for i := range 500 {
+		handlers = append(handlers, action.NewCommandHandler("!", fmt.Sprintf("cmd%d", i)))
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		found := false
+		for _, handler := range handlers {
+			if handler.Matches("!cmd499") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			b.Fatal("command not found")
+		}
+	}
+}
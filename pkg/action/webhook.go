@@ -0,0 +1,102 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/geekxflood/gxf-discord-bot/pkg/scheduler"
+)
+
+// webhookRetryBackoff is the wait before the first webhook retry, doubled on each attempt up to maxWebhookRetryBackoff
+const (
+	webhookRetryBackoff    = 5 * time.Second
+	maxWebhookRetryBackoff = time.Hour
+)
+
+// WebhookAttempt describes a webhook delivery that failed and is being retried
+type WebhookAttempt struct {
+	Action    string    `json:"action"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"lastError"`
+	NextRetry time.Time `json:"nextRetry,omitempty"`
+}
+
+// webhookDeliveries tracks failed webhook deliveries by delivery ID
+type webhookDeliveries struct {
+	attempts sync.Map // map[string]WebhookAttempt
+	nextID   atomic.Uint64
+}
+
+// SetScheduler sets the scheduler used to retry failed webhook deliveries
+func (m *Manager) SetScheduler(sched *scheduler.Scheduler) {
+	m.scheduler = sched
+}
+
+// WebhookDeliveryStats returns the failed webhook deliveries by delivery ID.
+// Deliveries are removed once a retry succeeds or the retries are exhausted.
+func (m *Manager) WebhookDeliveryStats() map[string]WebhookAttempt {
+	stats := make(map[string]WebhookAttempt)
+	m.webhooks.attempts.Range(func(key, value any) bool {
+		stats[key.(string)] = value.(WebhookAttempt)
+		return true
+	})
+	return stats
+}
+
+// trackWebhookFailure records a failed webhook delivery and schedules a retry.
+// It returns false when the delivery is not retried.
+func (m *Manager) trackWebhookFailure(session response.DiscordSession, message *discordgo.Message, actionCfg config.ActionConfig, err error) bool {
+	if m.scheduler == nil || actionCfg.Response.MaxRetries <= 0 {
+		return false
+	}
+
+	deliveryID := fmt.Sprintf("%s-%d", actionCfg.Name, m.webhooks.nextID.Add(1))
+	attempt := WebhookAttempt{
+		Action:   actionCfg.Name,
+		Attempts: 1,
+	}
+
+	m.scheduleWebhookRetry(deliveryID, attempt, session, message, actionCfg, err)
+	return true
+}
+
+// scheduleWebhookRetry stores the delivery state and schedules the next attempt if retries remain
+func (m *Manager) scheduleWebhookRetry(deliveryID string, attempt WebhookAttempt, session response.DiscordSession, message *discordgo.Message, actionCfg config.ActionConfig, err error) {
+	attempt.LastError = err.Error()
+
+	// Exhausted deliveries are reported to the dead letter destinations and forgotten
+	if attempt.Attempts > actionCfg.Response.MaxRetries {
+		m.webhooks.attempts.Delete(deliveryID)
+		m.logger.Error("Webhook delivery failed permanently", "action", actionCfg.Name, "delivery", deliveryID, "attempts", attempt.Attempts, "error", err)
+		m.deadLetter(session, message, actionCfg, err)
+		return
+	}
+
+	delay := webhookRetryBackoff
+	for i := 1; i < attempt.Attempts && delay < maxWebhookRetryBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxWebhookRetryBackoff)
+	attempt.NextRetry = time.Now().Add(delay)
+	m.webhooks.attempts.Store(deliveryID, attempt)
+
+	m.scheduler.ScheduleOnce(deliveryID, delay, func(ctx context.Context) error {
+		attempt.Attempts++
+
+		retryErr := response.ExecuteWithContext(ctx, session, message, actionCfg.Response, response.NewTemplateContext(message), m.logger)
+		if retryErr == nil {
+			m.webhooks.attempts.Delete(deliveryID)
+			m.logger.Info("Webhook delivered after retry", "action", actionCfg.Name, "delivery", deliveryID, "attempts", attempt.Attempts)
+			return nil
+		}
+
+		m.scheduleWebhookRetry(deliveryID, attempt, session, message, actionCfg, retryErr)
+		return nil
+	})
+}
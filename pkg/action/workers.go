@@ -0,0 +1,66 @@
+package action
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/alitto/pond/v2"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+)
+
+// Default worker pool settings used when none are configured
+const (
+	defaultPoolSize      = 10
+	defaultQueueCapacity = 100
+)
+
+// WorkerPoolStats describes the activity of the worker pool
+type WorkerPoolStats struct {
+	SubmittedTasks uint64 `json:"submittedTasks"`
+	WaitingTasks   uint64 `json:"waitingTasks"`
+	RunningWorkers int64  `json:"runningWorkers"`
+}
+
+// newWorkerPool creates the worker pool from the bot configuration
+func newWorkerPool(cfg *config.WorkersConfig) pond.Pool {
+	poolSize, queueCapacity := defaultPoolSize, defaultQueueCapacity
+	if cfg != nil {
+		poolSize, queueCapacity = cfg.PoolSize, cfg.QueueCapacity
+	}
+
+	return pond.NewPool(poolSize, pond.WithQueueSize(queueCapacity))
+}
+
+// Submit runs a task on the worker pool.
+// It returns an error instead of blocking when the queue is full.
+// A panicking task is logged and does not stop the worker.
+func (m *Manager) Submit(task func()) error {
+	m.metrics.queueDepth.Add(1)
+	if _, ok := m.pool.TrySubmit(func() {
+		defer m.metrics.queueDepth.Add(-1)
+		defer func() {
+			if r := recover(); r != nil {
+				m.logger.Error("Recovered from panic in worker", "panic", r, "stack", string(debug.Stack()))
+			}
+		}()
+		task()
+	}); !ok {
+		m.metrics.queueDepth.Add(-1)
+		return fmt.Errorf("worker pool queue is full")
+	}
+	return nil
+}
+
+// WorkerStats returns the current worker pool metrics
+func (m *Manager) WorkerStats() WorkerPoolStats {
+	return WorkerPoolStats{
+		SubmittedTasks: m.pool.SubmittedTasks(),
+		WaitingTasks:   m.pool.WaitingTasks(),
+		RunningWorkers: m.pool.RunningWorkers(),
+	}
+}
+
+// StopWorkers stops the worker pool after the queued tasks complete
+func (m *Manager) StopWorkers() {
+	m.pool.StopAndWait()
+}
@@ -0,0 +1,393 @@
+// Package admin provides an authenticated REST API for runtime bot management.
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/geekxflood/common/logging"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/ratelimit"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/geekxflood/gxf-discord-bot/pkg/scheduler"
+)
+
+// Dependencies contains the bot components managed through the admin API
+type Dependencies struct {
+	Actions     *action.Manager
+	RateLimiter *ratelimit.Limiter
+	Scheduler   *scheduler.Scheduler
+	Gateway     GatewayStatus
+	Config      *config.Manager
+	Batches     *response.BatchSender
+	OAuth       OAuthChecker
+}
+
+// GatewayStatus reports the state of the Discord gateway connection
+type GatewayStatus interface {
+	ReconnectAttempts() int
+}
+
+// Server is the admin HTTP server
+type Server struct {
+	addr       string
+	token      string
+	deps       Dependencies
+	logger     logging.Logger
+	httpServer *http.Server
+	listen     ListenFunc
+	mu         sync.Mutex
+}
+
+// ListenFunc opens the listener of the admin API
+type ListenFunc func(network, addr string) (net.Listener, error)
+
+// actionInfo is the JSON representation of an action
+type actionInfo struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	ResponseType string `json:"responseType"`
+	Enabled      bool   `json:"enabled"`
+}
+
+// jobInfo is the JSON representation of a scheduled job
+type jobInfo struct {
+	ID       string     `json:"id"`
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule"`
+	NextRun  *time.Time `json:"nextRun,omitempty"`
+	Paused   bool       `json:"paused"`
+}
+
+// New creates a new admin server listening on the given port
+func New(port int, token string, deps Dependencies, logger logging.Logger) *Server {
+	return &Server{
+		addr:   fmt.Sprintf(":%d", port),
+		token:  token,
+		deps:   deps,
+		logger: logger,
+		listen: net.Listen,
+	}
+}
+
+// SetListenFunc replaces the function opening the listener, for example
+// to inherit the socket from a parent process during a graceful restart
+func (s *Server) SetListenFunc(listen ListenFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listen = listen
+}
+
+// Handler returns the authenticated HTTP handler serving the admin API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /actions", s.handleListActions)
+	mux.HandleFunc("POST /actions/{name}/enable", s.handleSetActionEnabled(true))
+	mux.HandleFunc("POST /actions/{name}/disable", s.handleSetActionEnabled(false))
+	mux.HandleFunc("GET /ratelimit/{userID}", s.handleGetRateLimit)
+	mux.HandleFunc("DELETE /ratelimit/{userID}", s.handleResetRateLimit)
+	mux.HandleFunc("GET /scheduler/jobs", s.handleListJobs)
+	mux.HandleFunc("POST /scheduler/jobs/{id}/pause", s.handlePauseJob)
+	mux.HandleFunc("GET /stats", s.handleListStats)
+	mux.HandleFunc("DELETE /stats/{name}", s.handleResetStats)
+	mux.HandleFunc("GET /webhooks", s.handleWebhookDeliveries)
+	mux.HandleFunc("GET /health/components", s.handleHealthComponents)
+	mux.HandleFunc("GET /auth/health", s.handleAuthHealth)
+	mux.HandleFunc("GET /debug/events", s.handleDebugEvents)
+	mux.HandleFunc("PATCH /config", s.handlePatchConfig)
+
+	return s.authenticate(mux)
+}
+
+// Start starts serving the admin API in the background
+func (s *Server) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpServer != nil {
+		return fmt.Errorf("admin server already running")
+	}
+
+	listener, err := s.listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+
+	s.httpServer = &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	httpServer := s.httpServer
+	go func() {
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Admin server failed", "error", err)
+		}
+	}()
+
+	s.logger.Info("Admin API started", "addr", listener.Addr().String())
+	return nil
+}
+
+// Stop gracefully shuts down the admin API
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	httpServer := s.httpServer
+	s.httpServer = nil
+	s.mu.Unlock()
+
+	if httpServer == nil {
+		return nil
+	}
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown admin server: %w", err)
+	}
+
+	s.logger.Info("Admin API stopped")
+	return nil
+}
+
+// authenticate rejects requests without the configured bearer token
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleListActions lists all loaded actions
+func (s *Server) handleListActions(w http.ResponseWriter, r *http.Request) {
+	configs := s.deps.Actions.GetActions()
+
+	actions := make([]actionInfo, 0, len(configs))
+	for _, cfg := range configs {
+		actions = append(actions, actionInfo{
+			Name:         cfg.Name,
+			Type:         cfg.Type,
+			Description:  cfg.Description,
+			ResponseType: cfg.Response.Type,
+			Enabled:      s.deps.Actions.IsEnabled(cfg.Name),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, actions)
+}
+
+// handleSetActionEnabled toggles an action on or off
+func (s *Server) handleSetActionEnabled(enabled bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+
+		var err error
+		if enabled {
+			err = s.deps.Actions.EnableAction(name)
+		} else {
+			err = s.deps.Actions.DisableAction(name)
+		}
+
+		if errors.As(err, &boterrors.ActionNotFoundError{}) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"name":    name,
+			"enabled": enabled,
+		})
+	}
+}
+
+// handleGetRateLimit returns the rate limit status of a user
+func (s *Server) handleGetRateLimit(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("userID")
+
+	writeJSON(w, http.StatusOK, struct {
+		UserID string `json:"userID"`
+		ratelimit.BucketStatus
+	}{UserID: userID, BucketStatus: s.deps.RateLimiter.Status(userID)})
+}
+
+// handleResetRateLimit resets the rate limit for a user
+func (s *Server) handleResetRateLimit(w http.ResponseWriter, r *http.Request) {
+	s.deps.RateLimiter.ResetUser(r.PathValue("userID"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListJobs lists all scheduled jobs with their next run time
+func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	infos := s.deps.Scheduler.ListJobs()
+
+	jobs := make([]jobInfo, 0, len(infos))
+	for _, info := range infos {
+		job := jobInfo{
+			ID:       info.ID,
+			Name:     info.Name,
+			Schedule: info.Schedule,
+			Paused:   info.Paused,
+		}
+		if !info.NextRun.IsZero() {
+			nextRun := info.NextRun
+			job.NextRun = &nextRun
+		}
+		jobs = append(jobs, job)
+	}
+
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+// handlePauseJob pauses a scheduled job
+func (s *Server) handlePauseJob(w http.ResponseWriter, r *http.Request) {
+	jobID := r.PathValue("id")
+
+	if err := s.deps.Scheduler.PauseJob(jobID); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":     jobID,
+		"paused": true,
+	})
+}
+
+// handleListStats lists the usage of the actions, the most used first
+func (s *Server) handleListStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.deps.Actions.Stats().Top(0))
+}
+
+// handleResetStats resets the usage of an action
+func (s *Server) handleResetStats(w http.ResponseWriter, r *http.Request) {
+	if err := s.deps.Actions.Stats().Reset(r.PathValue("name")); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleWebhookDeliveries lists failed webhook deliveries and their retry state
+func (s *Server) handleWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.deps.Actions.WebhookDeliveryStats())
+}
+
+// handleHealthComponents returns the state of the bot components
+func (s *Server) handleHealthComponents(w http.ResponseWriter, r *http.Request) {
+	components := map[string]interface{}{
+		"workers": s.deps.Actions.WorkerStats(),
+		"actions": s.deps.Actions.Metrics(),
+		"logs": map[string]int64{
+			"sampledDropped": s.deps.Actions.DroppedLogs(),
+		},
+	}
+	if s.deps.Batches != nil {
+		components["batches"] = s.deps.Batches.Stats()
+	}
+	if s.deps.Gateway != nil {
+		components["gateway"] = map[string]int{
+			"reconnectAttempts": s.deps.Gateway.ReconnectAttempts(),
+		}
+	}
+	if s.deps.OAuth != nil {
+		components["auth"] = s.deps.OAuth.Check(r.Context())
+	}
+	writeJSON(w, http.StatusOK, components)
+}
+
+// handleAuthHealth checks that the OAuth provider is reachable
+func (s *Server) handleAuthHealth(w http.ResponseWriter, r *http.Request) {
+	if s.deps.OAuth == nil {
+		writeError(w, http.StatusNotFound, "OAuth authentication is not configured")
+		return
+	}
+
+	status := s.deps.OAuth.Check(r.Context())
+	if status.Status != "ok" {
+		writeJSON(w, http.StatusServiceUnavailable, status)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// configChange is the JSON body of a runtime configuration change
+type configChange struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// handlePatchConfig applies a single validated setting change, persisting it when ?persist=true
+func (s *Server) handlePatchConfig(w http.ResponseWriter, r *http.Request) {
+	if s.deps.Config == nil {
+		writeError(w, http.StatusServiceUnavailable, "runtime configuration is not available")
+		return
+	}
+
+	var change configChange
+	if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var err error
+	switch value := change.Value.(type) {
+	case string:
+		err = s.deps.Config.SetString(change.Key, value)
+	case bool:
+		err = s.deps.Config.SetBool(change.Key, value)
+	case float64:
+		if value != float64(int(value)) {
+			writeError(w, http.StatusBadRequest, "value must be a string, integer or boolean")
+			return
+		}
+		err = s.deps.Config.SetInt(change.Key, int(value))
+	default:
+		writeError(w, http.StatusBadRequest, "value must be a string, integer or boolean")
+		return
+	}
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("persist") == "true" {
+		if err := s.deps.Config.Persist(); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	s.logger.Info("Configuration updated", "key", change.Key)
+	writeJSON(w, http.StatusOK, change)
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,319 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/admin"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/ratelimit"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/geekxflood/gxf-discord-bot/pkg/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "secret-token"
+
+func newTestServer(t *testing.T) (*httptest.Server, admin.Dependencies) {
+	t.Helper()
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	logger.On("Warn", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "test-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+			},
+		},
+	}
+
+	actionMgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+
+	deps := admin.Dependencies{
+		Actions:     actionMgr,
+		RateLimiter: ratelimit.New(logger),
+		Scheduler:   scheduler.New(logger),
+		Config:      config.NewManager("", cfg),
+		Batches:     response.NewBatchSender(&testutil.MockDiscordSession{}, 0, logger),
+	}
+
+	server := httptest.NewServer(admin.New(0, testToken, deps, logger).Handler())
+	t.Cleanup(server.Close)
+
+	return server, deps
+}
+
+func doRequest(t *testing.T, method, url, token string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/actions", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = doRequest(t, http.MethodGet, server.URL+"/actions", "wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestServer_ListActions(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/actions", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var actions []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&actions))
+	require.Len(t, actions, 1)
+	assert.Equal(t, "ping", actions[0]["name"])
+	assert.Equal(t, true, actions[0]["enabled"])
+}
+
+func TestServer_ToggleAction(t *testing.T) {
+	server, deps := newTestServer(t)
+
+	resp := doRequest(t, http.MethodPost, server.URL+"/actions/ping/disable", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.False(t, deps.Actions.IsEnabled("ping"))
+
+	resp = doRequest(t, http.MethodPost, server.URL+"/actions/ping/enable", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, deps.Actions.IsEnabled("ping"))
+
+	resp = doRequest(t, http.MethodPost, server.URL+"/actions/unknown/disable", testToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	server, deps := newTestServer(t)
+	deps.RateLimiter.SetUserLimit(3, time.Minute)
+	deps.RateLimiter.AllowUser("user123")
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/ratelimit/user123", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, float64(2), body["remaining"])
+	assert.Equal(t, float64(3), body["total"])
+	assert.Equal(t, false, body["limited"])
+	assert.NotEmpty(t, body["resetsAt"])
+
+	resp = doRequest(t, http.MethodDelete, server.URL+"/ratelimit/user123", testToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, 3, deps.RateLimiter.GetUserRemaining("user123"))
+}
+
+func TestServer_SchedulerJobs(t *testing.T) {
+	server, deps := newTestServer(t)
+
+	jobID, err := deps.Scheduler.AddJob("daily", "0 0 9 * * *", func(ctx context.Context) error { return nil })
+	require.NoError(t, err)
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/scheduler/jobs", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var jobs []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, jobID, jobs[0]["id"])
+
+	resp = doRequest(t, http.MethodPost, server.URL+"/scheduler/jobs/"+jobID+"/pause", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	info, err := deps.Scheduler.GetJobInfo(jobID)
+	require.NoError(t, err)
+	assert.True(t, info.Paused)
+}
+
+func TestServer_Stats(t *testing.T) {
+	server, deps := newTestServer(t)
+	require.NoError(t, deps.Actions.Stats().Record("ping", "u1"))
+	require.NoError(t, deps.Actions.Stats().Record("ping", "u2"))
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/stats", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var usage []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&usage))
+	require.Len(t, usage, 1)
+	assert.Equal(t, "ping", usage[0]["action"])
+	assert.Equal(t, float64(2), usage[0]["invocationCount"])
+	assert.Equal(t, float64(2), usage[0]["uniqueUsers"])
+
+	resp = doRequest(t, http.MethodDelete, server.URL+"/stats/ping", testToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, deps.Actions.Stats().Top(0))
+}
+
+func TestServer_WebhookDeliveries(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/webhooks", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var deliveries map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&deliveries))
+	assert.Empty(t, deliveries)
+}
+
+func TestServer_HealthComponents(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	resp := doRequest(t, http.MethodGet, server.URL+"/health/components", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var components map[string]map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&components))
+	assert.Contains(t, components["workers"], "submittedTasks")
+	assert.Contains(t, components["workers"], "waitingTasks")
+	assert.Contains(t, components["workers"], "runningWorkers")
+	assert.Contains(t, components["batches"], "throughputBuckets")
+	assert.Contains(t, components["logs"], "sampledDropped")
+	assert.Contains(t, components["actions"], "actionDurationHistogram")
+	assert.Contains(t, components["actions"], "actionQueueDepth")
+}
+
+func TestServer_SetListenFunc(t *testing.T) {
+	_, deps := newTestServer(t)
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var requested string
+	server := admin.New(9090, testToken, deps, logger)
+	server.SetListenFunc(func(network, addr string) (net.Listener, error) {
+		requested = addr
+		return listener, nil
+	})
+
+	require.NoError(t, server.Start())
+	defer func() {
+		_ = server.Stop(context.Background())
+	}()
+
+	assert.Equal(t, ":9090", requested)
+
+	resp := doRequest(t, http.MethodGet, "http://"+listener.Addr().String()+"/actions", testToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServer_PatchConfig(t *testing.T) {
+	server, deps := newTestServer(t)
+
+	patch := func(body string) *http.Response {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, server.URL+"/config", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := patch(`{"key": "bot.status", "value": "Maintenance"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Maintenance", deps.Config.Config().Bot.Status)
+
+	resp = patch(`{"key": "bot.largeThreshold", "value": 100}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 100, deps.Config.Config().Bot.LargeThreshold)
+
+	resp = patch(`{"key": "bot.largeThreshold", "value": 10}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, 100, deps.Config.Config().Bot.LargeThreshold)
+
+	resp = patch(`{"key": "bot.prefix", "value": ["!"]}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// No config file is known, so persisting fails
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, server.URL+"/config?persist=true", strings.NewReader(`{"key": "bot.prefix", "value": "?"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	persistResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer persistResp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, persistResp.StatusCode)
+}
+
+func TestServer_AuthHealth(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	// Without OAuth authentication there is no provider to check
+	resp := doRequest(t, http.MethodGet, server.URL+"/auth/health", testToken)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	var methods []string
+	code := http.StatusMethodNotAllowed
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(provider.Close)
+
+	logger := &testutil.MockLogger{}
+	deps := admin.Dependencies{OAuth: admin.NewOAuthProvider("discord", provider.URL)}
+	authServer := httptest.NewServer(admin.New(0, testToken, deps, logger).Handler())
+	t.Cleanup(authServer.Close)
+
+	resp = doRequest(t, http.MethodGet, authServer.URL+"/auth/health", testToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var status admin.OAuthStatus
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	assert.Equal(t, "discord", status.Provider)
+	assert.Equal(t, "ok", status.Status)
+	assert.Equal(t, []string{http.MethodHead}, methods)
+
+	// Server errors of the provider are degraded
+	code = http.StatusBadGateway
+	resp = doRequest(t, http.MethodGet, authServer.URL+"/auth/health", testToken)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	assert.Equal(t, "degraded", status.Status)
+	assert.Equal(t, "502 Bad Gateway", status.Error)
+
+	// An unreachable provider is degraded
+	provider.Close()
+	resp = doRequest(t, http.MethodGet, authServer.URL+"/auth/health", testToken)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	assert.Equal(t, "degraded", status.Status)
+	assert.NotEmpty(t, status.Error)
+}
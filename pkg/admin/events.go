@@ -0,0 +1,122 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// eventBufferSize is the number of events buffered per client before it is considered slow
+	eventBufferSize = 64
+
+	// eventWriteTimeout bounds how long a single event write may take
+	eventWriteTimeout = 5 * time.Second
+)
+
+// eventMessage is the JSON representation of a processed event
+type eventMessage struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Action      string    `json:"action,omitempty"`
+	Trigger     string    `json:"trigger"`
+	UserID      string    `json:"userId"`
+	ChannelID   string    `json:"channelId"`
+	Matched     bool      `json:"matched"`
+	DurationMs  float64   `json:"durationMs"`
+	Error       string    `json:"error,omitempty"`
+	RateLimited bool      `json:"rateLimited,omitempty"`
+}
+
+// eventClient is a connected event stream subscriber
+type eventClient struct {
+	events chan eventMessage
+	slow   chan struct{}
+	once   sync.Once
+}
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// send queues an event for the client, marking it slow when its buffer is full
+func (c *eventClient) send(event action.Event) {
+	msg := eventMessage{
+		Timestamp:  time.Now(),
+		Action:     event.Action,
+		Trigger:    event.Trigger,
+		UserID:     event.UserID,
+		ChannelID:  event.ChannelID,
+		Matched:    event.Matched,
+		DurationMs: float64(event.Duration.Microseconds()) / 1000,
+	}
+	// An action skipped by its rate limit did not fail
+	switch {
+	case errors.As(event.Error, &boterrors.RateLimitedError{}):
+		msg.RateLimited = true
+	case event.Error != nil:
+		msg.Error = event.Error.Error()
+	}
+
+	select {
+	case c.events <- msg:
+	default:
+		c.once.Do(func() { close(c.slow) })
+	}
+}
+
+// handleDebugEvents streams processed events to a WebSocket client
+func (s *Server) handleDebugEvents(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.logger.Error("Failed to upgrade debug events connection", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	client := &eventClient{
+		events: make(chan eventMessage, eventBufferSize),
+		slow:   make(chan struct{}),
+	}
+
+	remove := s.deps.Actions.AddListener(client.send)
+	defer remove()
+
+	s.logger.Info("Debug events client connected", "remote", r.RemoteAddr)
+
+	// Detect client disconnects, incoming messages are discarded
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-client.events:
+			_ = conn.SetWriteDeadline(time.Now().Add(eventWriteTimeout))
+			if err := conn.WriteJSON(msg); err != nil {
+				s.logger.Debug("Debug events client write failed", "remote", r.RemoteAddr, "error", err)
+				return
+			}
+		case <-client.slow:
+			s.logger.Warn("Disconnecting slow debug events client", "remote", r.RemoteAddr)
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "client too slow"),
+				time.Now().Add(eventWriteTimeout))
+			return
+		case <-closed:
+			s.logger.Info("Debug events client disconnected", "remote", r.RemoteAddr)
+			return
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_DebugEvents(t *testing.T) {
+	server, deps := newTestServer(t)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/debug/events"
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+testToken)
+
+	conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	defer conn.Close()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Pong!").Return(&discordgo.Message{}, nil)
+
+	message := &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			Content:   "!ping",
+			ChannelID: "channel123",
+			Author:    &discordgo.User{ID: "user123"},
+		},
+	}
+
+	// The listener is registered once the upgrade completes, keep sending until an event arrives
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_ = deps.Actions.HandleMessage(context.Background(), session, message)
+			}
+		}
+	}()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+
+	var event map[string]interface{}
+	require.NoError(t, conn.ReadJSON(&event))
+
+	assert.Equal(t, "ping", event["action"])
+	assert.Equal(t, "message", event["trigger"])
+	assert.Equal(t, "user123", event["userId"])
+	assert.Equal(t, true, event["matched"])
+}
+
+func TestServer_DebugEvents_RequiresToken(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/debug/events"
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
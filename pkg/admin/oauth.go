@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// oauthCheckTimeout bounds the request made to the OAuth provider
+const oauthCheckTimeout = 3 * time.Second
+
+// oauthTokenURLs are the token endpoints of the supported OAuth providers
+var oauthTokenURLs = map[string]string{
+	"discord": "https://discord.com/api/oauth2/token",
+}
+
+// OAuthStatus is the reachability of the OAuth provider
+type OAuthStatus struct {
+	Provider  string `json:"provider"`
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// OAuthChecker reports whether the OAuth provider is reachable
+type OAuthChecker interface {
+	Check(ctx context.Context) OAuthStatus
+}
+
+// OAuthProvider checks the token endpoint of an OAuth provider
+type OAuthProvider struct {
+	name     string
+	tokenURL string
+	client   *http.Client
+}
+
+// OAuthTokenURL returns the token endpoint of a supported OAuth provider.
+// An empty provider is Discord.
+func OAuthTokenURL(provider string) (string, bool) {
+	if provider == "" {
+		provider = "discord"
+	}
+	url, ok := oauthTokenURLs[provider]
+	return url, ok
+}
+
+// NewOAuthProvider creates a checker of the token endpoint of a provider
+func NewOAuthProvider(name, tokenURL string) *OAuthProvider {
+	if name == "" {
+		name = "discord"
+	}
+	return &OAuthProvider{
+		name:     name,
+		tokenURL: tokenURL,
+		client:   &http.Client{Timeout: oauthCheckTimeout},
+	}
+}
+
+// Check sends a HEAD request to the token endpoint. Any response below 500
+// means the provider is up, the endpoint itself only accepts POST.
+// The latency is reported on failures too, a slow provider is the first sign of an outage.
+func (p *OAuthProvider) Check(ctx context.Context) OAuthStatus {
+	status := OAuthStatus{Provider: p.name, Status: "degraded"}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, p.tokenURL, nil)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	start := time.Now()
+	resp, err := p.client.Do(req)
+	status.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		status.Error = resp.Status
+		return status
+	}
+
+	status.Status = "ok"
+	return status
+}
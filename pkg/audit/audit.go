@@ -0,0 +1,178 @@
+// Package audit provides structured audit logging of action executions.
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/geekxflood/common/logging"
+)
+
+// rotatedSuffixFormat is the timestamp format appended to rotated audit files
+const rotatedSuffixFormat = "20060102-150405.000"
+
+// AuditEntry records a single action execution
+type AuditEntry struct {
+	Timestamp      time.Time     `json:"timestamp"`
+	ActionName     string        `json:"actionName"`
+	UserID         string        `json:"userId"`
+	GuildID        string        `json:"guildId,omitempty"`
+	ChannelID      string        `json:"channelId"`
+	TriggerContent string        `json:"triggerContent,omitempty"`
+	ResponseType   string        `json:"responseType"`
+	Duration       time.Duration `json:"duration"`
+	Error          string        `json:"error,omitempty"`
+}
+
+// AuditLog records action executions
+type AuditLog interface {
+	Log(entry AuditEntry)
+	Close() error
+}
+
+// NoopAuditLog discards all entries
+type NoopAuditLog struct{}
+
+// Log discards the entry
+func (NoopAuditLog) Log(entry AuditEntry) {}
+
+// Close does nothing
+func (NoopAuditLog) Close() error { return nil }
+
+// FileAuditLog writes entries as JSON lines and rotates the file daily or when it exceeds a size threshold
+type FileAuditLog struct {
+	path     string
+	maxSize  int64
+	logger   logging.Logger
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	mu       sync.Mutex
+}
+
+// NewFileAuditLog creates a file audit log. A maxSize of 0 disables size-based rotation.
+func NewFileAuditLog(path string, maxSize int64, logger logging.Logger) (*FileAuditLog, error) {
+	l := &FileAuditLog{
+		path:    path,
+		maxSize: maxSize,
+		logger:  logger,
+	}
+
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Audit log opened", "path", path)
+	return l, nil
+}
+
+// Log appends an entry to the audit file
+func (l *FileAuditLog) Log(entry AuditEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		l.logger.Error("Failed to marshal audit entry", "error", err)
+		return
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return
+	}
+
+	if l.shouldRotate(entry.Timestamp, int64(len(data))) {
+		// The entry is still written when the current file could be reopened
+		if err := l.rotate(); err != nil {
+			l.logger.Error("Failed to rotate audit log", "error", err)
+		}
+		if l.file == nil {
+			return
+		}
+	}
+
+	n, err := l.file.Write(data)
+	l.size += int64(n)
+	if err != nil {
+		l.logger.Error("Failed to write audit entry", "error", err)
+	}
+}
+
+// Close closes the audit file
+func (l *FileAuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// shouldRotate checks whether the day changed or the size threshold would be exceeded
+func (l *FileAuditLog) shouldRotate(now time.Time, pending int64) bool {
+	if l.size == 0 {
+		return false
+	}
+
+	y1, m1, d1 := l.openedAt.Date()
+	y2, m2, d2 := now.Date()
+	if y1 != y2 || m1 != m2 || d1 != d2 {
+		return true
+	}
+
+	return l.maxSize > 0 && l.size+pending > l.maxSize
+}
+
+// rotate moves the current file aside and opens a fresh one, the current file is reopened when it cannot be moved
+func (l *FileAuditLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit file: %w", err)
+	}
+	l.file = nil
+
+	rotated := fmt.Sprintf("%s.%s", l.path, time.Now().Format(rotatedSuffixFormat))
+	if err := os.Rename(l.path, rotated); err != nil {
+		err = fmt.Errorf("failed to rename audit file: %w", err)
+		return errors.Join(err, l.open())
+	}
+
+	l.logger.Info("Audit log rotated", "rotated", rotated)
+	return l.open()
+}
+
+// open opens the audit file for appending
+func (l *FileAuditLog) open() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0750); err != nil {
+		return fmt.Errorf("failed to create audit directory: %w", err)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat audit file: %w", err)
+	}
+
+	l.file = file
+	l.size = info.Size()
+	l.openedAt = info.ModTime()
+	if l.size == 0 {
+		l.openedAt = time.Now()
+	}
+
+	return nil
+}
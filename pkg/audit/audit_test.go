@@ -0,0 +1,129 @@
+package audit_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogger() *testutil.MockLogger {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+	return logger
+}
+
+func readEntries(t *testing.T, path string) []audit.AuditEntry {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var entries []audit.AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry audit.AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+
+	return entries
+}
+
+func TestFileAuditLog_Log(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	auditLog, err := audit.NewFileAuditLog(path, 0, newLogger())
+	require.NoError(t, err)
+
+	auditLog.Log(audit.AuditEntry{
+		Timestamp:    time.Now(),
+		ActionName:   "ping",
+		UserID:       "user123",
+		ChannelID:    "channel123",
+		ResponseType: "text",
+		Duration:     5 * time.Millisecond,
+	})
+	auditLog.Log(audit.AuditEntry{
+		Timestamp:  time.Now(),
+		ActionName: "help",
+		Error:      errors.New("boom").Error(),
+	})
+	require.NoError(t, auditLog.Close())
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "ping", entries[0].ActionName)
+	assert.Equal(t, 5*time.Millisecond, entries[0].Duration)
+	assert.Equal(t, "boom", entries[1].Error)
+}
+
+func TestFileAuditLog_RotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+
+	auditLog, err := audit.NewFileAuditLog(path, 100, newLogger())
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		auditLog.Log(audit.AuditEntry{Timestamp: time.Now(), ActionName: "ping", UserID: "user123"})
+	}
+	require.NoError(t, auditLog.Close())
+
+	matches, err := filepath.Glob(filepath.Join(dir, "audit.log.*"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, matches)
+	assert.Len(t, readEntries(t, path), 1)
+}
+
+func TestFileAuditLog_RotateFailureKeepsLogging(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	auditLog, err := audit.NewFileAuditLog(path, 100, newLogger())
+	require.NoError(t, err)
+
+	auditLog.Log(audit.AuditEntry{Timestamp: time.Now(), ActionName: "ping", UserID: "user123"})
+
+	// The file removed behind the log cannot be renamed, it is created again
+	require.NoError(t, os.Remove(path))
+	auditLog.Log(audit.AuditEntry{Timestamp: time.Now(), ActionName: "help", UserID: "user123"})
+	require.NoError(t, auditLog.Close())
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "help", entries[0].ActionName)
+}
+
+func TestFileAuditLog_RotatesOnDayChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+
+	auditLog, err := audit.NewFileAuditLog(path, 0, newLogger())
+	require.NoError(t, err)
+
+	auditLog.Log(audit.AuditEntry{Timestamp: time.Now(), ActionName: "ping"})
+	auditLog.Log(audit.AuditEntry{Timestamp: time.Now().Add(24 * time.Hour), ActionName: "ping"})
+	require.NoError(t, auditLog.Close())
+
+	matches, err := filepath.Glob(filepath.Join(dir, "audit.log.*"))
+	require.NoError(t, err)
+	assert.Len(t, matches, 1)
+}
+
+func TestNoopAuditLog(t *testing.T) {
+	var auditLog audit.AuditLog = audit.NoopAuditLog{}
+	auditLog.Log(audit.AuditEntry{ActionName: "ping"})
+	assert.NoError(t, auditLog.Close())
+}
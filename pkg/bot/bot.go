@@ -3,30 +3,83 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/geekxflood/common/logging"
 	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/admin"
+	"github.com/geekxflood/gxf-discord-bot/pkg/audit"
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/coordination"
+	"github.com/geekxflood/gxf-discord-bot/pkg/emoji"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/eventbus"
+	"github.com/geekxflood/gxf-discord-bot/pkg/logs"
+	"github.com/geekxflood/gxf-discord-bot/pkg/moderation"
+	"github.com/geekxflood/gxf-discord-bot/pkg/plugin"
 	"github.com/geekxflood/gxf-discord-bot/pkg/ratelimit"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
 	"github.com/geekxflood/gxf-discord-bot/pkg/scheduler"
+	"github.com/geekxflood/gxf-discord-bot/pkg/sticker"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
 )
 
 // Bot represents the Discord bot instance
 type Bot struct {
-	session    *discordgo.Session
-	cfg        *config.Config
-	logger     logging.Logger
-	actionMgr  *action.Manager
-	scheduler  *scheduler.Scheduler
+	session     *discordgo.Session
+	cfg         *config.Config
+	cfgMu       *sync.RWMutex
+	logger      logging.Logger
+	actionMgr   *action.Manager
+	moderation  *moderation.Filter
+	scheduler   *scheduler.Scheduler
 	rateLimiter *ratelimit.Limiter
-	running    bool
-	runningM   sync.RWMutex
+	admin       *admin.Server
+	configMgr   *config.Manager
+	batch       *response.BatchSender
+	queue       *response.Queue
+	emojis      *emoji.Registry
+	stickers    *sticker.Registry
+	auditLog    audit.AuditLog
+	lock        *coordination.RedisLock
+	counter     *coordination.RedisCounter
+	bus         *eventbus.Bus
+	shared      bool
+
+	// Scheduler jobs of the configured actions, replaced on reload
+	actionJobs   []string
+	actionJobsMu sync.Mutex
+
+	// Shards created from this bot, their status follows the configuration
+	shards   []*Bot
+	shardsMu sync.Mutex
+
+	// Config file reloaded on change when set
+	configPath    string
+	configProfile string
+	configOptions []config.LoadOption
+
+	connected atomic.Bool
+
+	// Gateway reconnection, discordgo's own reconnect logic is disabled
+	connCtx           context.Context
+	connCancel        context.CancelFunc
+	reconnecting      atomic.Bool
+	reconnectAttempts atomic.Int64
+	running           bool
+	runningM          sync.RWMutex
 }
 
+// reminderSchedule polls the due reminders at the start of every minute
+const reminderSchedule = "0 * * * * *"
+
 // New creates a new Discord bot instance
 func New(ctx context.Context, cfg *config.Config, logger logging.Logger) (*Bot, error) {
 	logger.Info("Initializing Discord bot")
@@ -42,75 +95,369 @@ func New(ctx context.Context, cfg *config.Config, logger logging.Logger) (*Bot,
 		return nil, fmt.Errorf("failed to get bot token: %w", err)
 	}
 
-	// Create Discord session
-	session, err := discordgo.New("Bot " + token)
+	intents, err := botIntents(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Discord session: %w", err)
+		return nil, err
 	}
 
-	// Set bot intents
-	session.Identify.Intents = discordgo.IntentsGuildMessages |
-		discordgo.IntentsGuildMessageReactions |
-		discordgo.IntentsDirectMessages |
-		discordgo.IntentsMessageContent
+	session, err := newSession(token, intents, &cfg.Bot, cfg.Bot.ShardID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Initialize action manager
-	actionMgr, err := action.NewManager(cfg, logger)
+	actionMgr, err := action.NewManager(cfg, logs.Module(logger, "actions"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create action manager: %w", err)
 	}
 
+	// Register custom action handlers from plugins
+	if err := plugin.NewLoader(logs.Module(logger, "plugins")).Load(actionMgr, cfg.Plugins); err != nil {
+		return nil, fmt.Errorf("failed to load plugins: %w", err)
+	}
+
+	// Initialize optional audit log
+	var auditLog audit.AuditLog = audit.NoopAuditLog{}
+	if cfg.Audit != nil && cfg.Audit.Enabled {
+		auditLog, err = audit.NewFileAuditLog(cfg.Audit.Path, int64(cfg.Audit.MaxSizeMB)*1024*1024, logs.Module(logger, "audit"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create audit log: %w", err)
+		}
+	}
+	actionMgr.SetAuditLog(auditLog)
+
+	// Initialize optional moderation filter
+	var filter *moderation.Filter
+	if cfg.Bot.Moderation != nil {
+		filter, err = moderation.New(cfg.Bot.Moderation, logs.Module(logger, "moderation"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create moderation filter: %w", err)
+		}
+	}
+
 	// Initialize optional scheduler
-	sched := scheduler.New(logger)
+	sched := scheduler.New(logs.Module(logger, "scheduler"))
+	actionMgr.SetScheduler(sched)
+
+	// Share scheduled job ticks between instances when coordination is configured
+	var lock *coordination.RedisLock
+	if cfg.Coordination != nil && cfg.Coordination.Redis != nil {
+		lock = coordination.NewRedisLock(cfg.Coordination.Redis.Address)
+		sched.SetLock(lock)
+	}
 
 	// Initialize optional rate limiter
-	limiter := ratelimit.New(logger)
+	limiter := ratelimit.New(logs.Module(logger, "ratelimit"))
+	actionMgr.SetRateLimiter(limiter)
+
+	// Action rate limits are shared by the instances, such as the processes of a sharded bot
+	var counter *coordination.RedisCounter
+	if cfg.Coordination != nil && cfg.Coordination.Redis != nil {
+		counter = coordination.NewRedisCounter(cfg.Coordination.Redis.Address)
+		limiter.SetSharedCounter(counter)
+	}
+
+	// Messages are paced per channel to stay within the Discord rate limit
+	queue := response.NewQueue(response.DefaultChannelBurst, response.DefaultChannelInterval)
+	actionMgr.SetQueue(queue)
+
+	emojis := emoji.NewRegistry()
+	actionMgr.SetEmojis(emojis)
+
+	stickers := sticker.NewRegistry()
+	actionMgr.SetStickers(stickers)
 
 	bot := &Bot{
 		session:     session,
 		cfg:         cfg,
+		cfgMu:       &sync.RWMutex{},
+		configMgr:   config.NewManager("", cfg),
+		batch:       response.NewBatchSender(queue.Wrap(session), batchSize(cfg), logs.Module(logger, "response")),
+		queue:       queue,
+		emojis:      emojis,
+		stickers:    stickers,
 		logger:      logger,
 		actionMgr:   actionMgr,
+		moderation:  filter,
+		auditLog:    auditLog,
 		scheduler:   sched,
 		rateLimiter: limiter,
+		lock:        lock,
+		counter:     counter,
+		bus:         eventbus.New(),
 		running:     false,
 	}
 
+	bot.batch.SetTemplateContext(bot.actionMgr.TemplateContext)
+
+	// Runtime config changes from the admin API go through the same reload as the file
+	bot.configMgr.SetApplyFunc(bot.applyChange)
+
+	bot.actionMgr.AddListener(func(event action.Event) {
+		if event.Matched {
+			bot.bus.Publish(eventbus.TopicActionExecuted, event)
+		}
+	})
+
+	// Schedule status cycle and scheduled actions
+	jobs, err := bot.scheduleActions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	bot.actionJobs = jobs
+
+	// Initialize optional admin API
+	if cfg.Bot.AdminPort != 0 {
+		adminToken, err := cfg.GetAdminToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get admin token: %w", err)
+		}
+
+		deps := admin.Dependencies{
+			Actions:     actionMgr,
+			RateLimiter: limiter,
+			Scheduler:   sched,
+			Gateway:     bot,
+			Config:      bot.configMgr,
+			Batches:     bot.batch,
+		}
+		if cfg.Auth != nil && cfg.Auth.Enabled {
+			if tokenURL, ok := admin.OAuthTokenURL(cfg.Auth.Provider); ok {
+				deps.OAuth = admin.NewOAuthProvider(cfg.Auth.Provider, tokenURL)
+			} else {
+				logger.Warn("No health check for OAuth provider", "provider", cfg.Auth.Provider)
+			}
+		}
+
+		bot.admin = admin.New(cfg.Bot.AdminPort, adminToken, deps, logs.Module(logger, "admin"))
+	}
+
 	// Register event handlers
 	bot.registerHandlers()
 
 	return bot, nil
 }
 
+// NewShard creates a bot for another shard of the same application.
+// The shard shares the actions, moderation, rate limiter and audit log of b,
+// scheduled jobs and the admin API only run on b.
+func (b *Bot) NewShard(shardID int) (*Bot, error) {
+	cfg := b.currentConfig()
+	if shardID < 0 || shardID >= cfg.Bot.ShardCount {
+		return nil, fmt.Errorf("shard ID %d out of range for %d shards", shardID, cfg.Bot.ShardCount)
+	}
+
+	token, err := cfg.GetBotToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot token: %w", err)
+	}
+
+	session, err := newSession(token, b.session.Identify.Intents, &cfg.Bot, shardID)
+	if err != nil {
+		return nil, err
+	}
+
+	shard := &Bot{
+		session:    session,
+		cfg:        cfg,
+		cfgMu:      b.cfgMu,
+		configMgr:  b.configMgr,
+		logger:     b.logger.With("shard", shardID),
+		actionMgr:  b.actionMgr,
+		moderation: b.moderation,
+		bus:        b.bus,
+		queue:      b.queue,
+		emojis:     b.emojis,
+		stickers:   b.stickers,
+		shared:     true,
+	}
+	shard.registerHandlers()
+
+	b.shardsMu.Lock()
+	b.shards = append(b.shards, shard)
+	b.shardsMu.Unlock()
+
+	return shard, nil
+}
+
+// newSession creates a Discord session for a shard.
+// Each session reconnects on its own when its gateway connection drops.
+func newSession(token string, intents discordgo.Intent, cfg *config.BotConfig, shardID int) (*discordgo.Session, error) {
+	session, err := discordgo.New("Bot " + token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Discord session: %w", err)
+	}
+
+	session.Identify.Intents = intents
+	session.ShouldReconnectOnError = false
+
+	session.Compress = cfg.CompressionEnabled()
+	session.Identify.Compress = session.Compress
+	session.Identify.LargeThreshold = config.DefaultLargeThreshold
+	if cfg.LargeThreshold > 0 {
+		session.Identify.LargeThreshold = cfg.LargeThreshold
+	}
+
+	if cfg.ShardCount > 1 {
+		session.ShardID = shardID
+		session.ShardCount = cfg.ShardCount
+		session.Identify.Shard = &[2]int{shardID, cfg.ShardCount}
+	}
+
+	return session, nil
+}
+
 // registerHandlers registers Discord event handlers
 func (b *Bot) registerHandlers() {
 	b.session.AddHandler(b.handleReady)
+	b.session.AddHandler(b.handleResumed)
+	b.session.AddHandler(b.handleDisconnect)
 	b.session.AddHandler(b.handleMessageCreate)
 	b.session.AddHandler(b.handleMessageReactionAdd)
+	b.session.AddHandler(b.handleMessageReactionRemove)
+	b.session.AddHandler(b.handleGuildMemberAdd)
+	b.session.AddHandler(b.handleGuildMemberRemove)
+	b.session.AddHandler(b.handleGuildEmojisUpdate)
+	b.session.AddHandler(b.handleGuildStickersUpdate)
+}
+
+// Bus returns the event bus shared by the bot components
+func (b *Bot) Bus() *eventbus.Bus {
+	return b.bus
 }
 
 // handleReady is called when the bot is ready
 func (b *Bot) handleReady(s *discordgo.Session, event *discordgo.Ready) {
 	b.logger.Info("Bot is ready", "user", event.User.String(), "guilds", len(event.Guilds))
+	b.bus.Publish(eventbus.TopicReady, event)
+	b.actionMgr.SetBotUserID(event.User.ID)
 
-	// Set bot status if configured
-	if b.cfg.Bot.Status != "" {
-		activityType := b.getActivityType(b.cfg.Bot.ActivityType)
+	// Set initial bot status if configured, status cycles take over on their first tick
+	if status := b.currentConfig().Bot.Status; status != "" {
+		if err := b.updateStatus(s, status); err != nil {
+			b.logger.Error("Failed to set bot status", "error", err)
+		}
+	}
 
-		err := s.UpdateStatusComplex(discordgo.UpdateStatusData{
-			Activities: []*discordgo.Activity{
-				{
-					Name: b.cfg.Bot.Status,
-					Type: activityType,
-				},
-			},
-			Status: "online",
-		})
+	go b.loadEmojis(s, event.Guilds)
+	go b.loadStickers(s, event.Guilds)
 
-		if err != nil {
-			b.logger.Error("Failed to set bot status", "error", err)
+	// A new session after a disconnect may have missed buffered messages
+	if b.connected.Swap(true) {
+		b.replayMessages(s)
+	}
+}
+
+// loadEmojis fetches the custom emojis of the guilds for the emoji template function
+func (b *Bot) loadEmojis(s *discordgo.Session, guilds []*discordgo.Guild) {
+	for _, guild := range guilds {
+		if err := b.emojis.Load(s, guild.ID); err != nil {
+			b.logger.Warn("Failed to load guild emojis", "guildID", guild.ID, "error", err)
+		}
+	}
+}
+
+// handleGuildEmojisUpdate keeps the emoji registry in sync when a guild changes its emojis
+func (b *Bot) handleGuildEmojisUpdate(s *discordgo.Session, event *discordgo.GuildEmojisUpdate) {
+	b.emojis.Set(event.GuildID, event.Emojis)
+}
+
+// loadStickers fetches the stickers of the guilds for the stickerID template function
+func (b *Bot) loadStickers(s *discordgo.Session, guilds []*discordgo.Guild) {
+	for _, guild := range guilds {
+		if err := b.stickers.Load(s, guild.ID); err != nil {
+			b.logger.Warn("Failed to load guild stickers", "guildID", guild.ID, "error", err)
+		}
+	}
+}
+
+// handleGuildStickersUpdate keeps the sticker registry in sync when a guild changes its stickers
+func (b *Bot) handleGuildStickersUpdate(s *discordgo.Session, event *discordgo.GuildStickersUpdate) {
+	b.stickers.Set(event.GuildID, event.Stickers)
+}
+
+// handleResumed is called when the gateway session is resumed after a disconnect
+func (b *Bot) handleResumed(s *discordgo.Session, event *discordgo.Resumed) {
+	b.logger.Info("Gateway session resumed")
+	b.replayMessages(s)
+}
+
+// replayMessages submits the buffered messages that were not handled before the disconnect
+func (b *Bot) replayMessages(s *discordgo.Session) {
+	pending := b.actionMgr.PendingMessages()
+	if len(pending) == 0 {
+		return
+	}
+
+	b.logger.Info("Replaying buffered messages", "count", len(pending))
+	for _, m := range pending {
+		b.submitMessage(s, m)
+	}
+}
+
+// cycleStatus advances a status_cycle action and applies the next status
+func (b *Bot) cycleStatus(name string) error {
+	status, err := b.actionMgr.NextStatus(name)
+	if err != nil {
+		return err
+	}
+
+	b.logger.Debug("Cycling bot status", "action", name, "status", status)
+	if err := b.updateStatus(b.session, status); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// executeScheduledAction sends the response of a scheduled action to each of its channels
+func (b *Bot) executeScheduledAction(ctx context.Context, actionCfg config.ActionConfig) error {
+	if !b.actionMgr.IsEnabled(actionCfg.Name) {
+		return nil
+	}
+
+	// A relay goes to its own target once, not to each trigger channel
+	var items []response.BatchItem
+	if actionCfg.Response.Type == "relay" {
+		items = []response.BatchItem{{ChannelID: actionCfg.Response.TargetChannelID, Response: actionCfg.Response}}
+	} else {
+		items = make([]response.BatchItem, 0, len(actionCfg.Trigger.Channels))
+		for _, channelID := range actionCfg.Trigger.Channels {
+			items = append(items, response.BatchItem{ChannelID: channelID, Response: actionCfg.Response})
+		}
+	}
+
+	if err := b.batch.Send(ctx, items); err != nil {
+		return fmt.Errorf("scheduled action %s failed: %w", actionCfg.Name, err)
+	}
+	return nil
+}
+
+// batchSize returns the number of channels scheduled actions send to at the same time
+func batchSize(cfg *config.Config) int {
+	if cfg.Bot.Scheduler == nil {
+		return 0
+	}
+	return cfg.Bot.Scheduler.BatchSize
+}
+
+// updateStatus sets the bot activity using the configured activity type
+func (b *Bot) updateStatus(s *discordgo.Session, status string) error {
+	return b.setStatus(s, status, b.currentConfig().Bot.ActivityType)
+}
+
+// setStatus sets the bot activity, an empty status clears it
+func (b *Bot) setStatus(s *discordgo.Session, status, activityType string) error {
+	data := discordgo.UpdateStatusData{Status: "online"}
+	if status != "" {
+		data.Activities = []*discordgo.Activity{
+			{
+				Name: status,
+				Type: b.getActivityType(activityType),
+			},
 		}
 	}
+	return s.UpdateStatusComplex(data)
 }
 
 // getActivityType converts string to ActivityType
@@ -131,6 +478,16 @@ func (b *Bot) getActivityType(activityType string) discordgo.ActivityType {
 	}
 }
 
+// handleGuildMemberAdd publishes member joins on the event bus
+func (b *Bot) handleGuildMemberAdd(s *discordgo.Session, m *discordgo.GuildMemberAdd) {
+	b.bus.Publish(eventbus.TopicMemberJoined, m.Member)
+}
+
+// handleGuildMemberRemove publishes member departures on the event bus
+func (b *Bot) handleGuildMemberRemove(s *discordgo.Session, m *discordgo.GuildMemberRemove) {
+	b.bus.Publish(eventbus.TopicMemberLeft, m.Member)
+}
+
 // handleMessageCreate handles message creation events
 func (b *Bot) handleMessageCreate(s *discordgo.Session, m *discordgo.MessageCreate) {
 	// Ignore messages from bots
@@ -139,8 +496,31 @@ func (b *Bot) handleMessageCreate(s *discordgo.Session, m *discordgo.MessageCrea
 	}
 
 	ctx := context.Background()
-	if err := b.actionMgr.HandleMessage(ctx, s, m); err != nil {
-		b.logger.Error("Failed to handle message", "error", err)
+
+	// Moderation runs before any action matching
+	if b.moderation != nil {
+		blocked, err := b.moderation.Handle(ctx, s, m)
+		if err != nil {
+			b.logger.Error("Failed to apply moderation action", "error", err)
+		}
+		if blocked {
+			return
+		}
+	}
+
+	b.actionMgr.RecordMessage(m)
+	b.submitMessage(s, m)
+}
+
+// submitMessage queues a message for action matching on the worker pool
+func (b *Bot) submitMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
+	err := b.actionMgr.Submit(func() {
+		if err := b.actionMgr.HandleMessage(context.Background(), s, m); err != nil && !isRateLimited(err) {
+			b.logger.Error("Failed to handle message", "error", err)
+		}
+	})
+	if err != nil {
+		b.logger.Error("Dropped message", "messageID", m.ID, "error", err)
 	}
 }
 
@@ -152,9 +532,47 @@ func (b *Bot) handleMessageReactionAdd(s *discordgo.Session, r *discordgo.Messag
 	}
 
 	ctx := context.Background()
-	if err := b.actionMgr.HandleReaction(ctx, s, r); err != nil {
-		b.logger.Error("Failed to handle reaction", "error", err)
+	err := b.actionMgr.Submit(func() {
+		if err := b.actionMgr.HandleReaction(ctx, s, r); err != nil && !isRateLimited(err) {
+			b.logger.Error("Failed to handle reaction", "error", err)
+		}
+	})
+	if err != nil {
+		b.logger.Error("Dropped reaction", "messageID", r.MessageID, "error", err)
+	}
+}
+
+// handleMessageReactionRemove handles reaction remove events
+func (b *Bot) handleMessageReactionRemove(s *discordgo.Session, r *discordgo.MessageReactionRemove) {
+	// Ignore the reactions of the bot itself
+	if s.State != nil && s.State.User != nil && r.UserID == s.State.User.ID {
+		return
+	}
+
+	ctx := context.Background()
+	err := b.actionMgr.Submit(func() {
+		if err := b.actionMgr.HandleReactionRemove(ctx, s, r); err != nil {
+			b.logger.Error("Failed to handle reaction removal", "error", err)
+		}
+	})
+	if err != nil {
+		b.logger.Error("Dropped reaction removal", "messageID", r.MessageID, "error", err)
+	}
+}
+
+// isRateLimited reports whether an action was skipped by its rate limit, which is not a failure
+func isRateLimited(err error) bool {
+	return errors.As(err, &boterrors.RateLimitedError{})
+}
+
+// runsScheduler reports whether the scheduled jobs run on this bot. With one shard per process
+// they run on shard 0 only, unless a coordination lock picks the instance running each tick.
+func (b *Bot) runsScheduler() bool {
+	if b.scheduler == nil {
+		return false
 	}
+	cfg := b.currentConfig()
+	return cfg.Bot.ShardCount <= 1 || cfg.Bot.ShardID == 0 || b.lock != nil
 }
 
 // Start starts the Discord bot
@@ -168,14 +586,24 @@ func (b *Bot) Start(ctx context.Context) error {
 		return fmt.Errorf("bot is already running")
 	}
 
+	// Scheduled jobs wait for the session to be ready
+	if b.runsScheduler() {
+		b.scheduler.StartOnReady(b.bus)
+	} else if b.scheduler != nil {
+		b.logger.Info("Scheduled jobs run on shard 0, scheduler not started", "shard", b.currentConfig().Bot.ShardID)
+	}
+
+	// The first connection fails fast, later disconnects are retried by handleDisconnect
 	if err := b.session.Open(); err != nil {
 		return fmt.Errorf("failed to open Discord session: %w", err)
 	}
+	b.connCtx, b.connCancel = context.WithCancel(ctx)
 
-	// Start scheduler if configured
-	if b.scheduler != nil {
-		if err := b.scheduler.Start(); err != nil {
-			b.logger.Error("Failed to start scheduler", "error", err)
+	if b.configPath != "" {
+		if err := config.Watch(b.connCtx, b.configPath, b.configProfile, b.reloadConfig, func(err error) {
+			b.logger.Warn("Config reload failed", "error", err)
+		}, b.configOptions...); err != nil {
+			b.logger.Error("Failed to watch config file", "error", err)
 		}
 	}
 
@@ -187,6 +615,13 @@ func (b *Bot) Start(ctx context.Context) error {
 		}
 	}
 
+	// Start admin API if configured
+	if b.admin != nil {
+		if err := b.admin.Start(); err != nil {
+			b.logger.Error("Failed to start admin API", "error", err)
+		}
+	}
+
 	b.running = true
 	b.logger.Info("Discord bot started successfully")
 
@@ -205,6 +640,11 @@ func (b *Bot) Stop() error {
 
 	b.logger.Info("Stopping Discord bot")
 
+	// Stop reconnecting before the session is closed
+	if b.connCancel != nil {
+		b.connCancel()
+	}
+
 	// Stop scheduler if running
 	if b.scheduler != nil && b.scheduler.IsRunning() {
 		if err := b.scheduler.Stop(); err != nil {
@@ -217,6 +657,45 @@ func (b *Bot) Stop() error {
 		b.rateLimiter.StopCleanup()
 	}
 
+	// Stop admin API
+	if b.admin != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := b.admin.Stop(ctx); err != nil {
+			b.logger.Error("Error stopping admin API", "error", err)
+		}
+		cancel()
+	}
+
+	// Let queued actions and their messages finish before closing their outputs
+	if !b.shared {
+		b.actionMgr.StopWorkers()
+		b.actionMgr.Stats().Close()
+		if err := b.queue.Drain(5 * time.Second); err != nil {
+			b.logger.Error("Error draining response queue", "error", err)
+		}
+	}
+
+	if b.auditLog != nil {
+		if err := b.auditLog.Close(); err != nil {
+			b.logger.Error("Error closing audit log", "error", err)
+		}
+	}
+
+	if !b.shared {
+		b.bus.Close()
+	}
+
+	if b.lock != nil {
+		if err := b.lock.Close(); err != nil {
+			b.logger.Error("Error closing coordination lock", "error", err)
+		}
+	}
+	if b.counter != nil {
+		if err := b.counter.Close(); err != nil {
+			b.logger.Error("Error closing shared rate limit counter", "error", err)
+		}
+	}
+
 	if b.session != nil {
 		if err := b.session.Close(); err != nil {
 			b.logger.Error("Error closing Discord session", "error", err)
@@ -230,6 +709,220 @@ func (b *Bot) Stop() error {
 	return nil
 }
 
+// SetAdminListenFunc sets the function opening the admin API listener
+func (b *Bot) SetAdminListenFunc(listen admin.ListenFunc) {
+	if b.admin != nil {
+		b.admin.SetListenFunc(listen)
+	}
+}
+
+// SetConfigSource sets the config file and profile watched for changes once the bot starts,
+// opts apply when the file is loaded again
+func (b *Bot) SetConfigSource(path, profile string, opts ...config.LoadOption) {
+	b.configPath = path
+	b.configProfile = profile
+	b.configOptions = opts
+
+	// A profiled config is merged from several files, so it is never written back
+	if profile == "" {
+		b.configMgr.SetPath(path)
+	}
+
+	if path != "" {
+		b.actionMgr.SetReloader(b.reloadFromFile)
+		b.actionMgr.SetDynamicActionsPath(config.DynamicActionsPath(path))
+		b.actionMgr.SetBaseDir(filepath.Dir(path))
+	}
+}
+
+// scheduleActions adds the scheduler jobs of the status_cycle, scheduled and reminder actions
+// and returns their IDs. The jobs already added are removed when one cannot be scheduled.
+func (b *Bot) scheduleActions(cfg *config.Config) ([]string, error) {
+	var jobs []string
+	add := func(jobID string, err error) error {
+		if err != nil {
+			b.removeJobs(jobs)
+			return err
+		}
+		jobs = append(jobs, jobID)
+		return nil
+	}
+
+	var reminders bool
+	for _, actionCfg := range cfg.Actions {
+		switch actionCfg.Type {
+		case "reminder":
+			reminders = true
+		case "status_cycle":
+			name := actionCfg.Name
+			jobID, err := b.scheduler.AddJobInTimezone(name, actionCfg.Trigger.Schedule, actionCfg.Trigger.Timezone, func(ctx context.Context) error {
+				return b.cycleStatus(name)
+			})
+			if err := add(jobID, err); err != nil {
+				return nil, fmt.Errorf("failed to schedule status cycle %s: %w", name, err)
+			}
+		case "scheduled":
+			if len(actionCfg.Trigger.Channels) == 0 && actionCfg.Response.Type != "relay" {
+				continue
+			}
+			jobID, err := b.scheduler.AddJobInTimezone(actionCfg.Name, actionCfg.Trigger.Schedule, actionCfg.Trigger.Timezone, func(ctx context.Context) error {
+				return b.executeScheduledAction(ctx, actionCfg)
+			})
+			if err := add(jobID, err); err != nil {
+				return nil, fmt.Errorf("failed to schedule action %s: %w", actionCfg.Name, err)
+			}
+		}
+	}
+
+	// Due reminders are polled every minute and sent by DM
+	if reminders {
+		jobID, err := b.scheduler.AddJob("reminders", reminderSchedule, func(ctx context.Context) error {
+			return b.actionMgr.DeliverReminders(ctx, b.session)
+		})
+		if err := add(jobID, err); err != nil {
+			return nil, fmt.Errorf("failed to schedule reminders: %w", err)
+		}
+	}
+
+	return jobs, nil
+}
+
+// removeJobs removes scheduler jobs added by scheduleActions
+func (b *Bot) removeJobs(jobs []string) {
+	for _, jobID := range jobs {
+		if err := b.scheduler.RemoveJob(jobID); err != nil {
+			b.logger.Warn("Failed to remove scheduled job", "jobID", jobID, "error", err)
+		}
+	}
+}
+
+// reloadActions replaces the actions and their scheduler jobs.
+// The new jobs are added first so a configuration failing to load keeps the current ones.
+func (b *Bot) reloadActions(cfg *config.Config) error {
+	if b.scheduler == nil {
+		return b.actionMgr.Reload(cfg)
+	}
+
+	b.actionJobsMu.Lock()
+	defer b.actionJobsMu.Unlock()
+
+	jobs, err := b.scheduleActions(cfg)
+	if err != nil {
+		return err
+	}
+	if err := b.actionMgr.Reload(cfg); err != nil {
+		b.removeJobs(jobs)
+		return err
+	}
+
+	b.removeJobs(b.actionJobs)
+	b.actionJobs = jobs
+	return nil
+}
+
+// reloadConfig reloads the actions from a changed configuration
+func (b *Bot) reloadConfig(cfg *config.Config) {
+	if err := b.applyConfig(cfg); err != nil {
+		b.logger.Warn("Config reload failed", "error", err)
+	}
+}
+
+// reloadFromFile loads and applies the config file, for the reload owner command
+func (b *Bot) reloadFromFile() error {
+	cfg, err := config.LoadRuntime(b.configPath, b.configProfile, b.configOptions...)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return b.applyConfig(cfg)
+}
+
+// applyConfig replaces the actions and the runtime configuration.
+// Changed settings that need a restart are logged and take effect on the next start.
+func (b *Bot) applyConfig(cfg *config.Config) error {
+	current := b.currentConfig()
+	if err := b.reloadActions(cfg); err != nil {
+		return err
+	}
+	if keys := restartSettings(current, cfg); len(keys) > 0 {
+		b.logger.Warn("Changed settings require a restart", "settings", keys)
+	}
+	b.setConfig(cfg)
+	b.applyPresence(current, cfg)
+	b.configMgr.Replace(cfg)
+	b.logger.Info("Configuration reloaded", "path", b.configPath)
+	return nil
+}
+
+// applyChange applies a runtime configuration change from the admin API.
+// Settings the running bot cannot apply are rejected instead of being reported as changed.
+func (b *Bot) applyChange(cfg *config.Config) error {
+	current := b.currentConfig()
+	if keys := restartSettings(current, cfg); len(keys) > 0 {
+		return fmt.Errorf("%s cannot be changed while the bot is running", strings.Join(keys, ", "))
+	}
+	if err := b.reloadActions(cfg); err != nil {
+		return err
+	}
+	b.setConfig(cfg)
+	b.applyPresence(current, cfg)
+	return nil
+}
+
+// applyPresence updates the status of the connected shards when it changed
+func (b *Bot) applyPresence(current, cfg *config.Config) {
+	if current.Bot.Status == cfg.Bot.Status && current.Bot.ActivityType == cfg.Bot.ActivityType {
+		return
+	}
+
+	b.shardsMu.Lock()
+	bots := append([]*Bot{b}, b.shards...)
+	b.shardsMu.Unlock()
+
+	for _, shard := range bots {
+		if !shard.connected.Load() {
+			continue
+		}
+		if err := shard.setStatus(shard.session, cfg.Bot.Status, cfg.Bot.ActivityType); err != nil {
+			shard.logger.Warn("Failed to update bot status", "error", err)
+		}
+	}
+}
+
+// SetStore sets the store persisting the action execution history,
+// and the user preferences, reminders, execution counts and usage when the store can hold them
+func (b *Bot) SetStore(store storage.Store) {
+	b.actionMgr.SetStore(store)
+	if prefs, ok := store.(storage.UserPrefs); ok {
+		b.actionMgr.SetPrefs(prefs)
+	}
+	if reminders, ok := store.(storage.Reminders); ok {
+		b.actionMgr.SetReminders(reminders)
+	}
+	if counts, ok := store.(storage.ExecutionCounts); ok {
+		b.actionMgr.SetExecutionCounts(counts)
+	}
+	if usage, ok := store.(storage.UsageStore); ok {
+		b.actionMgr.Stats().SetErrorHandler(func(err error) {
+			b.logger.Warn("Failed to store action usage", "error", err)
+		})
+		if err := b.actionMgr.Stats().SetStore(usage); err != nil {
+			b.logger.Error("Failed to load action usage", "error", err)
+		}
+	}
+}
+
+// SetDryRun enables or disables dry-run mode.
+// In dry-run mode the bot connects to Discord but only logs the actions it would execute.
+func (b *Bot) SetDryRun(enabled bool) {
+	b.actionMgr.DryRun = enabled
+	if b.moderation != nil {
+		b.moderation.DryRun = enabled
+	}
+}
+
 // IsRunning returns whether the bot is currently running
 func (b *Bot) IsRunning() bool {
 	b.runningM.RLock()
@@ -239,9 +932,34 @@ func (b *Bot) IsRunning() bool {
 
 // GetConfig returns the bot's configuration
 func (b *Bot) GetConfig() *config.Config {
+	return b.currentConfig()
+}
+
+// GetConfigManager returns the manager applying runtime configuration changes
+func (b *Bot) GetConfigManager() *config.Manager {
+	return b.configMgr
+}
+
+// currentConfig returns the configuration the bot runs with, replaced on reload
+func (b *Bot) currentConfig() *config.Config {
+	b.cfgMu.RLock()
+	defer b.cfgMu.RUnlock()
 	return b.cfg
 }
 
+// setConfig replaces the configuration of the bot and its shards
+func (b *Bot) setConfig(cfg *config.Config) {
+	b.shardsMu.Lock()
+	bots := append([]*Bot{b}, b.shards...)
+	b.shardsMu.Unlock()
+
+	b.cfgMu.Lock()
+	defer b.cfgMu.Unlock()
+	for _, shard := range bots {
+		shard.cfg = cfg
+	}
+}
+
 // GetScheduler returns the bot's scheduler
 func (b *Bot) GetScheduler() *scheduler.Scheduler {
 	return b.scheduler
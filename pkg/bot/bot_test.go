@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bwmarrin/discordgo"
 	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
 	"github.com/geekxflood/gxf-discord-bot/pkg/bot"
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
@@ -76,6 +77,27 @@ func TestNew_InvalidConfig(t *testing.T) {
 	assert.Nil(t, b)
 }
 
+func TestNew_DuplicateActionName(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:  "test-token-123",
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{Name: "ping", Type: "command", Trigger: config.TriggerConfig{Command: "ping"}, Response: config.ResponseConfig{Type: "text", Content: "pong"}},
+			{Name: "ping", Type: "command", Trigger: config.TriggerConfig{Command: "p"}, Response: config.ResponseConfig{Type: "text", Content: "pong"}},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	b, err := bot.New(context.Background(), cfg, logger)
+
+	assert.ErrorContains(t, err, `duplicate action name: "ping"`)
+	assert.Nil(t, b)
+}
+
 func TestStart_Success(t *testing.T) {
 	os.Setenv("TEST_BOT_TOKEN", "test-token-123")
 	defer os.Unsetenv("TEST_BOT_TOKEN")
@@ -174,6 +196,41 @@ func TestBot_GetConfig(t *testing.T) {
 	assert.Equal(t, "Playing games", retrievedCfg.Bot.Status)
 }
 
+func TestBot_ConfigChanges(t *testing.T) {
+	os.Setenv("TEST_BOT_TOKEN", "test-token-123")
+	defer os.Unsetenv("TEST_BOT_TOKEN")
+
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			TokenEnvVar: "TEST_BOT_TOKEN",
+			Prefix:      "!",
+			Status:      "Playing games",
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	b, err := bot.New(context.Background(), cfg, logger)
+	require.NoError(t, err)
+
+	// Settings applied by the running bot are committed
+	require.NoError(t, b.GetConfigManager().SetString("bot.status", "Maintenance"))
+	require.NoError(t, b.GetConfigManager().SetString("bot.prefix", "?"))
+	assert.Equal(t, "Maintenance", b.GetConfig().Bot.Status)
+	assert.Equal(t, "?", b.GetConfig().Bot.Prefix)
+
+	// Settings that need a restart are rejected
+	err = b.GetConfigManager().SetInt("bot.largeThreshold", 100)
+	assert.ErrorContains(t, err, "bot.largeThreshold cannot be changed while the bot is running")
+	err = b.GetConfigManager().SetInt("bot.shardCount", 2)
+	assert.ErrorContains(t, err, "bot.shardCount cannot be changed")
+	err = b.GetConfigManager().SetString("logging.level", "debug")
+	assert.ErrorContains(t, err, "logging cannot be changed")
+	assert.Zero(t, b.GetConfig().Bot.LargeThreshold)
+	assert.Nil(t, b.GetConfig().Logging)
+}
+
 func TestBot_IsRunning(t *testing.T) {
 	os.Setenv("TEST_BOT_TOKEN", "test-token-123")
 	defer os.Unsetenv("TEST_BOT_TOKEN")
@@ -278,3 +335,136 @@ func TestBot_ConfigValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_SchedulesStatusCycle(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:  "valid-token",
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "rotate",
+				Type: "status_cycle",
+				Trigger: config.TriggerConfig{
+					Schedule: "0 */5 * * * *",
+					Statuses: []string{"one", "two"},
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	b, err := bot.New(context.Background(), cfg, logger)
+	require.NoError(t, err)
+
+	jobs := b.GetScheduler().ListJobs()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "rotate", jobs[0].Name)
+}
+
+func TestNew_SchedulesRelayWithoutChannels(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:  "valid-token",
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:    "digest",
+				Type:    "scheduled",
+				Trigger: config.TriggerConfig{Schedule: "0 0 9 * * 1"},
+				Response: config.ResponseConfig{
+					Type:            "relay",
+					Content:         "Weekly digest",
+					TargetGuildID:   "guild456",
+					TargetChannelID: "channel456",
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	b, err := bot.New(context.Background(), cfg, logger)
+	require.NoError(t, err)
+
+	jobs := b.GetScheduler().ListJobs()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "digest", jobs[0].Name)
+}
+
+func TestDetectIntents(t *testing.T) {
+	tests := []struct {
+		name    string
+		types   []string
+		intents discordgo.Intent
+	}{
+		{name: "none", intents: discordgo.IntentsNone},
+		{name: "scheduled only", types: []string{"scheduled", "status_cycle"}, intents: discordgo.IntentsNone},
+		{name: "message", types: []string{"message"}, intents: discordgo.IntentsGuildMessages | discordgo.IntentsMessageContent},
+		{name: "reaction", types: []string{"reaction"}, intents: discordgo.IntentsGuildMessageReactions},
+		{name: "reaction role", types: []string{"reaction_role"}, intents: discordgo.IntentsGuildMessageReactions},
+		{name: "members", types: []string{"member_join", "member_leave"}, intents: discordgo.IntentsGuildMembers},
+		{name: "presence and reaction", types: []string{"presence", "reaction"}, intents: discordgo.IntentsGuildPresences | discordgo.IntentsGuildMessageReactions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actions []config.ActionConfig
+			for _, actionType := range tt.types {
+				actions = append(actions, config.ActionConfig{Type: actionType})
+			}
+
+			assert.Equal(t, tt.intents, bot.DetectIntents(actions))
+		})
+	}
+}
+
+func TestNew_UnknownIntent(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:   "test-token",
+			Prefix:  "!",
+			Intents: []string{"everything"},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	_, err := bot.New(context.Background(), cfg, logger)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown intent")
+}
+
+func TestBot_ConnectStopsOnCancel(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:  "test-token",
+			Prefix: "!",
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Warn", mock.Anything, mock.Anything).Return()
+
+	b, err := bot.New(context.Background(), cfg, logger)
+	require.NoError(t, err)
+
+	// The invalid token never connects, so Connect retries until cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+
+	err = b.Connect(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, b.ReconnectAttempts(), 2)
+}
@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Backoff between gateway connection attempts
+const (
+	initialReconnectBackoff = time.Second
+	maxReconnectBackoff     = 5 * time.Minute
+)
+
+// Connect opens the gateway connection, retrying with exponential backoff
+// until it succeeds or ctx is cancelled. Resumable sessions are resumed.
+func (b *Bot) Connect(ctx context.Context) error {
+	backoff := initialReconnectBackoff
+
+	for {
+		b.reconnectAttempts.Add(1)
+
+		err := b.session.Open()
+		if err == nil || errors.Is(err, discordgo.ErrWSAlreadyOpen) {
+			return nil
+		}
+
+		wait := jitter(backoff)
+		b.logger.Warn("Failed to connect to Discord gateway", "error", err, "backoff", wait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, maxReconnectBackoff)
+	}
+}
+
+// ReconnectAttempts returns the number of gateway reconnection attempts
+func (b *Bot) ReconnectAttempts() int {
+	return int(b.reconnectAttempts.Load())
+}
+
+// handleDisconnect reconnects to the gateway unless the bot is stopping
+func (b *Bot) handleDisconnect(s *discordgo.Session, event *discordgo.Disconnect) {
+	b.runningM.RLock()
+	ctx := b.connCtx
+	b.runningM.RUnlock()
+
+	if ctx == nil || ctx.Err() != nil {
+		return
+	}
+
+	// Only one reconnect loop runs at a time
+	if !b.reconnecting.CompareAndSwap(false, true) {
+		return
+	}
+
+	b.logger.Warn("Disconnected from Discord gateway, reconnecting")
+	go func() {
+		defer b.reconnecting.Store(false)
+
+		if err := b.Connect(ctx); err != nil {
+			b.logger.Info("Gateway reconnection stopped", "reason", err)
+			return
+		}
+		b.logger.Info("Reconnected to Discord gateway")
+	}()
+}
+
+// jitter randomizes a backoff duration between half and the full duration
+func jitter(d time.Duration) time.Duration {
+	half := d / 2
+	return half + rand.N(half+1) // #nosec G404 -- jitter does not need a secure source
+}
@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+)
+
+// intentNames maps configured intent names to gateway intents
+var intentNames = map[string]discordgo.Intent{
+	"guilds":                 discordgo.IntentsGuilds,
+	"guildMembers":           discordgo.IntentsGuildMembers,
+	"guildBans":              discordgo.IntentsGuildBans,
+	"guildEmojis":            discordgo.IntentsGuildEmojis,
+	"guildIntegrations":      discordgo.IntentsGuildIntegrations,
+	"guildWebhooks":          discordgo.IntentsGuildWebhooks,
+	"guildInvites":           discordgo.IntentsGuildInvites,
+	"guildVoiceStates":       discordgo.IntentsGuildVoiceStates,
+	"guildPresences":         discordgo.IntentsGuildPresences,
+	"guildMessages":          discordgo.IntentsGuildMessages,
+	"guildMessageReactions":  discordgo.IntentsGuildMessageReactions,
+	"guildMessageTyping":     discordgo.IntentsGuildMessageTyping,
+	"directMessages":         discordgo.IntentsDirectMessages,
+	"directMessageReactions": discordgo.IntentsDirectMessageReactions,
+	"directMessageTyping":    discordgo.IntentsDirectMessageTyping,
+	"messageContent":         discordgo.IntentsMessageContent,
+	"guildScheduledEvents":   discordgo.IntentsGuildScheduledEvents,
+}
+
+// DetectIntents returns the minimum gateway intents needed by the actions
+func DetectIntents(actions []config.ActionConfig) discordgo.Intent {
+	var intents discordgo.Intent
+
+	for _, action := range actions {
+		switch action.Type {
+		case "command", "setpref", "getpref", "reminder", "vote_tally":
+			intents |= discordgo.IntentsGuildMessages | discordgo.IntentsDirectMessages | discordgo.IntentsMessageContent
+		case "message":
+			intents |= discordgo.IntentsGuildMessages | discordgo.IntentsMessageContent
+		case "reaction", "reaction_role":
+			intents |= discordgo.IntentsGuildMessageReactions
+		case "member_join", "member_leave":
+			intents |= discordgo.IntentsGuildMembers
+		case "presence":
+			intents |= discordgo.IntentsGuildPresences
+		case "scheduled", "status_cycle":
+			// Triggered by the scheduler, no gateway events needed
+		default:
+			// Plugin action types are matched against messages like commands
+			intents |= discordgo.IntentsGuildMessages | discordgo.IntentsDirectMessages | discordgo.IntentsMessageContent
+		}
+	}
+
+	return intents
+}
+
+// botIntents returns the detected intents combined with the configured ones
+func botIntents(cfg *config.Config) (discordgo.Intent, error) {
+	intents := DetectIntents(cfg.Actions)
+
+	// Moderation inspects every guild message
+	if cfg.Bot.Moderation != nil {
+		intents |= discordgo.IntentsGuildMessages | discordgo.IntentsMessageContent
+	}
+
+	for _, name := range cfg.Bot.Intents {
+		intent, ok := intentNames[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown intent: %s", name)
+		}
+		intents |= intent
+	}
+
+	return intents, nil
+}
@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+)
+
+// liveSettings are the settings the running bot applies without a restart
+var liveSettings = map[string]bool{
+	"actions":            true,
+	"bot.prefix":         true,
+	"bot.mentionTrigger": true,
+	"bot.status":         true,
+	"bot.activityType":   true,
+	"bot.timezone":       true,
+	"bot.ownerIds":       true,
+	"bot.httpAllowlist":  true,
+	"bot.httpDenylist":   true,
+}
+
+// restartSettings returns the keys of the changed settings the running bot cannot apply
+func restartSettings(current, updated *config.Config) []string {
+	keys := changedSettings("", reflect.ValueOf(*current), reflect.ValueOf(*updated))
+	return append(keys, changedSettings("bot.", reflect.ValueOf(current.Bot), reflect.ValueOf(updated.Bot))...)
+}
+
+// changedSettings compares the fields of two structs by their YAML key, skipping the live settings
+func changedSettings(prefix string, current, updated reflect.Value) []string {
+	var keys []string
+	for i := range current.NumField() {
+		name, _, _ := strings.Cut(current.Type().Field(i).Tag.Get("yaml"), ",")
+		key := prefix + name
+		if key == "bot" || liveSettings[key] {
+			continue
+		}
+		if !reflect.DeepEqual(current.Field(i).Interface(), updated.Field(i).Interface()) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -2,8 +2,16 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,28 +22,237 @@ type Config struct {
 	Actions []ActionConfig `yaml:"actions,omitempty"`
 	Auth    *AuthConfig    `yaml:"auth,omitempty"`
 	Secrets *SecretsConfig `yaml:"secrets,omitempty"`
+	Audit   *AuditConfig   `yaml:"audit,omitempty"`
+	Logging *LoggingConfig `yaml:"logging,omitempty"`
+	Plugins []string       `yaml:"plugins,omitempty"`
+
+	Coordination *CoordinationConfig `yaml:"coordination,omitempty"`
 }
 
 // BotConfig contains Discord bot configuration
 type BotConfig struct {
-	Token         string `yaml:"token,omitempty"`
-	TokenEnvVar   string `yaml:"tokenEnvVar,omitempty"`
-	TokenVaultPath string `yaml:"tokenVaultPath,omitempty"`
-	Prefix        string `yaml:"prefix"`
-	Status        string `yaml:"status,omitempty"`
-	ActivityType  string `yaml:"activityType,omitempty"`
+	Token               string            `yaml:"token,omitempty"`
+	TokenEnvVar         string            `yaml:"tokenEnvVar,omitempty"`
+	TokenVaultPath      string            `yaml:"tokenVaultPath,omitempty"`
+	Prefix              string            `yaml:"prefix"`
+	MentionTrigger      bool              `yaml:"mentionTrigger,omitempty"`
+	Status              string            `yaml:"status,omitempty"`
+	ActivityType        string            `yaml:"activityType,omitempty"`
+	Moderation          *ModerationConfig `yaml:"moderation,omitempty"`
+	AdminPort           int               `yaml:"adminPort,omitempty"`
+	AdminTokenEnvVar    string            `yaml:"adminTokenEnvVar,omitempty"`
+	AdminTokenVaultPath string            `yaml:"adminTokenVaultPath,omitempty"`
+	Workers             *WorkersConfig    `yaml:"workers,omitempty"`
+	ShardID             int               `yaml:"shardId,omitempty"`
+	ShardCount          int               `yaml:"shardCount,omitempty"`
+
+	// GatewayCompression compresses gateway payloads, unset means enabled
+	GatewayCompression *bool `yaml:"gatewayCompression,omitempty"`
+	// LargeThreshold is the member count above which guilds are sent without offline members
+	LargeThreshold int `yaml:"largeThreshold,omitempty"`
+
+	// ReplayBuffer is the number of recent messages replayed after a reconnect
+	ReplayBuffer int `yaml:"replayBuffer,omitempty"`
+
+	// Features opts in to experimental functionality by name
+	Features map[string]bool `yaml:"features,omitempty"`
+
+	// Intents are requested in addition to the ones detected from the actions
+	Intents []string `yaml:"intents,omitempty"`
+
+	Scheduler *SchedulerConfig `yaml:"scheduler,omitempty"`
+
+	// Timezone is the IANA name of the zone of time_of_day conditions, UTC when unset
+	Timezone string `yaml:"timezone,omitempty"`
+
+	// OwnerIDs are the users bypassing rate limits and conditions, allowed to run the owner commands
+	OwnerIDs []string `yaml:"ownerIds,omitempty"`
+
+	// HTTPAllowlist and HTTPDenylist are the CIDR, IP or hostname patterns such as "*.example.com"
+	// http responses may or may not reach, private networks are denied unless allowlisted
+	HTTPAllowlist []string `yaml:"httpAllowlist,omitempty"`
+	HTTPDenylist  []string `yaml:"httpDenylist,omitempty"`
+}
+
+// IsOwner reports whether the user is one of the bot owners
+func (c BotConfig) IsOwner(userID string) bool {
+	return userID != "" && slices.Contains(c.OwnerIDs, userID)
+}
+
+// SchedulerConfig configures the execution of scheduled actions
+type SchedulerConfig struct {
+	// BatchSize is the number of channels a scheduled action sends to at the same time
+	BatchSize int `yaml:"batchSize,omitempty"`
+}
+
+// Discord accepts large thresholds between 50 and 250
+const (
+	MinLargeThreshold     = 50
+	DefaultLargeThreshold = 250
+)
+
+// CompressionEnabled reports whether gateway compression is enabled, it is by default
+func (b BotConfig) CompressionEnabled() bool {
+	return b.GatewayCompression == nil || *b.GatewayCompression
+}
+
+// WorkersConfig configures the worker pool executing actions
+type WorkersConfig struct {
+	PoolSize      int `yaml:"poolSize"`
+	QueueCapacity int `yaml:"queueCapacity"`
+}
+
+// ModerationConfig defines content moderation rules applied before action matching
+type ModerationConfig struct {
+	BlockedPatterns []string `yaml:"blockedPatterns,omitempty"`
+	Action          string   `yaml:"action,omitempty"`
+	WarningMessage  string   `yaml:"warningMessage,omitempty"`
 }
 
 // ActionConfig represents a bot action configuration
 type ActionConfig struct {
-	Name        string         `yaml:"name"`
-	Description string         `yaml:"description,omitempty"`
-	Type        string         `yaml:"type"`
-	Trigger     TriggerConfig  `yaml:"trigger"`
-	Response    ResponseConfig `yaml:"response"`
-	RequireAuth bool           `yaml:"requireAuth,omitempty"`
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description,omitempty"`
+	Type        string           `yaml:"type"`
+	Trigger     TriggerConfig    `yaml:"trigger"`
+	Response    ResponseConfig   `yaml:"response"`
+	RequireAuth bool             `yaml:"requireAuth,omitempty"`
+	RateLimit   *RateLimitConfig `yaml:"rateLimit,omitempty"`
+
+	// Conditions must all hold for a matched action to run
+	Conditions []ConditionConfig `yaml:"conditions,omitempty"`
+
+	// Enabled disables the action when set to false, unset means enabled
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// MaxExecutions disables the action after that many successful executions, 0 means unlimited.
+	// MaxReachedMessage is sent to the channel of the execution reaching the limit.
+	MaxExecutions     int    `yaml:"maxExecutions,omitempty"`
+	MaxReachedMessage string `yaml:"maxReachedMessage,omitempty"`
+
+	// DeadLetterChannel and DeadLetterWebhook receive a report when the action fails
+	DeadLetterChannel string `yaml:"deadLetterChannel,omitempty"`
+	DeadLetterWebhook string `yaml:"deadLetterWebhook,omitempty"`
+
+	GuildOverrides map[string]ActionOverride `yaml:"guildOverrides,omitempty"`
+}
+
+// ActionOverride changes parts of an action for a specific guild
+type ActionOverride struct {
+	Content   string           `yaml:"content,omitempty"`
+	Color     int              `yaml:"color,omitempty"`
+	RateLimit *RateLimitConfig `yaml:"rateLimit,omitempty"`
+}
+
+// IsEnabled returns whether the action is enabled in the configuration
+func (a ActionConfig) IsEnabled() bool {
+	return a.Enabled == nil || *a.Enabled
+}
+
+// ForGuild returns the action with the override of the given guild applied
+func (a ActionConfig) ForGuild(guildID string) ActionConfig {
+	override, ok := a.GuildOverrides[guildID]
+	if !ok {
+		return a
+	}
+
+	if override.Content != "" {
+		a.Response.Content = override.Content
+	}
+
+	if override.Color != 0 && a.Response.Embed != nil {
+		embed := *a.Response.Embed
+		embed.Color = override.Color
+		embed.ColorName = ""
+		a.Response.Embed = &embed
+	}
+
+	if override.RateLimit != nil {
+		a.RateLimit = override.RateLimit
+	}
+
+	return a
+}
+
+// ConditionConfig restricts when a matched action runs
+type ConditionConfig struct {
+	Type string `yaml:"type"`
+
+	// Operator compares the checked value to Value, equals when unset.
+	// Role and permission conditions only accept equals and not,
+	// boost_level conditions hold from the Value tier unless the operator is not,
+	// lt, gt, lte and gte only apply to word_count conditions.
+	Operator string `yaml:"operator,omitempty"`
+	Value    string `yaml:"value"`
+
+	// Message is sent to the channel when the condition fails
+	Message string `yaml:"message,omitempty"`
+
+	// Timezone is the IANA name of the zone of a time_of_day range, bot.timezone when unset
+	Timezone string `yaml:"timezone,omitempty"`
+}
+
+// ConditionTypes are the accepted condition types
+var ConditionTypes = []string{"boost_level", "channel", "content", "permission", "role", "time_of_day", "user", "word_count"}
+
+// ConditionOperators are the accepted condition operators
+var ConditionOperators = []string{"equals", "not", "contains", "startsWith", "endsWith", "matches", "lt", "gt", "lte", "gte"}
+
+// numericOperators are the operators of numeric conditions
+var numericOperators = []string{"", "equals", "not", "lt", "gt", "lte", "gte"}
+
+// ParseTimeRange parses a time of day range such as "09:00-17:00" into minutes since midnight.
+// The range crosses midnight when end is before start.
+func ParseTimeRange(value string) (start, end int, err error) {
+	from, to, ok := strings.Cut(value, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid time range: %s (must be HH:MM-HH:MM)", value)
+	}
+
+	if start, err = parseTimeOfDay(from); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseTimeOfDay(to); err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
 }
 
+// validateHostPatterns checks the CIDR, IP or hostname patterns of an http allowlist or denylist
+func validateHostPatterns(field string, patterns []string) error {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			return fmt.Errorf("invalid %s: empty pattern", field)
+		}
+		if strings.Contains(pattern, "/") {
+			if _, _, err := net.ParseCIDR(pattern); err != nil {
+				return fmt.Errorf("invalid %s: invalid CIDR %q", field, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// parseTimeOfDay parses a HH:MM time into minutes since midnight
+func parseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day: %s (must be HH:MM)", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// RateLimitConfig defines per-action rate limiting
+type RateLimitConfig struct {
+	Requests int    `yaml:"requests"`
+	Window   int    `yaml:"window"`
+	Scope    string `yaml:"scope,omitempty"`
+}
+
+// RateLimitScopes are the accepted rate limit scopes, user when unset
+var RateLimitScopes = []string{"user", "channel", "guild", "global", "thread", "user+channel"}
+
 // TriggerConfig defines when an action is triggered
 type TriggerConfig struct {
 	Command  string   `yaml:"command,omitempty"`
@@ -43,24 +260,221 @@ type TriggerConfig struct {
 	Emoji    string   `yaml:"emoji,omitempty"`
 	Schedule string   `yaml:"schedule,omitempty"`
 	Channels []string `yaml:"channels,omitempty"`
+	Statuses []string `yaml:"statuses,omitempty"`
+
+	// Timezone is the IANA name of the zone of the schedule, the local one of the bot when unset
+	Timezone string `yaml:"timezone,omitempty"`
+
+	// Role is granted by reaction_role actions to the users reacting to the message MessageID
+	Role      string `yaml:"role,omitempty"`
+	MessageID string `yaml:"messageId,omitempty"`
+	// OnRemove is what reaction_role actions do when the reaction is removed: remove (default) or keep the role
+	OnRemove string `yaml:"onRemove,omitempty"`
+
+	// ThreadOnly restricts the action to messages sent in threads, NoThreads to messages outside threads
+	ThreadOnly bool `yaml:"threadOnly,omitempty"`
+	NoThreads  bool `yaml:"noThreads,omitempty"`
+
+	// MaxEmojis limits the tally of vote_tally actions to the most voted emojis, all of them if 0
+	MaxEmojis int `yaml:"maxEmojis,omitempty"`
+
+	// ArgSpecs document the arguments of a command for its generated help
+	ArgSpecs []ArgSpec `yaml:"argSpecs,omitempty"`
+}
+
+// ArgSpec describes an argument of a command
+type ArgSpec struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
+
+	// HelpHidden leaves the argument out of the generated help
+	HelpHidden bool `yaml:"helpHidden,omitempty"`
 }
 
+// ArgTypes are the accepted argument types, string when unset
+var ArgTypes = []string{"string", "int", "number", "bool", "duration", "user", "channel", "role"}
+
 // ResponseConfig defines how the bot responds
 type ResponseConfig struct {
 	Type     string       `yaml:"type"`
 	Content  string       `yaml:"content,omitempty"`
 	Embed    *EmbedConfig `yaml:"embed,omitempty"`
 	Reaction string       `yaml:"reaction,omitempty"`
+	HTTP     *HTTPConfig  `yaml:"http,omitempty"`
+
+	// StickerID is the sticker sent by sticker responses, e.g. {{stickerID "wave"}}
+	StickerID string `yaml:"stickerId,omitempty"`
+
+	// SourceChannelID and SourceMessageID identify the message re-posted by forward responses
+	SourceChannelID string `yaml:"sourceChannelId,omitempty"`
+	SourceMessageID string `yaml:"sourceMessageId,omitempty"`
+
+	// TargetGuildID and TargetChannelID identify the channel receiving relay responses, possibly in another guild
+	TargetGuildID   string `yaml:"targetGuildId,omitempty"`
+	TargetChannelID string `yaml:"targetChannelId,omitempty"`
+
+	// Webhook responses post content or embed to a Discord webhook,
+	// failed deliveries are retried up to MaxRetries times
+	WebhookURL string `yaml:"webhookUrl,omitempty"`
+	Username   string `yaml:"username,omitempty"`
+	AvatarURL  string `yaml:"avatarUrl,omitempty"`
+	MaxRetries int    `yaml:"maxRetries,omitempty"`
+}
+
+// HTTPConfig defines an outgoing HTTP request
+type HTTPConfig struct {
+	URL     string            `yaml:"url"`
+	Method  string            `yaml:"method,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Body    string            `yaml:"body,omitempty"`
+	Timeout int               `yaml:"timeout,omitempty"`
+
+	// BodyFilePath is read as the body on each request, relative paths are resolved against the config file directory
+	BodyFilePath string `yaml:"bodyFilePath,omitempty"`
+
+	// MaxRetries retries failed requests with exponential backoff starting at InitialBackoff milliseconds
+	MaxRetries     int   `yaml:"maxRetries,omitempty"`
+	InitialBackoff int   `yaml:"initialBackoff,omitempty"`
+	RetryOn        []int `yaml:"retryOn,omitempty"`
+
+	// TLS client certificate, key and CA are file paths or inline PEM
+	TLSClientCert string `yaml:"tlsClientCert,omitempty"`
+	TLSClientKey  string `yaml:"tlsClientKey,omitempty"`
+	TLSCACert     string `yaml:"tlsCACert,omitempty"`
+	TLSSkipVerify bool   `yaml:"tlsSkipVerify,omitempty"`
+
+	// ProxyURL routes the request through an http, https or socks5 proxy
+	ProxyURL string `yaml:"proxyUrl,omitempty"`
+
+	// ParseResponse exposes the response body to the follow-up response as .HTTPResponse
+	ParseResponse bool            `yaml:"parseResponse,omitempty"`
+	ParseFormat   string          `yaml:"parseFormat,omitempty"`
+	FollowUp      *ResponseConfig `yaml:"followUp,omitempty"`
+
+	// ResponseMapping builds an embed from the JSON response body
+	ResponseMapping *HTTPResponseMapping `yaml:"responseMapping,omitempty"`
+}
+
+// HTTPResponseMapping maps dot-notation JSON paths of an http response to embed parts
+type HTTPResponseMapping struct {
+	TitlePath       string         `yaml:"titlePath,omitempty"`
+	DescriptionPath string         `yaml:"descriptionPath,omitempty"`
+	ColorPath       string         `yaml:"colorPath,omitempty"`
+	FieldMappings   []FieldMapping `yaml:"fieldMappings,omitempty"`
+}
+
+// FieldMapping maps a JSON path to an embed field
+type FieldMapping struct {
+	Name      string `yaml:"name"`
+	ValuePath string `yaml:"valuePath"`
+	Inline    bool   `yaml:"inline,omitempty"`
 }
 
 // EmbedConfig represents a Discord embed
 type EmbedConfig struct {
-	Title       string        `yaml:"title,omitempty"`
-	Description string        `yaml:"description,omitempty"`
-	Color       int           `yaml:"color,omitempty"`
-	Fields      []EmbedField  `yaml:"fields,omitempty"`
-	Footer      string        `yaml:"footer,omitempty"`
-	Timestamp   bool          `yaml:"timestamp,omitempty"`
+	Title       string       `yaml:"title,omitempty"`
+	Description string       `yaml:"description,omitempty"`
+	Color       int          `yaml:"color,omitempty"`
+	Fields      []EmbedField `yaml:"fields,omitempty"`
+	Footer      string       `yaml:"footer,omitempty"`
+	Timestamp   bool         `yaml:"timestamp,omitempty"`
+
+	// URL links the title, Image and Thumbnail are image URLs
+	URL       string       `yaml:"url,omitempty"`
+	Image     string       `yaml:"image,omitempty"`
+	Thumbnail string       `yaml:"thumbnail,omitempty"`
+	Author    *EmbedAuthor `yaml:"author,omitempty"`
+
+	// FooterIconURL is the HTTPS image shown next to the footer
+	FooterIconURL string `yaml:"footerIconUrl,omitempty"`
+
+	// Align pads the fields with empty ones to fill the last row of 3 inline fields
+	Align bool `yaml:"align,omitempty"`
+
+	// TruncateOnOverflow is true to shorten the fields over the Discord limits, the default, or error to fail
+	TruncateOnOverflow string `yaml:"truncateOnOverflow,omitempty"`
+
+	// ColorName holds the color when given as a string such as "gold" or "#FF5733"
+	ColorName string `yaml:"-"`
+}
+
+// embedConfigFields has the fields of EmbedConfig without its YAML methods
+type embedConfigFields EmbedConfig
+
+// UnmarshalYAML accepts the embed color as an integer or a string
+func (e *EmbedConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+
+	// The color is taken out of the mapping, the other fields decode as usual
+	fields := *value
+	var color *yaml.Node
+	if value.Kind == yaml.MappingNode {
+		fields.Content = make([]*yaml.Node, 0, len(value.Content))
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			if value.Content[i].Value == "color" {
+				color = value.Content[i+1]
+				continue
+			}
+			fields.Content = append(fields.Content, value.Content[i], value.Content[i+1])
+		}
+	}
+
+	if err := fields.Decode((*embedConfigFields)(e)); err != nil {
+		return err
+	}
+	if color == nil {
+		return nil
+	}
+
+	var raw interface{}
+	if err := color.Decode(&raw); err != nil {
+		return err
+	}
+	switch raw := raw.(type) {
+	case nil:
+	case int:
+		e.Color = raw
+	case string:
+		e.ColorName = raw
+	default:
+		return fmt.Errorf("invalid embed color: %v", raw)
+	}
+
+	return nil
+}
+
+// MarshalYAML writes the color name when the embed color was given as a string
+func (e EmbedConfig) MarshalYAML() (interface{}, error) {
+	if e.ColorName == "" {
+		return embedConfigFields(e), nil
+	}
+
+	// A placeholder color keeps the key in place, its value is replaced by the name
+	fields := embedConfigFields(e)
+	fields.Color = 1
+
+	var node yaml.Node
+	if err := node.Encode(fields); err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "color" {
+			node.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: e.ColorName}
+		}
+	}
+
+	return &node, nil
+}
+
+// EmbedAuthor is the author shown at the top of an embed
+type EmbedAuthor struct {
+	Name    string `yaml:"name"`
+	URL     string `yaml:"url,omitempty"`
+	IconURL string `yaml:"iconUrl,omitempty"`
 }
 
 // EmbedField represents a field in a Discord embed
@@ -72,26 +486,61 @@ type EmbedField struct {
 
 // AuthConfig contains OAuth authentication configuration
 type AuthConfig struct {
-	Enabled         bool     `yaml:"enabled"`
-	Provider        string   `yaml:"provider"`
-	ClientID        string   `yaml:"clientId"`
-	ClientSecretEnvVar string `yaml:"clientSecretEnvVar"`
-	RedirectURL     string   `yaml:"redirectUrl"`
-	Scopes          []string `yaml:"scopes,omitempty"`
-	AuthorizedUsers []string `yaml:"authorizedUsers,omitempty"`
-	AuthorizedRoles []string `yaml:"authorizedRoles,omitempty"`
+	Enabled            bool     `yaml:"enabled"`
+	Provider           string   `yaml:"provider"`
+	ClientID           string   `yaml:"clientId"`
+	ClientSecretEnvVar string   `yaml:"clientSecretEnvVar"`
+	RedirectURL        string   `yaml:"redirectUrl"`
+	Scopes             []string `yaml:"scopes,omitempty"`
+	AuthorizedUsers    []string `yaml:"authorizedUsers,omitempty"`
+	AuthorizedRoles    []string `yaml:"authorizedRoles,omitempty"`
+}
+
+// AuditConfig contains audit logging configuration
+type AuditConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	Path      string `yaml:"path"`
+	MaxSizeMB int    `yaml:"maxSizeMB,omitempty"`
+}
+
+// LoggingConfig contains the log level and format, with levels overridden per module
+type LoggingConfig struct {
+	// Level is debug, info, warn or error
+	Level string `yaml:"level,omitempty"`
+	// Format is json or text
+	Format string `yaml:"format,omitempty"`
+	// Modules sets the level of a module, e.g. scheduler: debug
+	Modules map[string]string `yaml:"modules,omitempty"`
+	// SampleRate keeps 1 in SampleRate debug and info messages logged for every message
+	SampleRate int `yaml:"sampleRate,omitempty"`
+}
+
+// LogLevels are the accepted log levels
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// LogModules are the modules whose log level can be set
+var LogModules = []string{"actions", "admin", "audit", "moderation", "plugins", "ratelimit", "response", "scheduler"}
+
+// CoordinationConfig contains the settings shared by multiple bot instances
+type CoordinationConfig struct {
+	Redis *RedisConfig `yaml:"redis,omitempty"`
+}
+
+// RedisConfig contains Redis connection configuration
+type RedisConfig struct {
+	Address string `yaml:"address"`
 }
 
 // SecretsConfig contains secret management configuration
 type SecretsConfig struct {
-	Provider   string              `yaml:"provider"`
-	Address    string              `yaml:"address"`
-	AuthMethod string              `yaml:"authMethod"`
-	MountPath  string              `yaml:"mountPath,omitempty"`
-	TLSVerify  bool                `yaml:"tlsVerify,omitempty"`
-	Kubernetes *KubernetesAuthConfig `yaml:"kubernetes,omitempty"`
-	AppRole    *AppRoleAuthConfig  `yaml:"appRole,omitempty"`
-	TokenEnvVar string              `yaml:"tokenEnvVar,omitempty"`
+	Provider    string                `yaml:"provider"`
+	Address     string                `yaml:"address"`
+	AuthMethod  string                `yaml:"authMethod"`
+	MountPath   string                `yaml:"mountPath,omitempty"`
+	TLSVerify   bool                  `yaml:"tlsVerify,omitempty"`
+	Kubernetes  *KubernetesAuthConfig `yaml:"kubernetes,omitempty"`
+	AppRole     *AppRoleAuthConfig    `yaml:"appRole,omitempty"`
+	TokenEnvVar string                `yaml:"tokenEnvVar,omitempty"`
 }
 
 // KubernetesAuthConfig for Kubernetes authentication
@@ -106,22 +555,261 @@ type AppRoleAuthConfig struct {
 	SecretID string `yaml:"secretId"`
 }
 
+// LoadOption changes how configuration files are loaded
+type LoadOption func(*loadOptions)
+
+// loadOptions are the settings of a configuration load
+type loadOptions struct {
+	packs *ActionPackLoader
+}
+
+// WithActionPacks fetches the action packs imported with $url using loader
+func WithActionPacks(loader *ActionPackLoader) LoadOption {
+	return func(o *loadOptions) {
+		o.packs = loader
+	}
+}
+
+// WithoutRemoteActions rejects the $url imports instead of fetching them
+func WithoutRemoteActions() LoadOption {
+	return WithActionPacks(nil)
+}
+
+// newLoadOptions applies opts to the default load settings
+func newLoadOptions(opts []LoadOption) loadOptions {
+	options := loadOptions{packs: defaultActionPacks}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
 // Load reads and parses the configuration file
-func Load(path string) (*Config, error) {
+func Load(path string, opts ...LoadOption) (*Config, error) {
+	return LoadProfile(path, "", opts...)
+}
+
+// Profiles selects the configuration overlay of an environment
+var Profiles = []string{"development", "staging", "production"}
+
+// profileVariable is replaced by the active profile in configuration files
+const profileVariable = "{{profile}}"
+
+// DefaultProfile is rendered for the profile variable when no profile is active
+const DefaultProfile = "default"
+
+// LoadProfile reads the configuration file and merges the overlay of the profile,
+// config.<profile>.yaml next to it, when it exists. An empty profile loads the file alone.
+// Actions entries such as "- $url: https://..." are replaced by the actions of the remote pack.
+func LoadProfile(path, profile string, opts ...LoadOption) (*Config, error) {
+	return loadProfile(path, profile, newLoadOptions(opts))
+}
+
+// LoadRuntime loads the configuration run by the bot, the profile merged with
+// the actions added at runtime in dynamic_actions.yaml next to the file.
+// Commands editing the config file use LoadProfile so the dynamic actions are not written to it.
+func LoadRuntime(path, profile string, opts ...LoadOption) (*Config, error) {
+	cfg, err := loadProfile(path, profile, newLoadOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	dynamic, err := LoadDynamicActions(DynamicActionsPath(path))
+	if err != nil {
+		return nil, err
+	}
+	cfg.Actions = MergeActions(cfg.Actions, dynamic)
+
+	return cfg, nil
+}
+
+// loadProfile reads the configuration file merged with the overlay of the profile
+func loadProfile(path, profile string, options loadOptions) (*Config, error) {
+	if profile != "" && !slices.Contains(Profiles, profile) {
+		return nil, fmt.Errorf("unknown profile %q (must be one of %s)", profile, strings.Join(Profiles, ", "))
+	}
+
 	// #nosec G304 -- Path is from command-line argument, expected behavior for config loading
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	cfg, err := parse(data, profile, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		return cfg, nil
+	}
+
+	overlayPath := ProfilePath(path, profile)
+	// #nosec G304 -- Path is derived from the config file path
+	overlay, err := os.ReadFile(overlayPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile config: %w", err)
+	}
+
+	overlay, err = resolveActionImports(expandProfile(overlay, profile), options.packs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", overlayPath, err)
+	}
+
+	merged, err := MergeConfigs(cfg, overlay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge %s: %w", overlayPath, err)
+	}
+
+	return merged, nil
+}
+
+// Parse parses configuration file data without a profile, as Load does
+func Parse(data []byte, opts ...LoadOption) (*Config, error) {
+	return parse(data, "", newLoadOptions(opts))
+}
+
+// parse expands the profile variable and the action imports of configuration data and decodes it
+func parse(data []byte, profile string, options loadOptions) (*Config, error) {
+	data, err := resolveActionImports(expandProfile(data, profile), options.packs)
+	if err != nil {
+		return nil, err
+	}
+
 	var cfg Config
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
-
 	return &cfg, nil
 }
 
+// ProfilePath returns the path of the profile overlay of a configuration file
+func ProfilePath(path, profile string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + profile + ext
+}
+
+// MergeConfigs applies a YAML overlay to a copy of the configuration.
+// Settings in the overlay replace the base ones, actions are merged by name.
+func MergeConfigs(base *Config, overlay []byte) (*Config, error) {
+	data, err := Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged Config
+	if err := yaml.Unmarshal(data, &merged); err != nil {
+		return nil, fmt.Errorf("failed to copy config: %w", err)
+	}
+	baseActions := merged.Actions
+
+	if err := yaml.Unmarshal(overlay, &merged); err != nil {
+		return nil, fmt.Errorf("failed to parse overlay: %w", err)
+	}
+
+	var overlayActions ActionsFile
+	if err := yaml.Unmarshal(overlay, &overlayActions); err != nil {
+		return nil, fmt.Errorf("failed to parse overlay: %w", err)
+	}
+	merged.Actions = MergeActions(baseActions, overlayActions.Actions)
+
+	return &merged, nil
+}
+
+// expandProfile replaces the profile variable in configuration data
+func expandProfile(data []byte, profile string) []byte {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	return bytes.ReplaceAll(data, []byte(profileVariable), []byte(profile))
+}
+
+// ActionsFile is a standalone file containing only action definitions
+type ActionsFile struct {
+	Actions []ActionConfig `yaml:"actions"`
+}
+
+// LoadActions reads and parses a standalone actions file
+func LoadActions(path string, opts ...LoadOption) ([]ActionConfig, error) {
+	// #nosec G304 -- Path is from command-line argument, expected behavior for config loading
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read actions file: %w", err)
+	}
+
+	data, err = resolveActionImports(data, newLoadOptions(opts).packs)
+	if err != nil {
+		return nil, err
+	}
+
+	var file ActionsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse actions file: %w", err)
+	}
+
+	return file.Actions, nil
+}
+
+// MergeActions merges overlay actions into base actions, deduplicating by name.
+// Actions in overlay replace base actions with the same name, new actions are appended.
+func MergeActions(base, overlay []ActionConfig) []ActionConfig {
+	merged := make([]ActionConfig, len(base), len(base)+len(overlay))
+	copy(merged, base)
+
+	index := make(map[string]int, len(merged))
+	for i, action := range merged {
+		index[action.Name] = i
+	}
+
+	for _, action := range overlay {
+		if i, exists := index[action.Name]; exists {
+			merged[i] = action
+			continue
+		}
+		index[action.Name] = len(merged)
+		merged = append(merged, action)
+	}
+
+	return merged
+}
+
+// Marshal serializes the configuration to YAML
+func Marshal(cfg *Config) ([]byte, error) {
+	data, err := encodeYAML(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return data, nil
+}
+
+// MarshalActions serializes actions to a standalone actions file
+func MarshalActions(actions []ActionConfig) ([]byte, error) {
+	data, err := encodeYAML(ActionsFile{Actions: actions})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal actions: %w", err)
+	}
+	return data, nil
+}
+
+// encodeYAML encodes a value to YAML with two-space indentation
+func encodeYAML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 // GetBotToken retrieves the bot token from configured sources
 // Priority: Direct token > Environment variable > Vault
 func (c *Config) GetBotToken() (string, error) {
@@ -147,6 +835,25 @@ func (c *Config) GetBotToken() (string, error) {
 	return "", fmt.Errorf("no token source configured")
 }
 
+// GetAdminToken retrieves the admin API bearer token from configured sources
+// Priority: Environment variable > Vault
+func (c *Config) GetAdminToken() (string, error) {
+	if c.Bot.AdminTokenEnvVar != "" {
+		token := os.Getenv(c.Bot.AdminTokenEnvVar)
+		if token == "" {
+			return "", fmt.Errorf("environment variable %s not set", c.Bot.AdminTokenEnvVar)
+		}
+		return token, nil
+	}
+
+	// Vault path would be handled by secrets manager
+	if c.Bot.AdminTokenVaultPath != "" {
+		return "", fmt.Errorf("vault token retrieval requires secrets manager")
+	}
+
+	return "", fmt.Errorf("no admin token source configured")
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	// Validate bot config
@@ -159,5 +866,335 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("no token source configured (token, tokenEnvVar, or tokenVaultPath required)")
 	}
 
+	// The admin API must never run unauthenticated
+	if c.Bot.AdminPort != 0 {
+		if c.Bot.AdminPort < 0 || c.Bot.AdminPort > 65535 {
+			return fmt.Errorf("invalid admin port: %d", c.Bot.AdminPort)
+		}
+		if c.Bot.AdminTokenEnvVar == "" && c.Bot.AdminTokenVaultPath == "" {
+			return fmt.Errorf("admin API requires a token source (adminTokenEnvVar or adminTokenVaultPath)")
+		}
+	}
+
+	if c.Bot.ShardCount < 0 {
+		return fmt.Errorf("shardCount must not be negative")
+	}
+	if c.Bot.ShardCount > 1 && (c.Bot.ShardID < 0 || c.Bot.ShardID >= c.Bot.ShardCount) {
+		return fmt.Errorf("shardId must be between 0 and %d", c.Bot.ShardCount-1)
+	}
+
+	if c.Bot.LargeThreshold != 0 && (c.Bot.LargeThreshold < MinLargeThreshold || c.Bot.LargeThreshold > DefaultLargeThreshold) {
+		return fmt.Errorf("largeThreshold must be between %d and %d", MinLargeThreshold, DefaultLargeThreshold)
+	}
+
+	if c.Bot.Timezone != "" {
+		if _, err := time.LoadLocation(c.Bot.Timezone); err != nil {
+			return fmt.Errorf("invalid bot timezone: %s", c.Bot.Timezone)
+		}
+	}
+
+	if err := validateHostPatterns("httpAllowlist", c.Bot.HTTPAllowlist); err != nil {
+		return err
+	}
+	if err := validateHostPatterns("httpDenylist", c.Bot.HTTPDenylist); err != nil {
+		return err
+	}
+
+	if c.Bot.ReplayBuffer < 0 {
+		return fmt.Errorf("replayBuffer must not be negative")
+	}
+
+	// Validate worker pool config
+	if w := c.Bot.Workers; w != nil {
+		if w.PoolSize < 1 {
+			return fmt.Errorf("workers poolSize must be at least 1")
+		}
+		if w.QueueCapacity < w.PoolSize {
+			return fmt.Errorf("workers queueCapacity must be at least poolSize")
+		}
+	}
+
+	if c.Bot.Scheduler != nil && c.Bot.Scheduler.BatchSize < 0 {
+		return fmt.Errorf("scheduler batchSize must not be negative")
+	}
+
+	// Validate audit config
+	if c.Audit != nil && c.Audit.Enabled {
+		if c.Audit.Path == "" {
+			return fmt.Errorf("audit path is required when audit is enabled")
+		}
+		if c.Audit.MaxSizeMB < 0 {
+			return fmt.Errorf("audit maxSizeMB must not be negative")
+		}
+	}
+
+	if err := validateLogging(c.Logging); err != nil {
+		return err
+	}
+
+	if c.Coordination != nil && c.Coordination.Redis != nil && c.Coordination.Redis.Address == "" {
+		return fmt.Errorf("coordination redis address is required")
+	}
+
+	// Validate action responses
+	seen := make(map[string]bool, len(c.Actions))
+	for _, action := range c.Actions {
+		if seen[action.Name] {
+			return fmt.Errorf("duplicate action name: %q", action.Name)
+		}
+		seen[action.Name] = true
+
+		if action.Response.Type == "embed" && action.Response.Embed == nil {
+			return fmt.Errorf("action %s: embed response requires an embed", action.Name)
+		}
+		if err := validateEmbed(action.Response.Embed); err != nil {
+			return fmt.Errorf("action %s: %w", action.Name, err)
+		}
+		if err := validateHTTP(action.Response.HTTP); err != nil {
+			return fmt.Errorf("action %s: %w", action.Name, err)
+		}
+		if action.Response.Type == "webhook" && action.Response.WebhookURL == "" {
+			return fmt.Errorf("action %s: webhook response requires a webhookUrl", action.Name)
+		}
+		if action.Response.Type == "sticker" && action.Response.StickerID == "" {
+			return fmt.Errorf("action %s: sticker response requires a stickerId", action.Name)
+		}
+		if action.Response.Type == "forward" && (action.Response.SourceChannelID == "" || action.Response.SourceMessageID == "") {
+			return fmt.Errorf("action %s: forward response requires a sourceChannelId and a sourceMessageId", action.Name)
+		}
+		if action.Response.Type == "relay" {
+			if action.Response.TargetGuildID == "" || action.Response.TargetChannelID == "" {
+				return fmt.Errorf("action %s: relay response requires a targetGuildId and a targetChannelId", action.Name)
+			}
+			if action.Response.Content == "" && action.Response.Embed == nil {
+				return fmt.Errorf("action %s: relay response requires content or an embed", action.Name)
+			}
+		}
+		if action.Type == "reaction_role" {
+			if action.Trigger.Emoji == "" || action.Trigger.Role == "" || action.Trigger.MessageID == "" {
+				return fmt.Errorf("action %s: reaction_role requires an emoji, a role and a messageId", action.Name)
+			}
+			switch action.Trigger.OnRemove {
+			case "", "remove", "keep":
+			default:
+				return fmt.Errorf("action %s: invalid onRemove: %s (must be remove or keep)", action.Name, action.Trigger.OnRemove)
+			}
+		}
+		if action.Trigger.Timezone != "" {
+			if _, err := time.LoadLocation(action.Trigger.Timezone); err != nil {
+				return fmt.Errorf("action %s: invalid trigger timezone: %s", action.Name, action.Trigger.Timezone)
+			}
+		}
+		if action.RateLimit != nil && action.RateLimit.Scope != "" && !slices.Contains(RateLimitScopes, action.RateLimit.Scope) {
+			return fmt.Errorf("action %s: invalid rate limit scope: %s (must be one of %s)", action.Name, action.RateLimit.Scope, strings.Join(RateLimitScopes, ", "))
+		}
+		if action.Trigger.MaxEmojis < 0 {
+			return fmt.Errorf("action %s: maxEmojis must not be negative", action.Name)
+		}
+		if action.MaxExecutions < 0 {
+			return fmt.Errorf("action %s: maxExecutions must not be negative", action.Name)
+		}
+		if action.Trigger.ThreadOnly && action.Trigger.NoThreads {
+			return fmt.Errorf("action %s: threadOnly and noThreads are mutually exclusive", action.Name)
+		}
+		if err := validateArgSpecs(action.Trigger.ArgSpecs); err != nil {
+			return fmt.Errorf("action %s: %w", action.Name, err)
+		}
+		if err := validateConditions(action.Conditions); err != nil {
+			return fmt.Errorf("action %s: %w", action.Name, err)
+		}
+	}
+
+	// Validate moderation config
+	if c.Bot.Moderation != nil {
+		switch c.Bot.Moderation.Action {
+		case "", "ignore", "delete", "warn":
+		default:
+			return fmt.Errorf("invalid moderation action: %s (must be ignore, delete, or warn)", c.Bot.Moderation.Action)
+		}
+	}
+
+	return nil
+}
+
+// validateLogging checks the log levels, format and module names
+func validateLogging(cfg *LoggingConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.Level != "" && !slices.Contains(LogLevels, cfg.Level) {
+		return fmt.Errorf("invalid logging level: %s (must be one of %s)", cfg.Level, strings.Join(LogLevels, ", "))
+	}
+	switch cfg.Format {
+	case "", "json", "text":
+	default:
+		return fmt.Errorf("invalid logging format: %s (must be json or text)", cfg.Format)
+	}
+
+	if cfg.SampleRate < 0 {
+		return fmt.Errorf("logging sampleRate must not be negative")
+	}
+
+	for module, level := range cfg.Modules {
+		if !slices.Contains(LogModules, module) {
+			return fmt.Errorf("unknown logging module: %s (must be one of %s)", module, strings.Join(LogModules, ", "))
+		}
+		if !slices.Contains(LogLevels, level) {
+			return fmt.Errorf("invalid logging level for %s: %s (must be one of %s)", module, level, strings.Join(LogLevels, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateArgSpecs checks that arguments are named once, with a known type,
+// and that required arguments come before optional ones
+func validateArgSpecs(specs []ArgSpec) error {
+	seen := make(map[string]bool, len(specs))
+	optional := false
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return fmt.Errorf("argument name is required")
+		}
+		if seen[spec.Name] {
+			return fmt.Errorf("duplicate argument: %s", spec.Name)
+		}
+		seen[spec.Name] = true
+
+		if spec.Type != "" && !slices.Contains(ArgTypes, spec.Type) {
+			return fmt.Errorf("unknown argument type: %s (must be one of %s)", spec.Type, strings.Join(ArgTypes, ", "))
+		}
+		if spec.Required && optional {
+			return fmt.Errorf("required argument %s must come before optional arguments", spec.Name)
+		}
+		optional = optional || !spec.Required
+	}
+
+	return nil
+}
+
+// validateConditions checks the condition types and operators
+func validateConditions(conditions []ConditionConfig) error {
+	for _, condition := range conditions {
+		if !slices.Contains(ConditionTypes, condition.Type) {
+			return fmt.Errorf("unknown condition type: %s (must be one of %s)", condition.Type, strings.Join(ConditionTypes, ", "))
+		}
+		if condition.Operator != "" && !slices.Contains(ConditionOperators, condition.Operator) {
+			return fmt.Errorf("unknown condition operator: %s (must be one of %s)", condition.Operator, strings.Join(ConditionOperators, ", "))
+		}
+
+		switch condition.Type {
+		case "channel", "content", "user":
+			switch condition.Operator {
+			case "lt", "gt", "lte", "gte":
+				return fmt.Errorf("%s condition does not accept the %s operator", condition.Type, condition.Operator)
+			}
+		case "word_count":
+			if !slices.Contains(numericOperators, condition.Operator) {
+				return fmt.Errorf("word_count condition only accepts the equals, not, lt, gt, lte and gte operators")
+			}
+			if count, err := strconv.Atoi(condition.Value); err != nil || count < 0 {
+				return fmt.Errorf("invalid word_count: %s (must be a positive number)", condition.Value)
+			}
+		case "role", "permission":
+			switch condition.Operator {
+			case "", "equals", "not":
+			default:
+				return fmt.Errorf("%s condition only accepts the equals and not operators", condition.Type)
+			}
+		case "boost_level":
+			if condition.Operator != "" && condition.Operator != "not" {
+				return fmt.Errorf("boost_level condition only accepts the not operator")
+			}
+			if tier, err := strconv.Atoi(condition.Value); err != nil || tier < 0 || tier > 3 {
+				return fmt.Errorf("invalid boost_level: %s (must be between 0 and 3)", condition.Value)
+			}
+		case "time_of_day":
+			if condition.Operator != "" && condition.Operator != "not" {
+				return fmt.Errorf("time_of_day condition only accepts the not operator")
+			}
+			if _, _, err := ParseTimeRange(condition.Value); err != nil {
+				return err
+			}
+			if condition.Timezone != "" {
+				if _, err := time.LoadLocation(condition.Timezone); err != nil {
+					return fmt.Errorf("invalid condition timezone: %s", condition.Timezone)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// maxEmbedFields is the maximum number of fields Discord accepts in an embed
+const maxEmbedFields = 25
+
+// validateEmbed checks an embed against the limits enforced by Discord
+func validateEmbed(embed *EmbedConfig) error {
+	if embed == nil {
+		return nil
+	}
+
+	// An embed showing only an image or an author is valid for Discord
+	hasAuthor := embed.Author != nil && embed.Author.Name != ""
+	if embed.Title == "" && embed.Description == "" && len(embed.Fields) == 0 &&
+		embed.Image == "" && embed.Thumbnail == "" && !hasAuthor {
+		return fmt.Errorf("embed requires a title, description, fields, image, thumbnail, or author")
+	}
+
+	if len(embed.Fields) > maxEmbedFields {
+		return fmt.Errorf("embed has %d fields (maximum %d)", len(embed.Fields), maxEmbedFields)
+	}
+
+	if embed.Color < 0 || embed.Color > 0xFFFFFF {
+		return fmt.Errorf("invalid embed color: %d (must be between 0 and 0xFFFFFF)", embed.Color)
+	}
+
+	switch embed.TruncateOnOverflow {
+	case "", "true", "error":
+	default:
+		return fmt.Errorf("invalid embed truncateOnOverflow: %s (must be true or error)", embed.TruncateOnOverflow)
+	}
+
+	return nil
+}
+
+// MaxHTTPRetries is the highest maxRetries of an http response, the backoff is capped long before
+const MaxHTTPRetries = 10
+
+// validateHTTP checks an http response configuration
+func validateHTTP(cfg *HTTPConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.URL == "" {
+		return fmt.Errorf("http response requires a url")
+	}
+
+	if cfg.MaxRetries < 0 || cfg.InitialBackoff < 0 {
+		return fmt.Errorf("http maxRetries and initialBackoff must not be negative")
+	}
+
+	if cfg.MaxRetries > MaxHTTPRetries {
+		return fmt.Errorf("http maxRetries must be at most %d", MaxHTTPRetries)
+	}
+
+	if cfg.Body != "" && cfg.BodyFilePath != "" {
+		return fmt.Errorf("http body and bodyFilePath are mutually exclusive")
+	}
+
+	if (cfg.TLSClientCert == "") != (cfg.TLSClientKey == "") {
+		return fmt.Errorf("http tlsClientCert and tlsClientKey must be set together")
+	}
+
+	switch cfg.ParseFormat {
+	case "", "json", "text":
+	default:
+		return fmt.Errorf("invalid http parseFormat: %s (must be json or text)", cfg.ParseFormat)
+	}
+
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadConfig_Success(t *testing.T) {
@@ -160,3 +161,792 @@ func TestConfig_Validate_MissingToken(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "token source")
 }
+
+func TestConfig_Validate_InvalidModerationAction(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:  "valid-token",
+			Prefix: "!",
+			Moderation: &config.ModerationConfig{
+				BlockedPatterns: []string{"spam"},
+				Action:          "ban",
+			},
+		},
+	}
+
+	err := cfg.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid moderation action")
+}
+
+func TestConfig_Validate_AuditWithoutPath(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:  "valid-token",
+			Prefix: "!",
+		},
+		Audit: &config.AuditConfig{
+			Enabled: true,
+		},
+	}
+
+	err := cfg.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "audit path")
+}
+
+func TestConfig_Validate_Logging(t *testing.T) {
+	tests := []struct {
+		name    string
+		logging *config.LoggingConfig
+		wantErr string
+	}{
+		{name: "defaults", logging: &config.LoggingConfig{}},
+		{name: "module levels", logging: &config.LoggingConfig{Level: "warn", Format: "text", Modules: map[string]string{"scheduler": "debug"}}},
+		{name: "invalid level", logging: &config.LoggingConfig{Level: "verbose"}, wantErr: "invalid logging level"},
+		{name: "invalid format", logging: &config.LoggingConfig{Format: "xml"}, wantErr: "invalid logging format"},
+		{name: "unknown module", logging: &config.LoggingConfig{Modules: map[string]string{"auth": "debug"}}, wantErr: "unknown logging module"},
+		{name: "negative sample rate", logging: &config.LoggingConfig{SampleRate: -1}, wantErr: "sampleRate must not be negative"},
+		{name: "invalid module level", logging: &config.LoggingConfig{Modules: map[string]string{"scheduler": "trace"}}, wantErr: "invalid logging level for scheduler"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Bot:     config.BotConfig{Token: "valid-token", Prefix: "!"},
+				Logging: tt.logging,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Validate_Conditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []config.ConditionConfig
+		wantErr    string
+	}{
+		{name: "default operator", conditions: []config.ConditionConfig{{Type: "channel", Value: "123"}}},
+		{name: "content operator", conditions: []config.ConditionConfig{{Type: "content", Operator: "contains", Value: "urgent"}}},
+		{name: "role not", conditions: []config.ConditionConfig{{Type: "role", Operator: "not", Value: "123"}}},
+		{name: "unknown type", conditions: []config.ConditionConfig{{Type: "weather", Value: "sunny"}}, wantErr: "unknown condition type"},
+		{name: "unknown operator", conditions: []config.ConditionConfig{{Type: "content", Operator: "like", Value: "a"}}, wantErr: "unknown condition operator"},
+		{name: "boost level", conditions: []config.ConditionConfig{{Type: "boost_level", Value: "2"}}},
+		{name: "boost level out of range", conditions: []config.ConditionConfig{{Type: "boost_level", Value: "4"}}, wantErr: "invalid boost_level"},
+		{name: "time of day", conditions: []config.ConditionConfig{{Type: "time_of_day", Value: "22:00-06:00", Timezone: "Europe/Paris"}}},
+		{name: "invalid time range", conditions: []config.ConditionConfig{{Type: "time_of_day", Value: "9am-5pm"}}, wantErr: "invalid time of day"},
+		{name: "invalid timezone", conditions: []config.ConditionConfig{{Type: "time_of_day", Value: "09:00-17:00", Timezone: "Mars/Olympus"}}, wantErr: "invalid condition timezone"},
+		{name: "word count", conditions: []config.ConditionConfig{{Type: "word_count", Operator: "gte", Value: "5"}}},
+		{name: "word count substring", conditions: []config.ConditionConfig{{Type: "word_count", Operator: "contains", Value: "5"}}, wantErr: "word_count condition only accepts"},
+		{name: "content numeric operator", conditions: []config.ConditionConfig{{Type: "content", Operator: "gt", Value: "5"}}, wantErr: "does not accept the gt operator"},
+		{name: "role substring", conditions: []config.ConditionConfig{{Type: "role", Operator: "contains", Value: "1"}}, wantErr: "only accepts the equals and not operators"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+				Actions: []config.ActionConfig{
+					{Name: "ask", Type: "command", Response: config.ResponseConfig{Type: "text", Content: "ok"}, Conditions: tt.conditions},
+				},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Validate_ReactionRole(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{Name: "gamer-role", Type: "reaction_role", Trigger: config.TriggerConfig{Emoji: "🎮", Role: "123", MessageID: "456", OnRemove: "keep"}},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].Trigger.OnRemove = "toggle"
+	assert.ErrorContains(t, cfg.Validate(), "invalid onRemove")
+
+	cfg.Actions[0].Trigger = config.TriggerConfig{Emoji: "🎮", MessageID: "456"}
+	assert.ErrorContains(t, cfg.Validate(), "requires an emoji, a role and a messageId")
+
+	cfg.Actions[0].Trigger = config.TriggerConfig{Emoji: "🎮", Role: "123"}
+	assert.ErrorContains(t, cfg.Validate(), "requires an emoji, a role and a messageId")
+}
+
+func TestConfig_Validate_ThreadFlags(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ask",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ask", ThreadOnly: true},
+				Response: config.ResponseConfig{Type: "text", Content: "answered"},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].Trigger.NoThreads = true
+	assert.ErrorContains(t, cfg.Validate(), "threadOnly and noThreads are mutually exclusive")
+}
+
+func TestConfig_Validate_DuplicateActionName(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{Name: "ping", Type: "command", Trigger: config.TriggerConfig{Command: "ping"}, Response: config.ResponseConfig{Type: "text", Content: "pong"}},
+			{Name: "ping", Type: "command", Trigger: config.TriggerConfig{Command: "p"}, Response: config.ResponseConfig{Type: "text", Content: "pong"}},
+		},
+	}
+
+	assert.EqualError(t, cfg.Validate(), `duplicate action name: "ping"`)
+}
+
+func TestConfig_Validate_TriggerTimezone(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "morning",
+				Type:     "scheduled",
+				Trigger:  config.TriggerConfig{Schedule: "0 0 9 * * *", Timezone: "Europe/Paris", Channels: []string{"123"}},
+				Response: config.ResponseConfig{Type: "text", Content: "Good morning"},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].Trigger.Timezone = "Europe/Atlantis"
+	assert.ErrorContains(t, cfg.Validate(), "action morning: invalid trigger timezone: Europe/Atlantis")
+}
+
+func TestConfig_Validate_TruncateOnOverflow(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "report",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "report"},
+				Response: config.ResponseConfig{Type: "embed", Embed: &config.EmbedConfig{Title: "Report", TruncateOnOverflow: "error"}},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].Response.Embed.TruncateOnOverflow = "never"
+	assert.ErrorContains(t, cfg.Validate(), "invalid embed truncateOnOverflow: never (must be true or error)")
+}
+
+func TestConfig_Validate_HTTPLists(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:         "valid-token",
+			Prefix:        "!",
+			HTTPAllowlist: []string{"10.0.0.0/8", "api.example.com", "*.example.org"},
+			HTTPDenylist:  []string{"10.0.0.5"},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Bot.HTTPDenylist = []string{"10.0.0.0/40"}
+	assert.ErrorContains(t, cfg.Validate(), `invalid httpDenylist: invalid CIDR "10.0.0.0/40"`)
+
+	cfg.Bot.HTTPDenylist = nil
+	cfg.Bot.HTTPAllowlist = []string{""}
+	assert.ErrorContains(t, cfg.Validate(), "invalid httpAllowlist: empty pattern")
+}
+
+func TestConfig_Validate_MaxEmojis(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{Name: "tally", Type: "vote_tally", Trigger: config.TriggerConfig{MaxEmojis: 5}},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].Trigger.MaxEmojis = -1
+	assert.ErrorContains(t, cfg.Validate(), "maxEmojis must not be negative")
+}
+
+func TestConfig_Validate_RateLimitScope(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:      "roll",
+				Type:      "command",
+				Trigger:   config.TriggerConfig{Command: "roll"},
+				Response:  config.ResponseConfig{Type: "text", Content: "4"},
+				RateLimit: &config.RateLimitConfig{Requests: 1, Window: 60, Scope: "user+channel"},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].RateLimit.Scope = "thread"
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].RateLimit.Scope = "role"
+	assert.ErrorContains(t, cfg.Validate(), "action roll: invalid rate limit scope: role")
+}
+
+func TestConfig_Validate_ArgSpecs(t *testing.T) {
+	tests := []struct {
+		name   string
+		specs  []config.ArgSpec
+		errMsg string
+	}{
+		{name: "valid", specs: []config.ArgSpec{{Name: "member", Type: "user", Required: true}, {Name: "reason"}}},
+		{name: "missing name", specs: []config.ArgSpec{{Type: "user"}}, errMsg: "argument name is required"},
+		{name: "duplicate", specs: []config.ArgSpec{{Name: "member"}, {Name: "member"}}, errMsg: "duplicate argument: member"},
+		{name: "unknown type", specs: []config.ArgSpec{{Name: "count", Type: "float"}}, errMsg: "unknown argument type: float"},
+		{name: "required after optional", specs: []config.ArgSpec{{Name: "reason"}, {Name: "member", Required: true}}, errMsg: "required argument member must come before optional arguments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+				Actions: []config.ActionConfig{
+					{
+						Name:     "ban",
+						Type:     "command",
+						Trigger:  config.TriggerConfig{Command: "ban", ArgSpecs: tt.specs},
+						Response: config.ResponseConfig{Type: "text", Content: "banned"},
+					},
+				},
+			}
+
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestConfig_Validate_MaxExecutions(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:          "claim",
+				Type:          "command",
+				Trigger:       config.TriggerConfig{Command: "claim"},
+				Response:      config.ResponseConfig{Type: "text", Content: "You won!"},
+				MaxExecutions: 100,
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].MaxExecutions = -1
+	assert.ErrorContains(t, cfg.Validate(), "maxExecutions must not be negative")
+}
+
+func TestConfig_Validate_Forward(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "repost",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "repost"},
+				Response: config.ResponseConfig{Type: "forward", SourceChannelID: "{{.ChannelID}}", SourceMessageID: "123"},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].Response.SourceMessageID = ""
+	assert.ErrorContains(t, cfg.Validate(), "requires a sourceChannelId and a sourceMessageId")
+}
+
+func TestConfig_Validate_Relay(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "announce",
+				Type:     "scheduled",
+				Trigger:  config.TriggerConfig{Schedule: "0 0 9 * * *"},
+				Response: config.ResponseConfig{Type: "relay", Content: "Good morning", TargetGuildID: "456", TargetChannelID: "789"},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].Response.Content = ""
+	assert.ErrorContains(t, cfg.Validate(), "relay response requires content or an embed")
+
+	cfg.Actions[0].Response.Content = "Good morning"
+	cfg.Actions[0].Response.TargetGuildID = ""
+	assert.ErrorContains(t, cfg.Validate(), "requires a targetGuildId and a targetChannelId")
+}
+
+func TestParseTimeRange(t *testing.T) {
+	start, end, err := config.ParseTimeRange("09:30-17:00")
+	require.NoError(t, err)
+	assert.Equal(t, 9*60+30, start)
+	assert.Equal(t, 17*60, end)
+
+	_, _, err = config.ParseTimeRange("09:30")
+	assert.ErrorContains(t, err, "invalid time range")
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			TokenEnvVar: "DISCORD_BOT_TOKEN",
+			Prefix:      "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+			},
+		},
+	}
+
+	data, err := config.Marshal(cfg)
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, data, 0600))
+
+	loaded, err := config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+}
+
+func TestMergeActions(t *testing.T) {
+	base := []config.ActionConfig{
+		{Name: "ping", Type: "command", Response: config.ResponseConfig{Content: "Pong!"}},
+		{Name: "help", Type: "command"},
+	}
+	overlay := []config.ActionConfig{
+		{Name: "ping", Type: "command", Response: config.ResponseConfig{Content: "Pong v2"}},
+		{Name: "greet", Type: "message"},
+	}
+
+	merged := config.MergeActions(base, overlay)
+
+	require.Len(t, merged, 3)
+	assert.Equal(t, "ping", merged[0].Name)
+	assert.Equal(t, "Pong v2", merged[0].Response.Content)
+	assert.Equal(t, "help", merged[1].Name)
+	assert.Equal(t, "greet", merged[2].Name)
+
+	// Base is left untouched
+	assert.Equal(t, "Pong!", base[0].Response.Content)
+}
+
+func TestLoadActions(t *testing.T) {
+	actionsPath := filepath.Join(t.TempDir(), "actions.yaml")
+	content := `
+actions:
+  - name: "ping"
+    type: "command"
+    trigger:
+      command: "ping"
+`
+	require.NoError(t, os.WriteFile(actionsPath, []byte(content), 0600))
+
+	actions, err := config.LoadActions(actionsPath)
+
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "ping", actions[0].Trigger.Command)
+}
+
+func TestActionConfig_ForGuild(t *testing.T) {
+	action := config.ActionConfig{
+		Name: "welcome",
+		Response: config.ResponseConfig{
+			Type:    "embed",
+			Content: "Welcome!",
+			Embed:   &config.EmbedConfig{Title: "Hi", Color: 1},
+		},
+		GuildOverrides: map[string]config.ActionOverride{
+			"123456789012345678": {
+				Content:   "Bienvenue !",
+				Color:     2,
+				RateLimit: &config.RateLimitConfig{Requests: 1, Window: 60},
+			},
+		},
+	}
+
+	overridden := action.ForGuild("123456789012345678")
+	assert.Equal(t, "Bienvenue !", overridden.Response.Content)
+	assert.Equal(t, 2, overridden.Response.Embed.Color)
+	require.NotNil(t, overridden.RateLimit)
+	assert.Equal(t, 1, overridden.RateLimit.Requests)
+
+	// The base action is left untouched
+	assert.Equal(t, "Welcome!", action.Response.Content)
+	assert.Equal(t, 1, action.Response.Embed.Color)
+	assert.Nil(t, action.RateLimit)
+
+	unknown := action.ForGuild("999999999999999999")
+	assert.Equal(t, "Welcome!", unknown.Response.Content)
+}
+
+func TestConfig_Validate_InvalidHTTPParseFormat(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:  "valid-token",
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name: "weather",
+				Type: "command",
+				Response: config.ResponseConfig{
+					Type: "http",
+					HTTP: &config.HTTPConfig{
+						URL:           "https://api.example.com/weather",
+						ParseResponse: true,
+						ParseFormat:   "xml",
+					},
+				},
+			},
+		},
+	}
+
+	err := cfg.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "parseFormat")
+}
+
+func TestConfig_Validate_HTTPBodyFile(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name: "report",
+				Type: "command",
+				Response: config.ResponseConfig{
+					Type: "http",
+					HTTP: &config.HTTPConfig{URL: "https://api.example.com/report", BodyFilePath: "report.json"},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].Response.HTTP.Body = "{}"
+	assert.ErrorContains(t, cfg.Validate(), "http body and bodyFilePath are mutually exclusive")
+}
+
+func TestConfig_Validate_HTTPMaxRetries(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{Token: "valid-token", Prefix: "!"},
+		Actions: []config.ActionConfig{
+			{
+				Name: "report",
+				Type: "command",
+				Response: config.ResponseConfig{
+					Type: "http",
+					HTTP: &config.HTTPConfig{URL: "https://api.example.com/report", MaxRetries: config.MaxHTTPRetries},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Actions[0].Response.HTTP.MaxRetries = 64
+	assert.ErrorContains(t, cfg.Validate(), "http maxRetries must be at most 10")
+}
+
+func TestEmbedConfig_ColorFromYAML(t *testing.T) {
+	var embeds []config.EmbedConfig
+	err := yaml.Unmarshal([]byte(`
+- title: "int"
+  color: 3447003
+- title: "name"
+  color: "gold"
+- title: "hex"
+  color: "#FF5733"
+`), &embeds)
+	require.NoError(t, err)
+	require.Len(t, embeds, 3)
+
+	assert.Equal(t, 3447003, embeds[0].Color)
+	assert.Empty(t, embeds[0].ColorName)
+	assert.Equal(t, "gold", embeds[1].ColorName)
+	assert.Equal(t, "#FF5733", embeds[2].ColorName)
+
+	data, err := yaml.Marshal(embeds[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "color: gold")
+}
+
+func TestEmbedConfig_YAMLRoundTrip(t *testing.T) {
+	for _, embed := range []config.EmbedConfig{
+		{Title: "int", Color: 3447003, Align: true, TruncateOnOverflow: "error"},
+		{Title: "name", ColorName: "123456", Author: &config.EmbedAuthor{Name: "Bot"}, FooterIconURL: "https://example.com/icon.png"},
+		{Image: "https://example.com/cat.png"},
+	} {
+		data, err := yaml.Marshal(embed)
+		require.NoError(t, err)
+
+		var decoded config.EmbedConfig
+		require.NoError(t, yaml.Unmarshal(data, &decoded))
+		assert.Equal(t, embed, decoded, string(data))
+	}
+}
+
+func TestConfig_Validate_Embed(t *testing.T) {
+	tooManyFields := make([]config.EmbedField, 26)
+	for i := range tooManyFields {
+		tooManyFields[i] = config.EmbedField{Name: "name", Value: "value"}
+	}
+
+	tests := []struct {
+		name     string
+		response config.ResponseConfig
+		errMsg   string
+	}{
+		{
+			name:     "missing embed",
+			response: config.ResponseConfig{Type: "embed"},
+			errMsg:   "requires an embed",
+		},
+		{
+			name:     "empty embed",
+			response: config.ResponseConfig{Type: "embed", Embed: &config.EmbedConfig{Color: 1}},
+			errMsg:   "title, description, fields, image, thumbnail, or author",
+		},
+		{
+			name:     "author without name",
+			response: config.ResponseConfig{Type: "embed", Embed: &config.EmbedConfig{Author: &config.EmbedAuthor{URL: "https://example.com"}}},
+			errMsg:   "title, description, fields, image, thumbnail, or author",
+		},
+		{
+			name:     "image only",
+			response: config.ResponseConfig{Type: "embed", Embed: &config.EmbedConfig{Image: "https://example.com/cat.png"}},
+		},
+		{
+			name:     "thumbnail only",
+			response: config.ResponseConfig{Type: "embed", Embed: &config.EmbedConfig{Thumbnail: "https://example.com/cat.png"}},
+		},
+		{
+			name:     "author only",
+			response: config.ResponseConfig{Type: "embed", Embed: &config.EmbedConfig{Author: &config.EmbedAuthor{Name: "Bot"}}},
+		},
+		{
+			name:     "too many fields",
+			response: config.ResponseConfig{Type: "embed", Embed: &config.EmbedConfig{Fields: tooManyFields}},
+			errMsg:   "26 fields",
+		},
+		{
+			name:     "color out of range",
+			response: config.ResponseConfig{Type: "embed", Embed: &config.EmbedConfig{Title: "Hi", Color: 0x1000000}},
+			errMsg:   "invalid embed color",
+		},
+		{
+			name:     "valid embed",
+			response: config.ResponseConfig{Type: "embed", Embed: &config.EmbedConfig{Title: "Hi", Color: 0xFFFFFF}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Bot: config.BotConfig{
+					Token:  "valid-token",
+					Prefix: "!",
+				},
+				Actions: []config.ActionConfig{
+					{Name: "info", Type: "command", Response: tt.response},
+				},
+			}
+
+			err := cfg.Validate()
+
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestConfig_Validate_Workers(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers *config.WorkersConfig
+		wantErr string
+	}{
+		{name: "defaults"},
+		{name: "valid", workers: &config.WorkersConfig{PoolSize: 4, QueueCapacity: 20}},
+		{name: "zero pool size", workers: &config.WorkersConfig{QueueCapacity: 20}, wantErr: "poolSize"},
+		{name: "queue smaller than pool", workers: &config.WorkersConfig{PoolSize: 4, QueueCapacity: 2}, wantErr: "queueCapacity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Bot: config.BotConfig{
+					Token:   "valid-token",
+					Prefix:  "!",
+					Workers: tt.workers,
+				},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Validate_CoordinationWithoutAddress(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:  "valid-token",
+			Prefix: "!",
+		},
+		Coordination: &config.CoordinationConfig{
+			Redis: &config.RedisConfig{},
+		},
+	}
+
+	err := cfg.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "redis address")
+}
+
+func TestConfig_Validate_ShardIDOutOfRange(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:      "valid-token",
+			Prefix:     "!",
+			ShardID:    2,
+			ShardCount: 2,
+		},
+	}
+
+	err := cfg.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "shardId")
+}
+
+func TestBotConfig_Gateway(t *testing.T) {
+	disabled := false
+
+	assert.True(t, config.BotConfig{}.CompressionEnabled())
+	assert.False(t, config.BotConfig{GatewayCompression: &disabled}.CompressionEnabled())
+
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:          "valid-token",
+			Prefix:         "!",
+			LargeThreshold: 1000,
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "largeThreshold")
+
+	cfg.Bot.LargeThreshold = 100
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadProfile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	base := `
+bot:
+  token: "test-token-123"
+  prefix: "{{profile}}-!"
+  status: "Serving"
+actions:
+  - name: "ping"
+    type: "command"
+    trigger:
+      command: "ping"
+    response:
+      type: "text"
+      content: "Pong!"
+`
+	overlay := `
+bot:
+  status: "Testing on {{profile}}"
+actions:
+  - name: "ping"
+    type: "command"
+    trigger:
+      command: "ping"
+    response:
+      type: "text"
+      content: "Staging pong!"
+  - name: "debug"
+    type: "command"
+    trigger:
+      command: "debug"
+    response:
+      type: "text"
+      content: "Debug"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(base), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "config.staging.yaml"), []byte(overlay), 0644))
+
+	cfg, err := config.LoadProfile(configPath, "staging")
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-token-123", cfg.Bot.Token)
+	assert.Equal(t, "staging-!", cfg.Bot.Prefix)
+	assert.Equal(t, "Testing on staging", cfg.Bot.Status)
+	require.Len(t, cfg.Actions, 2)
+	assert.Equal(t, "Staging pong!", cfg.Actions[0].Response.Content)
+	assert.Equal(t, "debug", cfg.Actions[1].Name)
+
+	// Profiles without an overlay file use the base configuration
+	cfg, err = config.LoadProfile(configPath, "production")
+	require.NoError(t, err)
+	assert.Equal(t, "production-!", cfg.Bot.Prefix)
+	assert.Equal(t, "Serving", cfg.Bot.Status)
+
+	// Without a profile the variable renders the default name
+	cfg, err = config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "default-!", cfg.Bot.Prefix)
+
+	_, err = config.LoadProfile(configPath, "qa")
+	assert.ErrorContains(t, err, "unknown profile")
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DynamicActionsFile holds the actions added at runtime with the addaction owner command,
+// next to the configuration file
+const DynamicActionsFile = "dynamic_actions.yaml"
+
+// DynamicActionsPath returns the path of the dynamic actions of a configuration file
+func DynamicActionsPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), DynamicActionsFile)
+}
+
+// ParseAction parses a single action definition, rejecting unknown fields
+func ParseAction(data []byte) (ActionConfig, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var action ActionConfig
+	if err := decoder.Decode(&action); err != nil {
+		return ActionConfig{}, fmt.Errorf("failed to parse action: %w", err)
+	}
+	return action, nil
+}
+
+// LoadDynamicActions reads the dynamic actions file, a missing file has no actions
+func LoadDynamicActions(path string) ([]ActionConfig, error) {
+	// #nosec G304 -- Path is derived from the config file path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dynamic actions: %w", err)
+	}
+
+	var file ActionsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse dynamic actions: %w", err)
+	}
+	return file.Actions, nil
+}
+
+// SaveDynamicActions writes the dynamic actions file
+func SaveDynamicActions(path string, actions []ActionConfig) error {
+	data, err := encodeYAML(ActionsFile{Actions: actions})
+	if err != nil {
+		return fmt.Errorf("failed to marshal dynamic actions: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write dynamic actions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRuntime_DynamicActions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+bot:
+  token: "valid-token"
+  prefix: "!"
+actions:
+  - name: ping
+    type: command
+    trigger:
+      command: ping
+    response:
+      type: text
+      content: Pong!
+`), 0600))
+
+	dynamic := []config.ActionConfig{
+		{Name: "ping", Type: "command", Trigger: config.TriggerConfig{Command: "ping"}, Response: config.ResponseConfig{Type: "text", Content: "Pong again!"}},
+		{Name: "hello", Type: "command", Trigger: config.TriggerConfig{Command: "hello"}, Response: config.ResponseConfig{Type: "text", Content: "Hello!"}},
+	}
+	require.NoError(t, config.SaveDynamicActions(config.DynamicActionsPath(path), dynamic))
+
+	cfg, err := config.LoadProfile(path, "")
+	require.NoError(t, err)
+	require.Len(t, cfg.Actions, 1)
+	assert.Equal(t, "Pong!", cfg.Actions[0].Response.Content)
+
+	cfg, err = config.LoadRuntime(path, "")
+	require.NoError(t, err)
+	require.Len(t, cfg.Actions, 2)
+	// Dynamic actions replace the configured actions with the same name
+	assert.Equal(t, "Pong again!", cfg.Actions[0].Response.Content)
+	assert.Equal(t, "hello", cfg.Actions[1].Name)
+}
+
+func TestLoadDynamicActions_Missing(t *testing.T) {
+	actions, err := config.LoadDynamicActions(filepath.Join(t.TempDir(), config.DynamicActionsFile))
+	require.NoError(t, err)
+	assert.Empty(t, actions)
+}
+
+func TestParseAction(t *testing.T) {
+	action, err := config.ParseAction([]byte("name: hello\ntype: command\ntrigger:\n  command: hello\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", action.Trigger.Command)
+
+	_, err = config.ParseAction([]byte("name: hello\ntrigger:\n  comand: hello\n"))
+	assert.ErrorContains(t, err, "field comand not found")
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ImportActions merges actions into the actions list of a configuration file.
+// Actions defined in the file with the same name are replaced in place, the others are appended.
+// The rest of the file, including comments, $url imports and {{profile}} variables, is kept as written.
+func ImportActions(data []byte, actions []ActionConfig) ([]byte, error) {
+	document, err := parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	root := document.Content[0]
+	list := mappingChild(root, "actions")
+	switch {
+	case list == nil:
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "actions"}, list)
+	case list.Kind == yaml.ScalarNode && list.Tag == "!!null":
+		list.Kind, list.Tag, list.Value = yaml.SequenceNode, "!!seq", ""
+	case list.Kind != yaml.SequenceNode:
+		return nil, fmt.Errorf("actions is not a list")
+	}
+
+	for _, action := range actions {
+		var node yaml.Node
+		if err := node.Encode(action); err != nil {
+			return nil, fmt.Errorf("failed to encode action %s: %w", action.Name, err)
+		}
+
+		if i := actionIndex(list, action.Name); i >= 0 {
+			list.Content[i] = &node
+			continue
+		}
+		list.Content = append(list.Content, &node)
+	}
+
+	out, err := encodeYAML(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return out, nil
+}
+
+// ExportActions returns a standalone actions file with the actions defined in a configuration file,
+// as written, and their number. The actions of $url imports are left out.
+func ExportActions(data []byte) ([]byte, int, error) {
+	document, err := parseDocument(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	exported := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	if list := mappingChild(document.Content[0], "actions"); list != nil && list.Kind == yaml.SequenceNode {
+		for _, entry := range list.Content {
+			if !isActionImport(entry) {
+				exported.Content = append(exported.Content, entry)
+			}
+		}
+	}
+
+	file := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "actions"},
+		exported,
+	}}
+	out, err := encodeYAML(file)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal actions: %w", err)
+	}
+	return out, len(exported.Content), nil
+}
+
+// parseDocument parses a configuration file into a document whose root is a mapping
+func parseDocument(data []byte) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config root is not a mapping")
+	}
+	return &document, nil
+}
+
+// actionIndex returns the index of the action with a name in an actions list node, or -1
+func actionIndex(list *yaml.Node, name string) int {
+	for i, entry := range list.Content {
+		if entry.Kind != yaml.MappingNode {
+			continue
+		}
+		if value := mappingChild(entry, "name"); value != nil && value.Value == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// isActionImport reports whether an actions list entry imports a remote pack, e.g. - $url: https://...
+func isActionImport(entry *yaml.Node) bool {
+	return entry.Kind == yaml.MappingNode && len(entry.Content) == 2 && entry.Content[0].Value == actionImportKey
+}
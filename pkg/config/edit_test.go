@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const editedConfig = `# Production bot
+bot:
+  token: "test-token"
+  prefix: "{{profile}}-!"
+actions:
+  # Shared pack
+  - $url: https://example.com/pack.yaml
+  - name: ping
+    type: command
+    trigger:
+      command: ping
+    response:
+      type: text
+      content: Pong!
+`
+
+func TestImportActions(t *testing.T) {
+	data, err := config.ImportActions([]byte(editedConfig), []config.ActionConfig{
+		{Name: "ping", Type: "command", Trigger: config.TriggerConfig{Command: "ping"}, Response: config.ResponseConfig{Type: "text", Content: "Pong again!"}},
+		{Name: "hello", Type: "command", Trigger: config.TriggerConfig{Command: "hello"}, Response: config.ResponseConfig{Type: "text", Content: "Hi!"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Production bot
+bot:
+  token: "test-token"
+  prefix: "{{profile}}-!"
+actions:
+  # Shared pack
+  - $url: https://example.com/pack.yaml
+  - name: ping
+    type: command
+    trigger:
+      command: ping
+    response:
+      type: text
+      content: Pong again!
+  - name: hello
+    type: command
+    trigger:
+      command: hello
+    response:
+      type: text
+      content: Hi!
+`, string(data))
+
+	// A config without actions gets the list
+	data, err = config.ImportActions([]byte("bot:\n  prefix: \"!\"\n"), []config.ActionConfig{
+		{Name: "hello", Type: "command", Trigger: config.TriggerConfig{Command: "hello"}, Response: config.ResponseConfig{Type: "text", Content: "Hi!"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "bot:\n  prefix: \"!\"\nactions:\n  - name: hello\n    type: command\n    trigger:\n      command: hello\n    response:\n      type: text\n      content: Hi!\n", string(data))
+}
+
+func TestExportActions(t *testing.T) {
+	data, count, err := config.ExportActions([]byte(editedConfig))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, count)
+	assert.Equal(t, `actions:
+  - name: ping
+    type: command
+    trigger:
+      command: ping
+    response:
+      type: text
+      content: Pong!
+`, string(data))
+}
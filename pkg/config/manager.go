@@ -0,0 +1,260 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ApplyFunc applies a validated configuration to the running bot
+type ApplyFunc func(*Config) error
+
+// Manager holds the configuration and applies validated runtime changes to it
+type Manager struct {
+	path    string
+	config  *Config
+	apply   ApplyFunc
+	pending []change
+	mu      sync.RWMutex
+}
+
+// change is a setting changed since the configuration was last persisted
+type change struct {
+	path  []string
+	value interface{}
+}
+
+// NewManager creates a manager for a configuration persisted to path
+func NewManager(path string, cfg *Config) *Manager {
+	return &Manager{
+		path:   path,
+		config: cfg,
+	}
+}
+
+// Config returns the current configuration, it must not be modified
+func (m *Manager) Config() *Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config
+}
+
+// SetPath sets the file written by Persist
+func (m *Manager) SetPath(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.path = path
+}
+
+// SetApplyFunc sets the function that applies runtime changes before they are committed
+func (m *Manager) SetApplyFunc(fn ApplyFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apply = fn
+}
+
+// Replace swaps the configuration, for example after the file was reloaded.
+// Changes not persisted yet are discarded with the configuration they applied to.
+func (m *Manager) Replace(cfg *Config) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config = cfg
+	m.pending = nil
+}
+
+// SetString sets the string setting at a dot-separated key such as "bot.prefix"
+func (m *Manager) SetString(key, value string) error {
+	return m.set(key, value)
+}
+
+// SetInt sets the integer setting at a dot-separated key such as "bot.adminPort"
+func (m *Manager) SetInt(key string, value int) error {
+	return m.set(key, value)
+}
+
+// SetBool sets the boolean setting at a dot-separated key such as "bot.gatewayCompression"
+func (m *Manager) SetBool(key string, value bool) error {
+	return m.set(key, value)
+}
+
+// Persist writes the settings changed since the last persist to the config file.
+// Only the changed keys are edited, the rest of the file, including comments,
+// key order, $url imports and {{profile}} variables, is kept as written.
+func (m *Manager) Persist() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.path == "" {
+		return fmt.Errorf("no config file to persist to")
+	}
+
+	// #nosec G304 -- Path is the config file the bot was started with
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file root is not a mapping")
+	}
+
+	for _, c := range m.pending {
+		if err := setNode(root, c.path, c.value); err != nil {
+			return fmt.Errorf("failed to persist %s: %w", strings.Join(c.path, "."), err)
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(m.path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	m.pending = nil
+	return nil
+}
+
+// set applies a change to a copy of the configuration and commits it if the result is valid
+func (m *Manager) set(key string, value interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fields, err := toFields(m.config)
+	if err != nil {
+		return err
+	}
+
+	if err := setField(fields, strings.Split(key, "."), value); err != nil {
+		return fmt.Errorf("invalid config key %s: %w", key, err)
+	}
+
+	data, err := yaml.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	// Known fields reject keys that do not map to a setting
+	var updated Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&updated); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	if err := updated.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// A change the running bot cannot apply is not committed
+	if m.apply != nil {
+		if err := m.apply(&updated); err != nil {
+			return fmt.Errorf("failed to apply configuration: %w", err)
+		}
+	}
+
+	m.config = &updated
+	m.pending = append(m.pending, change{path: strings.Split(key, "."), value: value})
+	return nil
+}
+
+// toFields converts the configuration to nested maps keyed by YAML names
+func toFields(cfg *Config) (map[string]interface{}, error) {
+	data, err := Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+	return fields, nil
+}
+
+// setField sets a value in nested maps, creating intermediate maps as needed
+func setField(fields map[string]interface{}, path []string, value interface{}) error {
+	for _, name := range path[:len(path)-1] {
+		next, exists := fields[name]
+		if !exists {
+			child := make(map[string]interface{})
+			fields[name] = child
+			fields = child
+			continue
+		}
+
+		child, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not a section", name)
+		}
+		fields = child
+	}
+
+	fields[path[len(path)-1]] = value
+	return nil
+}
+
+// setNode sets a value in a YAML mapping node, creating intermediate mappings as needed.
+// An existing value node is updated in place so its comments are kept.
+func setNode(node *yaml.Node, path []string, value interface{}) error {
+	for _, name := range path[:len(path)-1] {
+		child := mappingChild(node, name)
+		switch {
+		case child == nil:
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, child)
+		case child.Kind == yaml.ScalarNode && child.Tag == "!!null":
+			child.Kind, child.Tag, child.Value = yaml.MappingNode, "!!map", ""
+		case child.Kind != yaml.MappingNode:
+			return fmt.Errorf("%s is not a section", name)
+		}
+		node = child
+	}
+
+	var encoded yaml.Node
+	if err := encoded.Encode(value); err != nil {
+		return err
+	}
+
+	name := path[len(path)-1]
+	if existing := mappingChild(node, name); existing != nil {
+		// A string replacing a string keeps its quoting
+		if existing.Tag != "!!str" || encoded.Tag != "!!str" {
+			existing.Style = encoded.Style
+		}
+		existing.Kind, existing.Tag, existing.Value, existing.Content = encoded.Kind, encoded.Tag, encoded.Value, encoded.Content
+		return nil
+	}
+
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, &encoded)
+	return nil
+}
+
+// mappingChild returns the value of a key in a YAML mapping node
+func mappingChild(node *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_SetAndPersist(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`bot:
+  token: "test-token"
+  prefix: "!"
+actions:
+  - name: ping
+    type: command
+    trigger:
+      command: ping
+    response:
+      type: text
+      content: Pong!
+`), 0600))
+
+	mgr := config.NewManager(configPath, &config.Config{
+		Bot: config.BotConfig{
+			Token:  "test-token",
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:     "ping",
+				Type:     "command",
+				Trigger:  config.TriggerConfig{Command: "ping"},
+				Response: config.ResponseConfig{Type: "text", Content: "Pong!"},
+			},
+		},
+	})
+
+	require.NoError(t, mgr.SetString("bot.prefix", "?"))
+	require.NoError(t, mgr.SetInt("bot.largeThreshold", 100))
+	require.NoError(t, mgr.SetBool("bot.gatewayCompression", false))
+	require.NoError(t, mgr.SetString("audit.path", "audit.log"))
+	require.NoError(t, mgr.SetBool("audit.enabled", true))
+
+	require.NoError(t, mgr.Persist())
+
+	cfg, err := config.Load(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "?", cfg.Bot.Prefix)
+	assert.Equal(t, 100, cfg.Bot.LargeThreshold)
+	assert.False(t, cfg.Bot.CompressionEnabled())
+	assert.Equal(t, &config.AuditConfig{Enabled: true, Path: "audit.log"}, cfg.Audit)
+	require.Len(t, cfg.Actions, 1)
+	assert.Equal(t, "Pong!", cfg.Actions[0].Response.Content)
+}
+
+func TestManager_PersistKeepsFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`# Production bot
+bot:
+  token: "test-token"
+  prefix: "{{profile}}-!" # per environment
+  status: "Serving"
+actions:
+  - $url: https://example.com/pack.yaml
+  - name: ping
+    type: command
+    trigger:
+      command: ping
+    response:
+      type: text
+      content: Pong!
+`), 0600))
+
+	// The runtime configuration has the profile rendered and the pack expanded
+	mgr := config.NewManager(configPath, &config.Config{
+		Bot: config.BotConfig{Token: "test-token", Prefix: "default-!", Status: "Serving"},
+		Actions: []config.ActionConfig{
+			{Name: "from-pack", Type: "command", Trigger: config.TriggerConfig{Command: "pack"}, Response: config.ResponseConfig{Type: "text", Content: "Pack"}},
+			{Name: "ping", Type: "command", Trigger: config.TriggerConfig{Command: "ping"}, Response: config.ResponseConfig{Type: "text", Content: "Pong!"}},
+		},
+	})
+
+	require.NoError(t, mgr.SetString("bot.status", "Maintenance"))
+	require.NoError(t, mgr.SetInt("bot.largeThreshold", 100))
+	require.NoError(t, mgr.Persist())
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, `# Production bot
+bot:
+  token: "test-token"
+  prefix: "{{profile}}-!" # per environment
+  status: "Maintenance"
+  largeThreshold: 100
+actions:
+  - $url: https://example.com/pack.yaml
+  - name: ping
+    type: command
+    trigger:
+      command: ping
+    response:
+      type: text
+      content: Pong!
+`, string(data))
+}
+
+func TestManager_SetRejectsInvalidChanges(t *testing.T) {
+	mgr := config.NewManager("", &config.Config{
+		Bot: config.BotConfig{
+			Token:  "test-token",
+			Prefix: "!",
+		},
+	})
+
+	tests := []struct {
+		name string
+		set  func() error
+	}{
+		{name: "unknown key", set: func() error { return mgr.SetString("bot.unknown", "value") }},
+		{name: "wrong type", set: func() error { return mgr.SetString("bot.adminPort", "port") }},
+		{name: "not a section", set: func() error { return mgr.SetString("bot.prefix.value", "?") }},
+		{name: "fails validation", set: func() error { return mgr.SetString("bot.prefix", "") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.set())
+			assert.Equal(t, "!", mgr.Config().Bot.Prefix)
+		})
+	}
+
+	assert.Error(t, mgr.Persist())
+}
+
+func TestManager_SetApplies(t *testing.T) {
+	mgr := config.NewManager("", &config.Config{
+		Bot: config.BotConfig{
+			Token:  "test-token",
+			Prefix: "!",
+		},
+	})
+
+	var applied *config.Config
+	mgr.SetApplyFunc(func(cfg *config.Config) error {
+		applied = cfg
+		return nil
+	})
+
+	require.NoError(t, mgr.SetString("bot.prefix", "?"))
+	require.NotNil(t, applied)
+	assert.Equal(t, "?", applied.Bot.Prefix)
+	assert.Same(t, applied, mgr.Config())
+
+	mgr.SetApplyFunc(func(cfg *config.Config) error {
+		return errors.New("reload failed")
+	})
+
+	assert.Error(t, mgr.SetString("bot.prefix", "$"))
+	assert.Equal(t, "?", mgr.Config().Bot.Prefix)
+}
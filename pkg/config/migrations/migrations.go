@@ -0,0 +1,105 @@
+// Package migrations transforms configuration files written for older versions of the bot.
+package migrations
+
+import (
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Migration transforms a configuration document from one version to the next.
+// Apply edits the root mapping node in place so key order and comments survive
+type Migration interface {
+	From() string
+	To() string
+	Apply(cfg *yaml.Node) error
+}
+
+var (
+	registry   []Migration
+	registryMu sync.RWMutex
+)
+
+// Register adds a migration to the registry
+func Register(migration Migration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, migration)
+}
+
+// Plan returns the migrations leading from one version to another, in order
+func Plan(from, to string) ([]Migration, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var plan []Migration
+	for version := from; version != to; {
+		next, ok := findMigration(version)
+		if !ok {
+			return nil, fmt.Errorf("no migration path from %s to %s", from, to)
+		}
+		plan = append(plan, next)
+		version = next.To()
+
+		// A registry with a cycle would never reach the target
+		if len(plan) > len(registry) {
+			return nil, fmt.Errorf("no migration path from %s to %s", from, to)
+		}
+	}
+
+	return plan, nil
+}
+
+// Migrate applies the migrations leading from one version to another to a parsed document
+func Migrate(doc *yaml.Node, from, to string) error {
+	plan, err := Plan(from, to)
+	if err != nil {
+		return err
+	}
+
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config root is not a mapping")
+	}
+
+	for _, migration := range plan {
+		if err := migration.Apply(root); err != nil {
+			return fmt.Errorf("migration %s->%s failed: %w", migration.From(), migration.To(), err)
+		}
+	}
+
+	return nil
+}
+
+// findMigration returns the registered migration starting at a version
+func findMigration(version string) (Migration, bool) {
+	for _, migration := range registry {
+		if migration.From() == version {
+			return migration, true
+		}
+	}
+	return nil, false
+}
+
+// mappingIndex returns the index of a key node in a mapping node, or -1
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// mappingValue returns the value node of a key in a mapping node
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, bool) {
+	i := mappingIndex(node, key)
+	if i < 0 {
+		return nil, false
+	}
+	return node.Content[i+1], true
+}
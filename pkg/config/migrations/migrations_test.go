@@ -0,0 +1,82 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMigrate_V1ToV2(t *testing.T) {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`
+bot:
+  prefix: "!"
+actions:
+  - name: ping
+    trigger:
+      command: ping
+      channel: "123"
+  - name: merged
+    trigger:
+      channel: "456"
+      channels: ["789"]
+  - name: untouched
+    trigger:
+      command: help
+`), &doc))
+
+	require.NoError(t, migrations.Migrate(&doc, "v1", "v2"))
+
+	var migrated map[string]interface{}
+	require.NoError(t, doc.Decode(&migrated))
+
+	actions := migrated["actions"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"command": "ping", "channels": []interface{}{"123"}}, actions[0].(map[string]interface{})["trigger"])
+	assert.Equal(t, map[string]interface{}{"channels": []interface{}{"789", "456"}}, actions[1].(map[string]interface{})["trigger"])
+	assert.Equal(t, map[string]interface{}{"command": "help"}, actions[2].(map[string]interface{})["trigger"])
+}
+
+func TestMigrate_KeepsKeyOrderAndComments(t *testing.T) {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`# bot settings
+bot:
+  prefix: "!"
+actions:
+  - name: ping
+    trigger:
+      channel: "123"
+      command: ping
+`), &doc))
+
+	require.NoError(t, migrations.Migrate(&doc, "v1", "v2"))
+
+	out, err := yaml.Marshal(&doc)
+	require.NoError(t, err)
+	assert.Equal(t, `# bot settings
+bot:
+    prefix: "!"
+actions:
+    - name: ping
+      trigger:
+        channels:
+            - "123"
+        command: ping
+`, string(out))
+}
+
+func TestPlan(t *testing.T) {
+	plan, err := migrations.Plan("v1", "v2")
+	require.NoError(t, err)
+	require.Len(t, plan, 1)
+	assert.Equal(t, "v1", plan[0].From())
+
+	plan, err = migrations.Plan("v2", "v2")
+	require.NoError(t, err)
+	assert.Empty(t, plan)
+
+	_, err = migrations.Plan("v2", "v1")
+	assert.Error(t, err)
+}
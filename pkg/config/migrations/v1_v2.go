@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register(channelsMigration{})
+}
+
+// channelsMigration renames the single trigger.channel of each action to the trigger.channels list
+type channelsMigration struct{}
+
+// From returns the version the migration applies to
+func (channelsMigration) From() string { return "v1" }
+
+// To returns the version the migration produces
+func (channelsMigration) To() string { return "v2" }
+
+// Apply moves trigger.channel into trigger.channels
+func (channelsMigration) Apply(cfg *yaml.Node) error {
+	actions, ok := mappingValue(cfg, "actions")
+	if !ok || actions.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	for i, action := range actions.Content {
+		if action.Kind != yaml.MappingNode {
+			return fmt.Errorf("action %d is not a mapping", i)
+		}
+
+		trigger, ok := mappingValue(action, "trigger")
+		if !ok || trigger.Kind != yaml.MappingNode {
+			continue
+		}
+
+		idx := mappingIndex(trigger, "channel")
+		if idx < 0 {
+			continue
+		}
+		channel := trigger.Content[idx+1]
+		hasValue := channel.Kind == yaml.ScalarNode && channel.Tag != "!!null" && channel.Value != ""
+
+		channels, ok := mappingValue(trigger, "channels")
+		switch {
+		case ok && channels.Kind == yaml.SequenceNode:
+			if hasValue {
+				channels.Content = append(channels.Content, channel)
+			}
+		case ok:
+			if hasValue {
+				*channels = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{channel}}
+			}
+		case hasValue:
+			// Rename in place so the list keeps the position of the old key
+			trigger.Content[idx].Value = "channels"
+			trigger.Content[idx+1] = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{channel}}
+			continue
+		}
+
+		trigger.Content = append(trigger.Content[:idx], trigger.Content[idx+2:]...)
+	}
+
+	return nil
+}
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// actionImportKey marks an actions entry importing a remote action pack, e.g. - $url: https://...
+const actionImportKey = "$url"
+
+// maxActionPackSize is the maximum size of a downloaded action pack
+const maxActionPackSize = 1 << 20
+
+// defaultActionPacks fetches the action packs imported by configuration files
+// unless a load option replaces it
+var defaultActionPacks = NewActionPackLoader(DefaultActionCacheDir())
+
+// ActionPackLoader downloads action packs and caches them on disk,
+// a cached pack is reused while the server reports its ETag unchanged
+type ActionPackLoader struct {
+	cacheDir string
+	client   *http.Client
+}
+
+// NewActionPackLoader creates a loader caching the packs in cacheDir
+func NewActionPackLoader(cacheDir string) *ActionPackLoader {
+	return &ActionPackLoader{
+		cacheDir: cacheDir,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// DefaultActionCacheDir returns ~/.gxf/action-cache, or a temporary directory without a home directory
+func DefaultActionCacheDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "gxf-action-cache")
+	}
+	return filepath.Join(home, ".gxf", "action-cache")
+}
+
+// Load returns the actions of the pack at rawURL
+func (l *ActionPackLoader) Load(rawURL string) ([]ActionConfig, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid action pack URL %q: must be an http or https URL", rawURL)
+	}
+
+	data, err := l.fetch(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := parseActionPack(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action pack %s: %w", rawURL, err)
+	}
+	return actions, nil
+}
+
+// fetch downloads a pack, or returns the cached copy when it has not changed
+// or the server cannot be reached
+func (l *ActionPackLoader) fetch(rawURL string) ([]byte, error) {
+	sum := sha256.Sum256([]byte(rawURL))
+	cachePath := filepath.Join(l.cacheDir, hex.EncodeToString(sum[:]))
+
+	// #nosec G304 -- Path is derived from a hash of the pack URL
+	cached, cacheErr := os.ReadFile(cachePath + ".yaml")
+	// #nosec G304 -- Path is derived from a hash of the pack URL
+	etag, _ := os.ReadFile(cachePath + ".etag")
+
+	request, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", rawURL, err)
+	}
+	if cacheErr == nil && len(etag) > 0 {
+		request.Header.Set("If-None-Match", string(etag))
+	}
+
+	resp, err := l.client.Do(request)
+	if err != nil {
+		if cacheErr == nil {
+			return cached, nil
+		}
+		return nil, fmt.Errorf("failed to fetch action pack %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cacheErr == nil:
+		return cached, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to fetch action pack %s: status %d", rawURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxActionPackSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read action pack %s: %w", rawURL, err)
+	}
+	if len(data) > maxActionPackSize {
+		return nil, fmt.Errorf("action pack %s exceeds %d bytes", rawURL, maxActionPackSize)
+	}
+
+	// The pack is usable even when it cannot be cached
+	if err := l.store(cachePath, data, resp.Header.Get("ETag")); err != nil {
+		_ = os.Remove(cachePath + ".etag")
+	}
+
+	return data, nil
+}
+
+// store writes a pack and its ETag to the cache
+func (l *ActionPackLoader) store(cachePath string, data []byte, etag string) error {
+	if err := os.MkdirAll(l.cacheDir, 0o750); err != nil {
+		return err
+	}
+	if err := os.WriteFile(cachePath+".yaml", data, 0o600); err != nil {
+		return err
+	}
+	if etag == "" {
+		err := os.Remove(cachePath + ".etag")
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return os.WriteFile(cachePath+".etag", []byte(etag), 0o600)
+}
+
+// parseActionPack parses an actions file, rejecting unknown fields and incomplete actions
+func parseActionPack(data []byte) ([]ActionConfig, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var pack ActionsFile
+	if err := decoder.Decode(&pack); err != nil {
+		return nil, fmt.Errorf("failed to parse actions: %w", err)
+	}
+
+	for i, action := range pack.Actions {
+		if action.Name == "" {
+			return nil, fmt.Errorf("action %d: name is required", i)
+		}
+		if action.Type == "" {
+			return nil, fmt.Errorf("action %s: type is required", action.Name)
+		}
+		// A pack must not read files from the host running the bot or weaken its TLS checks
+		for _, request := range httpConfigs(reflect.ValueOf(action)) {
+			if err := checkRemoteHTTP(request); err != nil {
+				return nil, fmt.Errorf("action %s: %w", action.Name, err)
+			}
+		}
+	}
+
+	return pack.Actions, nil
+}
+
+// httpConfigs returns every http request nested in a value, such as the
+// response of an action and its follow-ups
+func httpConfigs(v reflect.Value) []*HTTPConfig {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if cfg, ok := v.Interface().(*HTTPConfig); ok {
+			return append([]*HTTPConfig{cfg}, httpConfigs(v.Elem())...)
+		}
+		return httpConfigs(v.Elem())
+	case reflect.Struct:
+		var configs []*HTTPConfig
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				configs = append(configs, httpConfigs(v.Field(i))...)
+			}
+		}
+		return configs
+	case reflect.Slice, reflect.Array:
+		var configs []*HTTPConfig
+		for i := range v.Len() {
+			configs = append(configs, httpConfigs(v.Index(i))...)
+		}
+		return configs
+	case reflect.Map:
+		var configs []*HTTPConfig
+		for _, key := range v.MapKeys() {
+			configs = append(configs, httpConfigs(v.MapIndex(key))...)
+		}
+		return configs
+	}
+	return nil
+}
+
+// checkRemoteHTTP rejects the settings of a remote http request that read local files
+// or skip certificate verification, TLS certificates and keys must be inline PEM
+func checkRemoteHTTP(cfg *HTTPConfig) error {
+	if cfg.BodyFilePath != "" {
+		return fmt.Errorf("bodyFilePath is not allowed in remote actions")
+	}
+	if cfg.TLSSkipVerify {
+		return fmt.Errorf("tlsSkipVerify is not allowed in remote actions")
+	}
+
+	pems := []struct{ name, value string }{
+		{"tlsClientCert", cfg.TLSClientCert},
+		{"tlsClientKey", cfg.TLSClientKey},
+		{"tlsCACert", cfg.TLSCACert},
+	}
+	for _, pem := range pems {
+		if pem.value != "" && !strings.Contains(pem.value, "-----BEGIN") {
+			return fmt.Errorf("%s must be inline PEM in remote actions", pem.name)
+		}
+	}
+	return nil
+}
+
+// resolveActionImports replaces the $url entries of the actions list with the
+// actions of the packs fetched by packs, nil rejects the imports.
+// Data without imports is returned unchanged.
+func resolveActionImports(data []byte, packs *ActionPackLoader) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return data, nil
+	}
+
+	root := document.Content[0]
+	var actions *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "actions" && root.Content[i+1].Kind == yaml.SequenceNode {
+			actions = root.Content[i+1]
+		}
+	}
+	if actions == nil {
+		return data, nil
+	}
+
+	var imported bool
+	resolved := make([]*yaml.Node, 0, len(actions.Content))
+	for _, entry := range actions.Content {
+		if !isActionImport(entry) {
+			resolved = append(resolved, entry)
+			continue
+		}
+		imported = true
+
+		if packs == nil {
+			return nil, fmt.Errorf("cannot import %s: remote actions are disabled", entry.Content[1].Value)
+		}
+		pack, err := packs.Load(entry.Content[1].Value)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, action := range pack {
+			var node yaml.Node
+			if err := node.Encode(action); err != nil {
+				return nil, fmt.Errorf("failed to merge action %s: %w", action.Name, err)
+			}
+			resolved = append(resolved, &node)
+		}
+	}
+
+	if !imported {
+		return data, nil
+	}
+
+	actions.Content = resolved
+	out, err := yaml.Marshal(&document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge imported actions: %w", err)
+	}
+	return out, nil
+}
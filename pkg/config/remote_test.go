@@ -0,0 +1,170 @@
+package config_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const actionPack = `
+actions:
+  - name: "ping"
+    type: "command"
+    trigger:
+      command: "ping"
+    response:
+      type: "text"
+      content: "Pong!"
+  - name: "help"
+    type: "command"
+    trigger:
+      command: "help"
+`
+
+// writeConfig writes a configuration importing the pack at url between two local actions
+func writeConfig(t *testing.T, url string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+bot:
+  token: "test-token"
+  prefix: "!"
+actions:
+  - name: "hello"
+    type: "command"
+    trigger:
+      command: "hello"
+  - $url: ` + url + `
+  - name: "bye"
+    type: "command"
+    trigger:
+      command: "bye"
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadProfile_RemoteActions(t *testing.T) {
+	var requests, notModified atomic.Int64
+	var online atomic.Bool
+	online.Store(true)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !online.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(actionPack))
+	}))
+	defer server.Close()
+
+	packs := config.WithActionPacks(config.NewActionPackLoader(t.TempDir()))
+	path := writeConfig(t, server.URL+"/ping.yaml")
+
+	names := func(cfg *config.Config) []string {
+		var names []string
+		for _, action := range cfg.Actions {
+			names = append(names, action.Name)
+		}
+		return names
+	}
+
+	cfg, err := config.Load(path, packs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello", "ping", "help", "bye"}, names(cfg))
+	assert.Equal(t, "Pong!", cfg.Actions[1].Response.Content)
+	assert.Equal(t, int64(0), notModified.Load())
+
+	// The cached pack is reused while its ETag is unchanged
+	cfg, err = config.Load(path, packs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello", "ping", "help", "bye"}, names(cfg))
+	assert.Equal(t, int64(1), notModified.Load())
+
+	// Server errors other than not modified fail the load
+	online.Store(false)
+	_, err = config.Load(path, packs)
+	assert.Error(t, err)
+	assert.Equal(t, int64(3), requests.Load())
+}
+
+func TestLoadProfile_RemoteActionsOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(actionPack))
+	}))
+
+	packs := config.WithActionPacks(config.NewActionPackLoader(t.TempDir()))
+	path := writeConfig(t, server.URL+"/ping.yaml")
+
+	_, err := config.Load(path, packs)
+	require.NoError(t, err)
+
+	// The cached copy is used when the server cannot be reached
+	server.Close()
+	cfg, err := config.Load(path, packs)
+	require.NoError(t, err)
+	assert.Len(t, cfg.Actions, 4)
+}
+
+func TestLoadProfile_RemoteActionsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		pack string
+	}{
+		{name: "unknown field", pack: "actions:\n  - name: ping\n    type: command\n    unknown: true\n"},
+		{name: "missing type", pack: "actions:\n  - name: ping\n"},
+		{name: "invalid YAML", pack: "actions: [\n"},
+		{name: "body file", pack: "actions:\n  - name: leak\n    type: command\n    response:\n      type: http\n      http:\n        url: https://example.com\n        bodyFilePath: /etc/passwd\n"},
+		{name: "client key file", pack: "actions:\n  - name: leak\n    type: command\n    response:\n      type: http\n      http:\n        url: https://example.com\n        tlsClientCert: /etc/ssl/bot.crt\n        tlsClientKey: /etc/ssl/bot.key\n"},
+		{name: "CA file", pack: "actions:\n  - name: leak\n    type: command\n    response:\n      type: http\n      http:\n        url: https://example.com\n        tlsCACert: /etc/ssl/ca.crt\n"},
+		{name: "skip verify", pack: "actions:\n  - name: mitm\n    type: command\n    response:\n      type: http\n      http:\n        url: https://example.com\n        tlsSkipVerify: true\n"},
+		{name: "follow-up body file", pack: "actions:\n  - name: leak\n    type: command\n    response:\n      type: http\n      http:\n        url: https://example.com\n        followUp:\n          type: http\n          http:\n            url: https://example.com\n            bodyFilePath: /etc/passwd\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.pack))
+			}))
+			defer server.Close()
+
+			packs := config.WithActionPacks(config.NewActionPackLoader(t.TempDir()))
+			_, err := config.Load(writeConfig(t, server.URL), packs)
+			assert.ErrorContains(t, err, "invalid action pack")
+		})
+	}
+}
+
+func TestLoadProfile_RemoteActionsDisabled(t *testing.T) {
+	_, err := config.Load(writeConfig(t, "https://example.com/actions/ping.yaml"), config.WithoutRemoteActions())
+	assert.ErrorContains(t, err, "remote actions are disabled")
+
+	_, err = config.Load(writeConfig(t, "file:///etc/passwd"), config.WithActionPacks(config.NewActionPackLoader(t.TempDir())))
+	assert.ErrorContains(t, err, "must be an http or https URL")
+}
+
+func TestLoadProfile_RemoteActionsInlinePEM(t *testing.T) {
+	pack := "actions:\n  - name: mtls\n    type: command\n    response:\n      type: http\n      http:\n        url: https://example.com\n        tlsCACert: |\n          -----BEGIN CERTIFICATE-----\n          MIIB\n          -----END CERTIFICATE-----\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(pack))
+	}))
+	defer server.Close()
+
+	cfg, err := config.Load(writeConfig(t, server.URL), config.WithActionPacks(config.NewActionPackLoader(t.TempDir())))
+	require.NoError(t, err)
+	assert.Contains(t, cfg.Actions[1].Response.HTTP.TLSCACert, "-----BEGIN CERTIFICATE-----")
+}
@@ -0,0 +1,233 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// configSource is parsed to document the configuration fields with their comments
+//
+//go:embed config.go
+var configSource string
+
+// SchemaField describes a configuration field
+type SchemaField struct {
+	Path        string
+	Type        string
+	Required    bool
+	Default     string
+	Description string
+}
+
+// ActionType describes an action type and the fields it uses
+type ActionType struct {
+	Name           string
+	Description    string
+	TriggerFields  []string
+	ResponseFields []string
+}
+
+// responseFields are the response fields shared by the action types sending a response
+var responseFields = []string{"type", "content", "embed", "reaction", "stickerId", "sourceChannelId", "sourceMessageId", "targetGuildId", "targetChannelId", "http", "webhookUrl", "username", "avatarUrl", "maxRetries"}
+
+// ActionTypes lists the built-in action types
+var ActionTypes = []ActionType{
+	{Name: "command", Description: "Runs when a message starts with the prefix and command", TriggerFields: []string{"command", "argSpecs", "threadOnly", "noThreads"}, ResponseFields: responseFields},
+	{Name: "message", Description: "Runs when a message matches a regular expression", TriggerFields: []string{"pattern", "threadOnly", "noThreads"}, ResponseFields: responseFields},
+	{Name: "reaction", Description: "Runs when a reaction with the emoji is added", TriggerFields: []string{"emoji"}, ResponseFields: responseFields},
+	{Name: "reaction_role", Description: "Grants a role when a reaction with the emoji is added and removes it when the reaction is removed, unless onRemove is keep", TriggerFields: []string{"emoji", "role", "messageId", "onRemove"}},
+	{Name: "scheduled", Description: "Runs on a cron schedule with seconds", TriggerFields: []string{"schedule", "timezone"}, ResponseFields: responseFields},
+	{Name: "setpref", Description: "Sets a preference of the user, e.g. !setpref language fr", TriggerFields: []string{"command"}},
+	{Name: "getpref", Description: "Shows a preference of the user, e.g. !getpref language", TriggerFields: []string{"command"}},
+	{Name: "reminder", Description: "Sends a reminder by DM after a delay, e.g. !remindme 2h do laundry, listed and cancelled with !reminders", TriggerFields: []string{"command"}},
+	{Name: "vote_tally", Description: "Counts the reactions of a message as votes and posts a bar chart, e.g. !tally 123456789", TriggerFields: []string{"command", "maxEmojis"}},
+	{Name: "status_cycle", Description: "Rotates the bot status on a cron schedule", TriggerFields: []string{"schedule", "timezone", "statuses"}},
+}
+
+// schemaDefaults are the values applied when an optional field is unset
+var schemaDefaults = map[string]string{
+	"bot.gatewayCompression":              "true",
+	"bot.largeThreshold":                  fmt.Sprint(DefaultLargeThreshold),
+	"bot.workers.poolSize":                "10",
+	"bot.workers.queueCapacity":           "100",
+	"bot.scheduler.batchSize":             "5",
+	"logging.level":                       "info",
+	"logging.format":                      "json",
+	"logging.sampleRate":                  "1",
+	"actions[].enabled":                   "true",
+	"actions[].response.http.method":      "GET",
+	"actions[].response.http.retryOn":     "429, 500, 502, 503, 504",
+	"actions[].response.http.parseFormat": "json",
+}
+
+// Schema lists the configuration fields in file order
+func Schema() ([]SchemaField, error) {
+	docs, err := fieldDocs()
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []SchemaField
+	walkSchema(reflect.TypeOf(Config{}), "", docs, map[reflect.Type]bool{}, &fields)
+	return fields, nil
+}
+
+// WriteSchemaDocs writes the configuration reference as Markdown
+func WriteSchemaDocs(w io.Writer) error {
+	fields, err := Schema()
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("# Configuration Reference\n\n")
+	b.WriteString("| Field | Type | Required | Default | Description |\n")
+	b.WriteString("|-------|------|----------|---------|-------------|\n")
+	for _, field := range fields {
+		required := "no"
+		if field.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", field.Path, field.Type, required, markdownCode(field.Default), escapeTableCell(field.Description))
+	}
+
+	b.WriteString("\n## Action Types\n\n")
+	b.WriteString("| Type | Description | Trigger fields | Response fields |\n")
+	b.WriteString("|------|-------------|----------------|-----------------|\n")
+	for _, actionType := range ActionTypes {
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", actionType.Name, actionType.Description, markdownList(actionType.TriggerFields), markdownList(actionType.ResponseFields))
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// walkSchema appends the fields of a struct type, descending into nested sections
+func walkSchema(t reflect.Type, prefix string, docs map[string]string, visiting map[reflect.Type]bool, fields *[]SchemaField) {
+	// Recursive types such as http follow-up responses are documented once
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		name, options, _ := strings.Cut(structField.Tag.Get("yaml"), ",")
+		if name == "-" || !structField.IsExported() {
+			continue
+		}
+
+		path := prefix + name
+		fieldType := structField.Type
+		*fields = append(*fields, SchemaField{
+			Path:        path,
+			Type:        typeName(fieldType),
+			Required:    !strings.Contains(options, "omitempty") && fieldType.Kind() != reflect.Pointer,
+			Default:     schemaDefaults[path],
+			Description: docs[t.Name()+"."+structField.Name],
+		})
+
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		switch {
+		case fieldType.Kind() == reflect.Struct:
+			walkSchema(fieldType, path+".", docs, visiting, fields)
+		case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Struct:
+			walkSchema(fieldType.Elem(), path+"[].", docs, visiting, fields)
+		}
+	}
+}
+
+// typeName returns the YAML type of a field
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeName(t.Elem())
+	case reflect.Struct:
+		return "object"
+	case reflect.Slice:
+		return "list of " + typeName(t.Elem())
+	case reflect.Map:
+		return "map of " + typeName(t.Elem())
+	default:
+		return t.Kind().String()
+	}
+}
+
+// fieldDocs returns the doc comments of the config struct fields keyed by "Type.Field".
+// A comment documents the fields directly following it, as in "A and B are ...".
+func fieldDocs() (map[string]string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "config.go", configSource, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config source: %w", err)
+	}
+
+	docs := make(map[string]string)
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		structType, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+
+		var doc string
+		var lastLine int
+		for _, field := range structType.Fields.List {
+			line := fset.Position(field.Pos()).Line
+			switch {
+			case field.Doc != nil:
+				doc = strings.Join(strings.Fields(field.Doc.Text()), " ")
+			case line != lastLine+1:
+				doc = ""
+			}
+			lastLine = fset.Position(field.End()).Line
+
+			for _, name := range field.Names {
+				if doc != "" {
+					docs[spec.Name.Name+"."+name.Name] = doc
+				}
+			}
+		}
+		return false
+	})
+
+	return docs, nil
+}
+
+// markdownList formats field names as inline code
+func markdownList(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+
+	codes := make([]string, len(names))
+	for i, name := range names {
+		codes[i] = "`" + name + "`"
+	}
+	return strings.Join(codes, ", ")
+}
+
+// markdownCode formats a value as inline code, or a dash when empty
+func markdownCode(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return "`" + value + "`"
+}
+
+// escapeTableCell escapes the characters breaking a Markdown table cell
+func escapeTableCell(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
@@ -0,0 +1,47 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema(t *testing.T) {
+	fields, err := config.Schema()
+	require.NoError(t, err)
+
+	byPath := make(map[string]config.SchemaField)
+	for _, field := range fields {
+		byPath[field.Path] = field
+	}
+
+	assert.Equal(t, config.SchemaField{Path: "bot.prefix", Type: "string", Required: true}, byPath["bot.prefix"])
+	assert.Equal(t, config.SchemaField{
+		Path:        "bot.largeThreshold",
+		Type:        "int",
+		Default:     "250",
+		Description: "LargeThreshold is the member count above which guilds are sent without offline members",
+	}, byPath["bot.largeThreshold"])
+
+	// A comment documents the fields grouped below it
+	assert.Equal(t, byPath["actions[].deadLetterChannel"].Description, byPath["actions[].deadLetterWebhook"].Description)
+	assert.NotEmpty(t, byPath["actions[].deadLetterWebhook"].Description)
+
+	assert.Equal(t, "list of object", byPath["actions[].response.embed.fields"].Type)
+	assert.True(t, byPath["actions[].response.embed.fields[].name"].Required)
+	assert.NotContains(t, byPath, "actions[].response.embed.colorName")
+	assert.NotContains(t, byPath, "actions[].response.http.followUp.http.url")
+}
+
+func TestWriteSchemaDocs(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, config.WriteSchemaDocs(&out))
+
+	docs := out.String()
+	assert.Contains(t, docs, "| `bot.prefix` | string | yes | - |  |\n")
+	assert.Contains(t, docs, "| `bot.gatewayCompression` | bool | no | `true` |")
+	assert.Contains(t, docs, "| `status_cycle` | Rotates the bot status on a cron schedule | `schedule`, `timezone`, `statuses` | - |\n")
+}
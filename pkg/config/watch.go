@@ -0,0 +1,76 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce groups the events of a single save, editors often write
+// a temporary file and rename it over the original
+const watchDebounce = 200 * time.Millisecond
+
+// Watch reloads the configuration file and its profile overlay when they change
+// until ctx is cancelled. Configurations that load and validate are passed to
+// onChange, failures are passed to onError. opts apply to every load.
+func Watch(ctx context.Context, path, profile string, onChange func(*Config), onError func(error), opts ...LoadOption) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+
+	// Watch the directory, renames replace the file and drop a watch on it
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to watch config directory: %w", err)
+	}
+
+	watched := map[string]bool{filepath.Clean(path): true}
+	if profile != "" {
+		watched[filepath.Clean(ProfilePath(path, profile))] = true
+	}
+
+	go func() {
+		defer func() {
+			_ = watcher.Close()
+		}()
+
+		debounce := time.NewTimer(watchDebounce)
+		debounce.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				debounce.Stop()
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if watched[filepath.Clean(event.Name)] && event.Has(fsnotify.Write|fsnotify.Create) {
+					debounce.Reset(watchDebounce)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				onError(fmt.Errorf("config watcher failed: %w", err))
+			case <-debounce.C:
+				cfg, err := LoadRuntime(path, profile, opts...)
+				if err == nil {
+					err = cfg.Validate()
+				}
+				if err != nil {
+					onError(fmt.Errorf("ignoring config change: %w", err))
+					continue
+				}
+				onChange(cfg)
+			}
+		}
+	}()
+
+	return nil
+}
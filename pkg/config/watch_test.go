@@ -0,0 +1,56 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("bot:\n  token: \"t\"\n  prefix: \"!\"\n"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan *config.Config, 1)
+	errs := make(chan error, 1)
+	err := config.Watch(ctx, configPath, "", func(cfg *config.Config) {
+		changes <- cfg
+	}, func(err error) {
+		errs <- err
+	})
+	require.NoError(t, err)
+
+	// A valid change is reported once all writes of the save settle
+	require.NoError(t, os.WriteFile(configPath, []byte("bot:\n  token: \"t\"\n"), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte("bot:\n  token: \"t\"\n  prefix: \"?\"\n"), 0644))
+
+	select {
+	case cfg := <-changes:
+		assert.Equal(t, "?", cfg.Bot.Prefix)
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("config change not reported")
+	}
+
+	// An invalid change is reported as an error
+	require.NoError(t, os.WriteFile(configPath, []byte("bot:\n  token: \"t\"\n"), 0644))
+
+	select {
+	case <-changes:
+		t.Fatal("invalid config reported as a change")
+	case err := <-errs:
+		assert.Contains(t, err.Error(), "prefix")
+	case <-time.After(2 * time.Second):
+		t.Fatal("config error not reported")
+	}
+}
@@ -0,0 +1,123 @@
+// Package coordination provides locking and shared counters between multiple bot instances.
+package coordination
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix namespaces the lock keys stored in Redis
+const keyPrefix = "gxf-discord-bot:lock:"
+
+// counterKeyPrefix namespaces the counter keys stored in Redis
+const counterKeyPrefix = "gxf-discord-bot:count:"
+
+// DistributedLock is a lock shared between bot instances
+type DistributedLock interface {
+	// Acquire takes the lock for key until it is released or ttl elapses.
+	// It returns a release function, or nil when the lock is held elsewhere.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (func(context.Context) error, error)
+}
+
+// unlockScript deletes the lock only if it is still owned by the caller
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisLock is a DistributedLock backed by Redis
+type RedisLock struct {
+	client *redis.Client
+}
+
+// NewRedisLock creates a lock using the Redis server at address
+func NewRedisLock(address string) *RedisLock {
+	return &RedisLock{
+		client: redis.NewClient(&redis.Options{Addr: address}),
+	}
+}
+
+// Acquire takes the lock with SET NX PX and releases it with a compare-and-delete script
+func (l *RedisLock) Acquire(ctx context.Context, key string, ttl time.Duration) (func(context.Context) error, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := l.client.SetNX(ctx, keyPrefix+key, token, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	release := func(ctx context.Context) error {
+		if err := unlockScript.Run(ctx, l.client, []string{keyPrefix + key}, token).Err(); err != nil {
+			return fmt.Errorf("failed to release lock %s: %w", key, err)
+		}
+		return nil
+	}
+
+	return release, nil
+}
+
+// Close closes the connection to Redis
+func (l *RedisLock) Close() error {
+	return l.client.Close()
+}
+
+// incrementScript counts a request in the window of a key, starting the window on the first request
+var incrementScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+// RedisCounter counts requests in fixed windows shared between bot instances
+type RedisCounter struct {
+	client *redis.Client
+}
+
+// NewRedisCounter creates a counter using the Redis server at address
+func NewRedisCounter(address string) *RedisCounter {
+	return &RedisCounter{
+		client: redis.NewClient(&redis.Options{Addr: address}),
+	}
+}
+
+// Increment counts a request of key and returns the requests counted in the current window
+// and the time until the window ends
+func (c *RedisCounter) Increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	result, err := incrementScript.Run(ctx, c.client, []string{counterKeyPrefix + key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count %s: %w", key, err)
+	}
+	if len(result) != 2 {
+		return 0, 0, fmt.Errorf("failed to count %s: unexpected reply %v", key, result)
+	}
+	return result[0], time.Duration(max(result[1], 0)) * time.Millisecond, nil
+}
+
+// Close closes the connection to Redis
+func (c *RedisCounter) Close() error {
+	return c.client.Close()
+}
+
+// newToken returns a random value identifying the lock owner
+func newToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,90 @@
+package coordination_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/geekxflood/gxf-discord-bot/pkg/coordination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisLock_Acquire(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx := context.Background()
+
+	lock := coordination.NewRedisLock(server.Addr())
+	defer func() {
+		_ = lock.Close()
+	}()
+
+	release, err := lock.Acquire(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, release)
+
+	// A second instance cannot take the lock while it is held
+	other, err := lock.Acquire(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, other)
+
+	require.NoError(t, release(ctx))
+
+	again, err := lock.Acquire(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	assert.NotNil(t, again)
+}
+
+func TestRedisLock_ReleaseAfterExpiry(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx := context.Background()
+
+	lock := coordination.NewRedisLock(server.Addr())
+	defer func() {
+		_ = lock.Close()
+	}()
+
+	release, err := lock.Acquire(ctx, "job", time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, release)
+
+	// Once expired the lock is taken by another instance, which must keep it
+	server.FastForward(2 * time.Second)
+	other, err := lock.Acquire(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, other)
+
+	require.NoError(t, release(ctx))
+	assert.True(t, server.Exists("gxf-discord-bot:lock:job"))
+}
+
+func TestRedisCounter_Increment(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx := context.Background()
+
+	counter := coordination.NewRedisCounter(server.Addr())
+	defer func() {
+		_ = counter.Close()
+	}()
+
+	count, reset, err := counter.Increment(ctx, "ping/user1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, time.Minute, reset)
+
+	// Another instance counts in the same window
+	other := coordination.NewRedisCounter(server.Addr())
+	defer func() {
+		_ = other.Close()
+	}()
+	count, _, err = other.Increment(ctx, "ping/user1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// A new window starts once the previous one expires
+	server.FastForward(time.Minute)
+	count, _, err = counter.Increment(ctx, "ping/user1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
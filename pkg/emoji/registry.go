@@ -0,0 +1,61 @@
+// Package emoji resolves custom guild emojis by name.
+package emoji
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Session defines the Discord session methods used to fetch emojis
+type Session interface {
+	GuildEmojis(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Emoji, error)
+}
+
+// Registry holds the custom emojis of each guild by name
+type Registry struct {
+	// guilds maps a guild ID to a map[string]*discordgo.Emoji of its emojis by name
+	guilds sync.Map
+}
+
+// NewRegistry creates an empty emoji registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Load fetches the emojis of a guild, replacing the ones already known
+func (r *Registry) Load(session Session, guildID string) error {
+	emojis, err := session.GuildEmojis(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch emojis of guild %s: %w", guildID, err)
+	}
+
+	r.Set(guildID, emojis)
+	return nil
+}
+
+// Set replaces the emojis of a guild
+func (r *Registry) Set(guildID string, emojis []*discordgo.Emoji) {
+	byName := make(map[string]*discordgo.Emoji, len(emojis))
+	for _, emoji := range emojis {
+		if emoji != nil && emoji.Name != "" {
+			byName[emoji.Name] = emoji
+		}
+	}
+	r.guilds.Store(guildID, byName)
+}
+
+// Lookup returns the message format of a guild emoji, such as <:name:id>
+func (r *Registry) Lookup(guildID, name string) (string, bool) {
+	value, ok := r.guilds.Load(guildID)
+	if !ok {
+		return "", false
+	}
+
+	emoji, ok := value.(map[string]*discordgo.Emoji)[name]
+	if !ok {
+		return "", false
+	}
+	return emoji.MessageFormat(), true
+}
@@ -0,0 +1,61 @@
+package emoji_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/emoji"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSession returns fixed emojis per guild
+type fakeSession map[string][]*discordgo.Emoji
+
+func (s fakeSession) GuildEmojis(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Emoji, error) {
+	emojis, ok := s[guildID]
+	if !ok {
+		return nil, errors.New("unknown guild")
+	}
+	return emojis, nil
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	session := fakeSession{
+		"guild1": {
+			{ID: "111", Name: "party"},
+			{ID: "222", Name: "dance", Animated: true},
+		},
+		"guild2": {
+			{ID: "333", Name: "party"},
+		},
+	}
+
+	registry := emoji.NewRegistry()
+	require.NoError(t, registry.Load(session, "guild1"))
+	require.NoError(t, registry.Load(session, "guild2"))
+	assert.Error(t, registry.Load(session, "guild3"))
+
+	value, ok := registry.Lookup("guild1", "party")
+	assert.True(t, ok)
+	assert.Equal(t, "<:party:111>", value)
+
+	value, ok = registry.Lookup("guild1", "dance")
+	assert.True(t, ok)
+	assert.Equal(t, "<a:dance:222>", value)
+
+	value, ok = registry.Lookup("guild2", "party")
+	assert.True(t, ok)
+	assert.Equal(t, "<:party:333>", value)
+
+	_, ok = registry.Lookup("guild2", "dance")
+	assert.False(t, ok)
+	_, ok = registry.Lookup("guild3", "party")
+	assert.False(t, ok)
+
+	// Reloading replaces the known emojis
+	registry.Set("guild1", []*discordgo.Emoji{{ID: "444", Name: "wave"}})
+	_, ok = registry.Lookup("guild1", "party")
+	assert.False(t, ok)
+}
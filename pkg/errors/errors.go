@@ -0,0 +1,103 @@
+// Package errors defines the typed errors returned by the bot, so callers can
+// handle them with errors.As instead of matching messages.
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ActionNotFoundError is returned when no action has the requested name
+type ActionNotFoundError struct {
+	Name string
+}
+
+// Error returns the error message
+func (e ActionNotFoundError) Error() string {
+	return "action not found: " + e.Name
+}
+
+// RateLimitedError is returned when an action exceeds its rate limit
+type RateLimitedError struct {
+	Action     string
+	RetryAfter time.Duration
+}
+
+// Error returns the error message
+func (e RateLimitedError) Error() string {
+	return fmt.Sprintf("action %s rate limited, retry after %s", e.Action, e.RetryAfter.Round(time.Second))
+}
+
+// AuthRequired is returned when the user must authenticate at AuthURL first
+type AuthRequired struct {
+	AuthURL string
+}
+
+// Error returns the error message
+func (e AuthRequired) Error() string {
+	return "authentication required: " + e.AuthURL
+}
+
+// ValidationError is returned when a field of a request or configuration is invalid
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+// Error returns the error message
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// BlockedHostError is returned when an http request targets a host denied by the http policy
+type BlockedHostError struct {
+	Host   string
+	Reason string
+}
+
+// Error returns the error message
+func (e BlockedHostError) Error() string {
+	return fmt.Sprintf("http request to %s blocked: %s", e.Host, e.Reason)
+}
+
+// DiscordAPIError is returned when the Discord API rejects a request
+type DiscordAPIError struct {
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+// Error returns the error message
+func (e DiscordAPIError) Error() string {
+	if e.StatusCode == 0 {
+		return "discord API error: " + e.Message
+	}
+	return fmt.Sprintf("discord API error %d: %s", e.StatusCode, e.Message)
+}
+
+// Unwrap returns the underlying error
+func (e DiscordAPIError) Unwrap() error {
+	return e.Err
+}
+
+// FromDiscord converts a REST API error of a discordgo request to a DiscordAPIError.
+// Other errors, such as network failures, are returned unchanged.
+func FromDiscord(err error) error {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) {
+		return err
+	}
+
+	apiErr := DiscordAPIError{Message: err.Error(), Err: err}
+	if restErr.Response != nil {
+		apiErr.StatusCode = restErr.Response.StatusCode
+	}
+	if restErr.Message != nil && restErr.Message.Message != "" {
+		apiErr.Message = restErr.Message.Message
+	}
+
+	return apiErr
+}
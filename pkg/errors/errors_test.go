@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorsAs(t *testing.T) {
+	err := fmt.Errorf("failed to execute response: %w", boterrors.RateLimitedError{Action: "ping", RetryAfter: 1500 * time.Millisecond})
+
+	var limited boterrors.RateLimitedError
+	require.ErrorAs(t, err, &limited)
+	assert.Equal(t, 1500*time.Millisecond, limited.RetryAfter)
+	assert.EqualError(t, limited, "action ping rate limited, retry after 2s")
+
+	assert.False(t, errors.As(err, &boterrors.ValidationError{}))
+	assert.EqualError(t, boterrors.ValidationError{Field: "response.type", Reason: "unsupported"}, "invalid response.type: unsupported")
+	assert.EqualError(t, boterrors.ActionNotFoundError{Name: "ping"}, "action not found: ping")
+	assert.EqualError(t, boterrors.AuthRequired{AuthURL: "https://example.com/auth"}, "authentication required: https://example.com/auth")
+	assert.EqualError(t, boterrors.BlockedHostError{Host: "169.254.169.254", Reason: "private address"}, "http request to 169.254.169.254 blocked: private address")
+}
+
+func TestFromDiscord(t *testing.T) {
+	assert.NoError(t, boterrors.FromDiscord(nil))
+
+	restErr := &discordgo.RESTError{
+		Response: &http.Response{StatusCode: http.StatusForbidden},
+		Message:  &discordgo.APIErrorMessage{Code: 50013, Message: "Missing Permissions"},
+	}
+	err := fmt.Errorf("failed to send text message: %w", boterrors.FromDiscord(restErr))
+
+	var apiErr boterrors.DiscordAPIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, "Missing Permissions", apiErr.Message)
+	assert.ErrorIs(t, err, restErr)
+	assert.EqualError(t, apiErr, "discord API error 403: Missing Permissions")
+
+	// Errors not coming from the REST API are not Discord API errors
+	networkErr := errors.New("connection reset")
+	assert.Same(t, networkErr, boterrors.FromDiscord(networkErr))
+	assert.NotErrorAs(t, boterrors.FromDiscord(networkErr), &apiErr)
+}
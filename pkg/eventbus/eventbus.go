@@ -0,0 +1,94 @@
+// Package eventbus provides publish/subscribe messaging between bot components.
+package eventbus
+
+import (
+	"slices"
+	"sync"
+)
+
+// Topics published by the bot
+const (
+	TopicReady          = "bot.ready"
+	TopicActionExecuted = "action.executed"
+	TopicMemberJoined   = "member.joined"
+	TopicMemberLeft     = "member.left"
+)
+
+// subscriberBuffer is the number of payloads queued for each subscriber
+const subscriberBuffer = 16
+
+// Bus delivers published payloads to the subscribers of a topic
+type Bus struct {
+	subscribers map[string][]chan interface{}
+	closed      bool
+	mu          sync.RWMutex
+}
+
+// New creates a new event bus
+func New() *Bus {
+	return &Bus{
+		subscribers: make(map[string][]chan interface{}),
+	}
+}
+
+// Publish sends a payload to the subscribers of a topic.
+// It never blocks, subscribers with a full buffer miss the payload.
+func (b *Bus) Publish(topic string, payload interface{}) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, ch := range b.subscribers[topic] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the payloads published on a topic.
+// The channel is closed by Unsubscribe or when the bus is closed.
+func (b *Bus) Subscribe(topic string) <-chan interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan interface{}, subscriberBuffer)
+	if b.closed {
+		close(ch)
+		return ch
+	}
+
+	b.subscribers[topic] = append(b.subscribers[topic], ch)
+	return ch
+}
+
+// Unsubscribe removes a subscription and closes its channel
+func (b *Bus) Unsubscribe(topic string, sub <-chan interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers[topic] = slices.DeleteFunc(b.subscribers[topic], func(ch chan interface{}) bool {
+		if ch == sub {
+			close(ch)
+			return true
+		}
+		return false
+	})
+}
+
+// Close closes every subscription, later subscriptions are closed immediately
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+
+	for topic, subs := range b.subscribers {
+		for _, ch := range subs {
+			close(ch)
+		}
+		delete(b.subscribers, topic)
+	}
+}
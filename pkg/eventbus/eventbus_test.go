@@ -0,0 +1,59 @@
+package eventbus_test
+
+import (
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/eventbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_PublishSubscribe(t *testing.T) {
+	bus := eventbus.New()
+
+	first := bus.Subscribe("topic")
+	second := bus.Subscribe("topic")
+	other := bus.Subscribe("other")
+
+	bus.Publish("topic", "payload")
+
+	assert.Equal(t, "payload", <-first)
+	assert.Equal(t, "payload", <-second)
+	assert.Empty(t, other)
+}
+
+func TestBus_PublishDoesNotBlock(t *testing.T) {
+	bus := eventbus.New()
+	sub := bus.Subscribe("topic")
+
+	// Payloads beyond the subscriber buffer are dropped
+	for i := 0; i < 100; i++ {
+		bus.Publish("topic", i)
+	}
+
+	assert.Equal(t, 0, <-sub)
+	assert.Less(t, len(sub), 100)
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	bus := eventbus.New()
+	sub := bus.Subscribe("topic")
+
+	bus.Unsubscribe("topic", sub)
+	bus.Publish("topic", "payload")
+
+	_, ok := <-sub
+	assert.False(t, ok)
+}
+
+func TestBus_Close(t *testing.T) {
+	bus := eventbus.New()
+	sub := bus.Subscribe("topic")
+
+	bus.Close()
+
+	_, ok := <-sub
+	assert.False(t, ok)
+
+	_, ok = <-bus.Subscribe("topic")
+	assert.False(t, ok)
+}
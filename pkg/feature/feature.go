@@ -0,0 +1,58 @@
+// Package feature provides flags for opting in to experimental functionality.
+package feature
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// Sharding runs the bot with several gateway shards
+const Sharding = "sharding"
+
+// Flags holds the enabled state of features
+type Flags struct {
+	enabled map[string]bool
+}
+
+// global holds the flags checked by IsEnabled
+var global atomic.Pointer[Flags]
+
+// New creates flags from the configured features, features are disabled unless set
+func New(features map[string]bool) *Flags {
+	enabled := make(map[string]bool, len(features))
+	for name, on := range features {
+		enabled[name] = on
+	}
+	return &Flags{enabled: enabled}
+}
+
+// IsEnabled reports whether a feature is enabled
+func (f *Flags) IsEnabled(name string) bool {
+	return f != nil && f.enabled[name]
+}
+
+// Enabled returns the names of the enabled features in sorted order
+func (f *Flags) Enabled() []string {
+	if f == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(f.enabled))
+	for name, on := range f.enabled {
+		if on {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// SetGlobal sets the flags checked by IsEnabled
+func SetGlobal(f *Flags) {
+	global.Store(f)
+}
+
+// IsEnabled reports whether a feature is enabled in the global flags
+func IsEnabled(name string) bool {
+	return global.Load().IsEnabled(name)
+}
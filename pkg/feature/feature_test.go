@@ -0,0 +1,30 @@
+package feature_test
+
+import (
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/feature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlags(t *testing.T) {
+	flags := feature.New(map[string]bool{
+		"sharding":   true,
+		"pagination": true,
+		"slash":      false,
+	})
+
+	assert.True(t, flags.IsEnabled("sharding"))
+	assert.False(t, flags.IsEnabled("slash"))
+	assert.False(t, flags.IsEnabled("unknown"))
+	assert.Equal(t, []string{"pagination", "sharding"}, flags.Enabled())
+}
+
+func TestGlobal(t *testing.T) {
+	t.Cleanup(func() { feature.SetGlobal(nil) })
+
+	assert.False(t, feature.IsEnabled(feature.Sharding))
+
+	feature.SetGlobal(feature.New(map[string]bool{feature.Sharding: true}))
+	assert.True(t, feature.IsEnabled(feature.Sharding))
+}
@@ -0,0 +1,147 @@
+// Package logs creates the bot logger with a log level per module.
+package logs
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/geekxflood/common/logging"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+)
+
+// Logger drops the messages below its level, and creates module loggers
+// with the level configured for the module
+type Logger struct {
+	logging.Logger
+	level   slog.Level
+	modules map[string]slog.Level
+}
+
+// New creates the logger described by cfg, writing to output.
+// A nil cfg logs at info level as JSON.
+func New(cfg *config.LoggingConfig, output string) (*Logger, io.Closer, error) {
+	if cfg == nil {
+		cfg = &config.LoggingConfig{}
+	}
+
+	level := parseLevel(cfg.Level)
+	modules := make(map[string]slog.Level, len(cfg.Modules))
+	lowest := level
+	for module, moduleLevel := range cfg.Modules {
+		modules[module] = parseLevel(moduleLevel)
+		lowest = min(lowest, modules[module])
+	}
+
+	// The output keeps the messages of the most verbose module, each logger filters its own
+	format := logging.FormatJSON
+	if cfg.Format == "text" {
+		format = logging.FormatLogfmt
+	}
+	base, closer, err := logging.NewLogger(logging.Config{
+		Level:  lowest.String(),
+		Format: format,
+		Output: output,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &Logger{Logger: base, level: level, modules: modules}, closer, nil
+}
+
+// Module returns the logger of a module, tagged with its name
+func (l *Logger) Module(name string) logging.Logger {
+	level, ok := l.modules[name]
+	if !ok {
+		level = l.level
+	}
+	return &Logger{Logger: l.Logger.With("module", name), level: level, modules: l.modules}
+}
+
+// Module returns the logger of a module when logger supports module levels,
+// otherwise logger itself
+func Module(logger logging.Logger, name string) logging.Logger {
+	if modular, ok := logger.(interface {
+		Module(name string) logging.Logger
+	}); ok {
+		return modular.Module(name)
+	}
+	return logger
+}
+
+// Enabled reports whether messages of the level are logged
+func (l *Logger) Enabled(level slog.Level) bool {
+	return level >= slog.LevelError || l.level <= level
+}
+
+// Debug logs a debug message
+func (l *Logger) Debug(msg string, args ...any) {
+	if l.level <= slog.LevelDebug {
+		l.Logger.Debug(msg, args...)
+	}
+}
+
+// Info logs an info message
+func (l *Logger) Info(msg string, args ...any) {
+	if l.level <= slog.LevelInfo {
+		l.Logger.Info(msg, args...)
+	}
+}
+
+// Warn logs a warning message
+func (l *Logger) Warn(msg string, args ...any) {
+	if l.level <= slog.LevelWarn {
+		l.Logger.Warn(msg, args...)
+	}
+}
+
+// Error logs an error message
+func (l *Logger) Error(msg string, args ...any) {
+	l.Logger.Error(msg, args...)
+}
+
+// DebugContext logs a debug message with context
+func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
+	if l.level <= slog.LevelDebug {
+		l.Logger.DebugContext(ctx, msg, args...)
+	}
+}
+
+// InfoContext logs an info message with context
+func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
+	if l.level <= slog.LevelInfo {
+		l.Logger.InfoContext(ctx, msg, args...)
+	}
+}
+
+// WarnContext logs a warning message with context
+func (l *Logger) WarnContext(ctx context.Context, msg string, args ...any) {
+	if l.level <= slog.LevelWarn {
+		l.Logger.WarnContext(ctx, msg, args...)
+	}
+}
+
+// ErrorContext logs an error message with context
+func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	l.Logger.ErrorContext(ctx, msg, args...)
+}
+
+// With returns a logger with additional attributes, keeping the level and module levels
+func (l *Logger) With(args ...any) logging.Logger {
+	return &Logger{Logger: l.Logger.With(args...), level: l.level, modules: l.modules}
+}
+
+// parseLevel converts a configured level, info when unset
+func parseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,57 @@
+package logs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_ModuleLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.log")
+	logger, closer, err := logs.New(&config.LoggingConfig{
+		Level:   "info",
+		Format:  "text",
+		Modules: map[string]string{"scheduler": "debug", "ratelimit": "warn"},
+	}, path)
+	require.NoError(t, err)
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	logger.With("shard", 1).Debug("shard debug")
+
+	scheduler := logger.Module("scheduler")
+	scheduler.Debug("scheduler debug")
+	scheduler.With("job", "ping").Debug("job debug")
+
+	limiter := logs.Module(logger.With("shard", 1), "ratelimit")
+	limiter.Info("ratelimit info")
+	limiter.Warn("ratelimit warn")
+
+	logs.Module(logger, "admin").Debug("admin debug")
+
+	require.NoError(t, closer.Close())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	output := string(data)
+
+	assert.Contains(t, output, `msg="root info"`)
+	assert.Contains(t, output, `msg="scheduler debug" module=scheduler`)
+	assert.Contains(t, output, `msg="job debug" module=scheduler job=ping`)
+	assert.Contains(t, output, `msg="ratelimit warn" shard=1 module=ratelimit`)
+	assert.NotContains(t, output, "root debug")
+	assert.NotContains(t, output, "shard debug")
+	assert.NotContains(t, output, "ratelimit info")
+	assert.NotContains(t, output, "admin debug")
+}
+
+func TestModule_PlainLogger(t *testing.T) {
+	// Loggers without module levels are used as is
+	logger := &testutil.MockLogger{}
+	assert.Same(t, logger, logs.Module(logger, "scheduler"))
+}
@@ -0,0 +1,87 @@
+package logs
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/geekxflood/common/logging"
+)
+
+// SampledLogger passes 1 in rate debug and info messages to the wrapped logger,
+// warnings and errors are always logged. It is meant for high-frequency paths
+// such as per-message logs. Messages below the level of the wrapped logger are
+// dropped before sampling, so they do not take the turn of a logged message.
+type SampledLogger struct {
+	logging.Logger
+	rate    int64
+	counter *sampleCounter
+}
+
+// sampleCounter is shared by a sampled logger and the loggers derived with With
+type sampleCounter struct {
+	seen    atomic.Int64
+	dropped atomic.Int64
+}
+
+// NewSampledLogger creates a logger keeping 1 in rate messages, a rate of 0 or 1 keeps them all
+func NewSampledLogger(logger logging.Logger, rate int) *SampledLogger {
+	return &SampledLogger{
+		Logger:  logger,
+		rate:    int64(max(rate, 1)),
+		counter: &sampleCounter{},
+	}
+}
+
+// DroppedCount returns the number of messages dropped so far
+func (l *SampledLogger) DroppedCount() int64 {
+	return l.counter.dropped.Load()
+}
+
+// Debug logs a sampled debug message
+func (l *SampledLogger) Debug(msg string, args ...any) {
+	if l.sample(slog.LevelDebug) {
+		l.Logger.Debug(msg, args...)
+	}
+}
+
+// Info logs a sampled info message
+func (l *SampledLogger) Info(msg string, args ...any) {
+	if l.sample(slog.LevelInfo) {
+		l.Logger.Info(msg, args...)
+	}
+}
+
+// DebugContext logs a sampled debug message with context
+func (l *SampledLogger) DebugContext(ctx context.Context, msg string, args ...any) {
+	if l.sample(slog.LevelDebug) {
+		l.Logger.DebugContext(ctx, msg, args...)
+	}
+}
+
+// InfoContext logs a sampled info message with context
+func (l *SampledLogger) InfoContext(ctx context.Context, msg string, args ...any) {
+	if l.sample(slog.LevelInfo) {
+		l.Logger.InfoContext(ctx, msg, args...)
+	}
+}
+
+// With returns a sampled logger with additional attributes, sharing the sampling counters
+func (l *SampledLogger) With(args ...any) logging.Logger {
+	return &SampledLogger{Logger: l.Logger.With(args...), rate: l.rate, counter: l.counter}
+}
+
+// sample reports whether the next message of the level is kept, counting the dropped ones
+func (l *SampledLogger) sample(level slog.Level) bool {
+	if leveled, ok := l.Logger.(interface{ Enabled(slog.Level) bool }); ok && !leveled.Enabled(level) {
+		return false
+	}
+	if l.rate == 1 {
+		return true
+	}
+	if (l.counter.seen.Add(1)-1)%l.rate == 0 {
+		return true
+	}
+	l.counter.dropped.Add(1)
+	return false
+}
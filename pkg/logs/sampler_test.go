@@ -0,0 +1,78 @@
+package logs_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampledLogger(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	sampled := logs.NewSampledLogger(logger, 10)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				sampled.Debug("Action matched")
+			}
+		}()
+	}
+	wg.Wait()
+
+	for range 3 {
+		sampled.Error("Action failed")
+	}
+
+	assert.Len(t, logger.DebugMessages, 10)
+	assert.Len(t, logger.ErrorMessages, 3)
+	assert.Equal(t, int64(90), sampled.DroppedCount())
+}
+
+func TestSampledLogger_NoSampling(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	sampled := logs.NewSampledLogger(logger, 0)
+	for range 5 {
+		sampled.Info("Action matched")
+	}
+
+	assert.Len(t, logger.InfoMessages, 5)
+	assert.Zero(t, sampled.DroppedCount())
+}
+
+func TestSampledLogger_FiltersLevelFirst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.log")
+	logger, closer, err := logs.New(&config.LoggingConfig{Level: "info", Format: "text"}, path)
+	require.NoError(t, err)
+
+	// Debug messages below the level do not take the turn of the info messages
+	sampled := logs.NewSampledLogger(logger, 2)
+	for range 4 {
+		sampled.Debug("Action checked")
+		sampled.Info("Action matched")
+	}
+
+	require.NoError(t, closer.Close())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, strings.Count(string(data), "Action matched"))
+	assert.NotContains(t, string(data), "Action checked")
+	assert.Equal(t, int64(2), sampled.DroppedCount())
+}
@@ -0,0 +1,57 @@
+// Package mentions parses and formats Discord user, role and channel mentions.
+package mentions
+
+import "regexp"
+
+// Mention patterns capturing a snowflake ID of 17 to 20 digits,
+// user mentions may carry the legacy nickname marker <@!id>
+var (
+	userPattern    = regexp.MustCompile(`<@!?([0-9]{17,20})>`)
+	rolePattern    = regexp.MustCompile(`<@&([0-9]{17,20})>`)
+	channelPattern = regexp.MustCompile(`<#([0-9]{17,20})>`)
+)
+
+// ParseUsers returns the IDs of the users mentioned in content, in order and without duplicates
+func ParseUsers(content string) []string {
+	return parse(userPattern, content)
+}
+
+// ParseRoles returns the IDs of the roles mentioned in content, in order and without duplicates
+func ParseRoles(content string) []string {
+	return parse(rolePattern, content)
+}
+
+// ParseChannels returns the IDs of the channels mentioned in content, in order and without duplicates
+func ParseChannels(content string) []string {
+	return parse(channelPattern, content)
+}
+
+// MentionUser returns the mention of a user
+func MentionUser(id string) string {
+	return "<@" + id + ">"
+}
+
+// MentionRole returns the mention of a role
+func MentionRole(id string) string {
+	return "<@&" + id + ">"
+}
+
+// MentionChannel returns the mention of a channel
+func MentionChannel(id string) string {
+	return "<#" + id + ">"
+}
+
+// parse returns the unique IDs captured by pattern in content
+func parse(pattern *regexp.Regexp, content string) []string {
+	matches := pattern.FindAllStringSubmatch(content, -1)
+
+	ids := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			ids = append(ids, match[1])
+		}
+	}
+	return ids
+}
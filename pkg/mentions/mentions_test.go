@@ -0,0 +1,93 @@
+package mentions_test
+
+import (
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/mentions"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userID    = "123456789012345678"
+	roleID    = "223456789012345678"
+	channelID = "323456789012345678"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		users    []string
+		roles    []string
+		channels []string
+	}{
+		{
+			name:     "no mentions",
+			content:  "hello world",
+			users:    []string{},
+			roles:    []string{},
+			channels: []string{},
+		},
+		{
+			name:     "mixed mentions",
+			content:  "<@" + userID + "> ask <@&" + roleID + "> in <#" + channelID + ">",
+			users:    []string{userID},
+			roles:    []string{roleID},
+			channels: []string{channelID},
+		},
+		{
+			name:     "nickname mention",
+			content:  "hi <@!" + userID + ">",
+			users:    []string{userID},
+			roles:    []string{},
+			channels: []string{},
+		},
+		{
+			name:     "duplicates are removed in order",
+			content:  "<@" + roleID + "> <@" + userID + "> <@!" + roleID + ">",
+			users:    []string{roleID, userID},
+			roles:    []string{},
+			channels: []string{},
+		},
+		{
+			name:     "role mention is not a user mention",
+			content:  "<@&" + roleID + ">",
+			users:    []string{},
+			roles:    []string{roleID},
+			channels: []string{},
+		},
+		{
+			name:     "invalid snowflakes",
+			content:  "<@123> <@&abc> <#12345678901234567890123> <@ " + userID + "> <#" + channelID,
+			users:    []string{},
+			roles:    []string{},
+			channels: []string{},
+		},
+		{
+			name:     "adjacent mentions",
+			content:  "<#" + channelID + "><#" + userID + ">",
+			users:    []string{},
+			roles:    []string{},
+			channels: []string{channelID, userID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.users, mentions.ParseUsers(tt.content))
+			assert.Equal(t, tt.roles, mentions.ParseRoles(tt.content))
+			assert.Equal(t, tt.channels, mentions.ParseChannels(tt.content))
+		})
+	}
+}
+
+func TestMention(t *testing.T) {
+	assert.Equal(t, "<@"+userID+">", mentions.MentionUser(userID))
+	assert.Equal(t, "<@&"+roleID+">", mentions.MentionRole(roleID))
+	assert.Equal(t, "<#"+channelID+">", mentions.MentionChannel(channelID))
+
+	// Formatted mentions parse back to their ID
+	assert.Equal(t, []string{userID}, mentions.ParseUsers(mentions.MentionUser(userID)))
+	assert.Equal(t, []string{roleID}, mentions.ParseRoles(mentions.MentionRole(roleID)))
+	assert.Equal(t, []string{channelID}, mentions.ParseChannels(mentions.MentionChannel(channelID)))
+}
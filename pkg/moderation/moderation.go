@@ -0,0 +1,111 @@
+// Package moderation provides content filtering for incoming Discord messages.
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/common/logging"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// Moderation actions applied to blocked messages
+const (
+	ActionIgnore = "ignore"
+	ActionDelete = "delete"
+	ActionWarn   = "warn"
+)
+
+// defaultWarningMessage is sent when the warn action has no configured message
+const defaultWarningMessage = "Your message contains blocked content and was not processed."
+
+// Filter checks messages against a blocklist of regex patterns
+type Filter struct {
+	patterns       []*regexp.Regexp
+	action         string
+	warningMessage string
+	logger         logging.Logger
+
+	// DryRun logs moderation actions instead of applying them
+	DryRun bool
+}
+
+// New creates a new moderation filter with pre-compiled blocked patterns
+func New(cfg *config.ModerationConfig, logger logging.Logger) (*Filter, error) {
+	filter := &Filter{
+		patterns:       make([]*regexp.Regexp, 0, len(cfg.BlockedPatterns)),
+		action:         cfg.Action,
+		warningMessage: cfg.WarningMessage,
+		logger:         logger,
+	}
+
+	if filter.action == "" {
+		filter.action = ActionIgnore
+	}
+
+	if filter.warningMessage == "" {
+		filter.warningMessage = defaultWarningMessage
+	}
+
+	for _, pattern := range cfg.BlockedPatterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blocked pattern %q: %w", pattern, err)
+		}
+		filter.patterns = append(filter.patterns, regex)
+	}
+
+	logger.Info("Moderation filter initialized", "patterns", len(filter.patterns), "action", filter.action)
+	return filter, nil
+}
+
+// Match returns the first blocked pattern matching the content
+func (f *Filter) Match(content string) (string, bool) {
+	for _, pattern := range f.patterns {
+		if pattern.MatchString(content) {
+			return pattern.String(), true
+		}
+	}
+	return "", false
+}
+
+// Handle applies the moderation action to a message.
+// It returns true when the message is blocked and must not be processed further.
+func (f *Filter) Handle(ctx context.Context, session response.DiscordSession, message *discordgo.MessageCreate) (bool, error) {
+	pattern, blocked := f.Match(message.Content)
+	if !blocked {
+		return false, nil
+	}
+
+	f.logger.Warn("Message blocked by moderation filter",
+		"userID", message.Author.ID,
+		"channelID", message.ChannelID,
+		"pattern", pattern,
+		"action", f.action,
+	)
+
+	if f.DryRun {
+		f.logger.Info("DRY RUN: would apply moderation action", "action", f.action, "userID", message.Author.ID)
+		return true, nil
+	}
+
+	switch f.action {
+	case ActionDelete:
+		if err := session.ChannelMessageDelete(message.ChannelID, message.ID); err != nil {
+			return true, fmt.Errorf("failed to delete blocked message: %w", err)
+		}
+	case ActionWarn:
+		warning := config.ResponseConfig{
+			Type:    "dm",
+			Content: f.warningMessage,
+		}
+		if err := response.Execute(ctx, session, message.Message, warning, f.logger); err != nil {
+			return true, fmt.Errorf("failed to warn user: %w", err)
+		}
+	}
+
+	return true, nil
+}
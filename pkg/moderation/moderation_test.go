@@ -0,0 +1,128 @@
+package moderation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/moderation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMessage(content string) *discordgo.MessageCreate {
+	return &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			ID:        "msg123",
+			Content:   content,
+			ChannelID: "channel123",
+			Author: &discordgo.User{
+				ID:       "user123",
+				Username: "testuser",
+			},
+		},
+	}
+}
+
+func newLogger() *testutil.MockLogger {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	logger.On("Warn", mock.Anything, mock.Anything).Return()
+	return logger
+}
+
+func TestNew_InvalidPattern(t *testing.T) {
+	filter, err := moderation.New(&config.ModerationConfig{
+		BlockedPatterns: []string{"[invalid("},
+	}, newLogger())
+
+	assert.Error(t, err)
+	assert.Nil(t, filter)
+	assert.Contains(t, err.Error(), "invalid blocked pattern")
+}
+
+func TestFilter_Match(t *testing.T) {
+	filter, err := moderation.New(&config.ModerationConfig{
+		BlockedPatterns: []string{"(?i)free\\s+nitro", "badword"},
+	}, newLogger())
+	require.NoError(t, err)
+
+	pattern, blocked := filter.Match("Get FREE Nitro here")
+	assert.True(t, blocked)
+	assert.Equal(t, "(?i)free\\s+nitro", pattern)
+
+	_, blocked = filter.Match("hello world")
+	assert.False(t, blocked)
+}
+
+func TestFilter_Handle_Ignore(t *testing.T) {
+	filter, err := moderation.New(&config.ModerationConfig{
+		BlockedPatterns: []string{"badword"},
+		Action:          moderation.ActionIgnore,
+	}, newLogger())
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+
+	blocked, err := filter.Handle(context.Background(), session, newMessage("a badword here"))
+
+	require.NoError(t, err)
+	assert.True(t, blocked)
+	session.AssertExpectations(t)
+}
+
+func TestFilter_Handle_Delete(t *testing.T) {
+	filter, err := moderation.New(&config.ModerationConfig{
+		BlockedPatterns: []string{"badword"},
+		Action:          moderation.ActionDelete,
+	}, newLogger())
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageDelete", "channel123", "msg123").Return(nil)
+
+	blocked, err := filter.Handle(context.Background(), session, newMessage("a badword here"))
+
+	require.NoError(t, err)
+	assert.True(t, blocked)
+	session.AssertExpectations(t)
+}
+
+func TestFilter_Handle_Warn(t *testing.T) {
+	filter, err := moderation.New(&config.ModerationConfig{
+		BlockedPatterns: []string{"badword"},
+		Action:          moderation.ActionWarn,
+		WarningMessage:  "Please keep it clean",
+	}, newLogger())
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("UserChannelCreate", "user123").Return(&discordgo.Channel{ID: "dm-channel"}, nil)
+	session.On("ChannelMessageSend", "dm-channel", "Please keep it clean").Return(&discordgo.Message{}, nil)
+
+	blocked, err := filter.Handle(context.Background(), session, newMessage("a badword here"))
+
+	require.NoError(t, err)
+	assert.True(t, blocked)
+	session.AssertExpectations(t)
+}
+
+func TestFilter_Handle_NotBlocked(t *testing.T) {
+	filter, err := moderation.New(&config.ModerationConfig{
+		BlockedPatterns: []string{"badword"},
+		Action:          moderation.ActionDelete,
+	}, newLogger())
+	require.NoError(t, err)
+
+	session := &testutil.MockDiscordSession{}
+
+	blocked, err := filter.Handle(context.Background(), session, newMessage("hello"))
+
+	require.NoError(t, err)
+	assert.False(t, blocked)
+	session.AssertExpectations(t)
+}
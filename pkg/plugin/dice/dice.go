@@ -0,0 +1,87 @@
+// Package dice provides an example plugin implementing a dice rolling command.
+package dice
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+)
+
+// ActionType is the action type handled by the plugin
+const ActionType = "dice"
+
+// Default and maximum number of sides of a die
+const (
+	defaultSides = 6
+	maxSides     = 1000
+)
+
+// Plugin registers the dice action type
+type Plugin struct{}
+
+// New creates the dice plugin
+func New() *Plugin {
+	return &Plugin{}
+}
+
+// Name returns the plugin name
+func (p *Plugin) Name() string {
+	return "dice"
+}
+
+// Version returns the plugin version
+func (p *Plugin) Version() string {
+	return "1.0.0"
+}
+
+// RegisterHandlers registers the dice action type
+func (p *Plugin) RegisterHandlers(mgr *action.Manager) error {
+	return mgr.RegisterHandler(ActionType, func(prefix string, cfg config.ActionConfig) (action.Handler, error) {
+		command := cfg.Trigger.Command
+		if command == "" {
+			command = "dice"
+		}
+		return &Handler{CommandHandler: action.NewCommandHandler(prefix, command)}, nil
+	})
+}
+
+// Handler rolls a die with the number of sides given as argument, e.g. !dice 20
+type Handler struct {
+	*action.CommandHandler
+}
+
+// Respond rolls the die and sends the result to the channel
+func (h *Handler) Respond(ctx context.Context, session response.DiscordSession, message *discordgo.Message) error {
+	sides, err := parseSides(h.ExtractArgs(message.Content))
+	if err != nil {
+		_, sendErr := session.ChannelMessageSend(message.ChannelID, err.Error())
+		return sendErr
+	}
+
+	result := rand.IntN(sides) + 1 // #nosec G404 -- dice rolls do not need a secure source
+	if _, err := session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("🎲 You rolled %d (1-%d)", result, sides)); err != nil {
+		return fmt.Errorf("failed to send dice roll: %w", err)
+	}
+
+	return nil
+}
+
+// parseSides returns the number of sides requested in the command arguments
+func parseSides(args []string) (int, error) {
+	if len(args) == 0 {
+		return defaultSides, nil
+	}
+
+	sides, err := strconv.Atoi(args[0])
+	if err != nil || sides < 2 || sides > maxSides {
+		return 0, fmt.Errorf("usage: dice N (N between 2 and %d)", maxSides)
+	}
+
+	return sides, nil
+}
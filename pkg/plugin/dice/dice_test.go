@@ -0,0 +1,76 @@
+package dice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/plugin/dice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDice_Roll(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "default sides", content: "!dice", expected: "(1-6)"},
+		{name: "custom sides", content: "!dice 20", expected: "(1-20)"},
+		{name: "invalid sides", content: "!dice abc", expected: "usage: dice N"},
+		{name: "too few sides", content: "!dice 1", expected: "usage: dice N"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Bot: config.BotConfig{Prefix: "!"},
+				Actions: []config.ActionConfig{
+					{Name: "roll", Type: dice.ActionType, Trigger: config.TriggerConfig{Command: "dice"}},
+				},
+			}
+
+			logger := &testutil.MockLogger{}
+			logger.On("Info", mock.Anything, mock.Anything).Return()
+			logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+			mgr, err := action.NewManager(cfg, logger)
+			require.NoError(t, err)
+			require.NoError(t, dice.New().RegisterHandlers(mgr))
+
+			session := &testutil.MockDiscordSession{}
+			session.On("ChannelMessageSend", "channel123", mock.MatchedBy(func(content string) bool {
+				return assert.Contains(t, content, tt.expected)
+			})).Return(&discordgo.Message{}, nil)
+
+			message := &discordgo.MessageCreate{
+				Message: &discordgo.Message{
+					Content:   tt.content,
+					ChannelID: "channel123",
+					Author:    &discordgo.User{ID: "123"},
+				},
+			}
+
+			err = mgr.HandleMessage(context.Background(), session, message)
+
+			assert.NoError(t, err)
+			session.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDice_RegisterTwice(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	mgr, err := action.NewManager(&config.Config{Bot: config.BotConfig{Prefix: "!"}}, logger)
+	require.NoError(t, err)
+
+	require.NoError(t, dice.New().RegisterHandlers(mgr))
+	assert.Error(t, dice.New().RegisterHandlers(mgr))
+}
@@ -0,0 +1,98 @@
+// Package plugin provides loading of external action handlers.
+package plugin
+
+import (
+	"fmt"
+	goplugin "plugin"
+	"sync"
+
+	"github.com/geekxflood/common/logging"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+)
+
+// SymbolName is the symbol a plugin shared object must export
+const SymbolName = "Plugin"
+
+// Plugin is an extension registering custom action handlers
+type Plugin interface {
+	Name() string
+	Version() string
+	RegisterHandlers(mgr *action.Manager) error
+}
+
+var (
+	registry   []Plugin
+	registryMu sync.Mutex
+)
+
+// RegisterPlugin registers a compiled-in plugin, it must be called before the bot is created
+func RegisterPlugin(p Plugin) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry = append(registry, p)
+}
+
+// Registered returns all compiled-in plugins
+func Registered() []Plugin {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	plugins := make([]Plugin, len(registry))
+	copy(plugins, registry)
+	return plugins
+}
+
+// Loader loads plugins and registers their handlers on an action manager
+type Loader struct {
+	logger logging.Logger
+}
+
+// NewLoader creates a new plugin loader
+func NewLoader(logger logging.Logger) *Loader {
+	return &Loader{logger: logger}
+}
+
+// Open loads a plugin from a Go plugin shared object
+func (l *Loader) Open(path string) (Plugin, error) {
+	so, err := goplugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin %s: %w", path, err)
+	}
+
+	sym, err := so.Lookup(SymbolName)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s does not export %s: %w", path, SymbolName, err)
+	}
+
+	switch p := sym.(type) {
+	case *Plugin:
+		return *p, nil
+	case Plugin:
+		return p, nil
+	default:
+		return nil, fmt.Errorf("plugin %s: symbol %s does not implement Plugin", path, SymbolName)
+	}
+}
+
+// Load registers the handlers of compiled-in plugins and of the plugins at the given paths
+func (l *Loader) Load(mgr *action.Manager, paths []string) error {
+	plugins := Registered()
+
+	for _, path := range paths {
+		p, err := l.Open(path)
+		if err != nil {
+			return err
+		}
+		plugins = append(plugins, p)
+	}
+
+	for _, p := range plugins {
+		if err := p.RegisterHandlers(mgr); err != nil {
+			return fmt.Errorf("failed to register handlers of plugin %s: %w", p.Name(), err)
+		}
+		l.logger.Info("Plugin loaded", "name", p.Name(), "version", p.Version())
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/action"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/plugin"
+	"github.com/geekxflood/gxf-discord-bot/pkg/plugin/dice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newManager(t *testing.T, logger *testutil.MockLogger) *action.Manager {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Prefix: "!",
+		},
+		Actions: []config.ActionConfig{
+			{
+				Name:    "roll",
+				Type:    dice.ActionType,
+				Trigger: config.TriggerConfig{Command: "dice"},
+			},
+		},
+	}
+
+	mgr, err := action.NewManager(cfg, logger)
+	require.NoError(t, err)
+	return mgr
+}
+
+func TestLoader_LoadRegisteredPlugin(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	mgr := newManager(t, logger)
+	assert.Empty(t, mgr.GetActions())
+
+	plugin.RegisterPlugin(dice.New())
+	require.Len(t, plugin.Registered(), 1)
+
+	err := plugin.NewLoader(logger).Load(mgr, nil)
+	require.NoError(t, err)
+
+	actions := mgr.GetActions()
+	require.Len(t, actions, 1)
+	assert.Equal(t, "roll", actions[0].Name)
+	assert.Contains(t, logger.InfoMessages, "Plugin loaded")
+}
+
+func TestLoader_OpenMissingFile(t *testing.T) {
+	logger := &testutil.MockLogger{}
+
+	_, err := plugin.NewLoader(logger).Open("/nonexistent/plugin.so")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to open plugin")
+}
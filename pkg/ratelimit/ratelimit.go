@@ -2,6 +2,7 @@
 package ratelimit
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -14,10 +15,10 @@ type Limiter struct {
 	logger logging.Logger
 
 	// User rate limits
-	userLimit    int
-	userWindow   time.Duration
-	userBuckets  map[string]*bucket
-	userMu       sync.RWMutex
+	userLimit   int
+	userWindow  time.Duration
+	userBuckets map[string]*bucket
+	userMu      sync.RWMutex
 
 	// Channel rate limits
 	channelLimit   int
@@ -37,11 +38,39 @@ type Limiter struct {
 	globalBucket *bucket
 	globalMu     sync.RWMutex
 
+	// Per-action rate limits, counted in shared when set with the times their windows reset
+	actionBuckets map[string]*bucket
+	shared        SharedCounter
+	sharedResets  map[string]time.Time
+	actionMu      sync.Mutex
+
 	// Cleanup
 	cleanupStop chan struct{}
 	cleanupMu   sync.Mutex
 }
 
+// sharedTimeout bounds a request to the shared counter, the local limit is used when it fails
+const sharedTimeout = 2 * time.Second
+
+// SharedCounter counts requests in fixed windows shared between bot processes
+type SharedCounter interface {
+	// Increment counts a request of key and returns the requests counted in the current window
+	// and the time until the window ends
+	Increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error)
+}
+
+// BucketStatus describes the rate limit bucket of a user
+type BucketStatus struct {
+	// Remaining is the number of requests left in the window, -1 when unlimited
+	Remaining int `json:"remaining"`
+	// Total is the number of requests allowed per window, 0 when unlimited
+	Total int `json:"total"`
+	// ResetsAt is when the window ends, zero when no request was made
+	ResetsAt time.Time `json:"resetsAt,omitzero"`
+	// IsLimited is true when no request is left in the window
+	IsLimited bool `json:"limited"`
+}
+
 type bucket struct {
 	tokens    int
 	maxTokens int
@@ -59,9 +88,19 @@ func New(logger logging.Logger) *Limiter {
 		userBuckets:    make(map[string]*bucket),
 		channelBuckets: make(map[string]*bucket),
 		guildBuckets:   make(map[string]*bucket),
+		actionBuckets:  make(map[string]*bucket),
+		sharedResets:   make(map[string]time.Time),
 	}
 }
 
+// SetSharedCounter counts the action rate limits in counter, so they hold across the
+// processes of a sharded bot
+func (l *Limiter) SetSharedCounter(counter SharedCounter) {
+	l.actionMu.Lock()
+	defer l.actionMu.Unlock()
+	l.shared = counter
+}
+
 // SetUserLimit configures per-user rate limiting
 func (l *Limiter) SetUserLimit(limit int, window time.Duration) {
 	l.userMu.Lock()
@@ -200,6 +239,85 @@ func (l *Limiter) AllowGlobal() bool {
 	return l.globalBucket.allow()
 }
 
+// AllowAction checks if a request is allowed for an action within a scope key.
+// The key identifies the user, channel or guild the limit applies to.
+func (l *Limiter) AllowAction(action, key string, limit int, window time.Duration) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	id := action + "/" + key
+	if allowed, ok := l.allowShared(id, limit, window); ok {
+		return allowed
+	}
+
+	l.actionMu.Lock()
+	defer l.actionMu.Unlock()
+
+	b, exists := l.actionBuckets[id]
+	if exists {
+		b.resize(limit, window)
+	} else {
+		b = &bucket{
+			tokens:    limit,
+			maxTokens: limit,
+			window:    window,
+			lastReset: time.Now(),
+		}
+		l.actionBuckets[id] = b
+	}
+
+	return b.allow()
+}
+
+// allowShared checks an action limit with the shared counter, ok is false when there is
+// no shared counter or it failed
+func (l *Limiter) allowShared(id string, limit int, window time.Duration) (allowed, ok bool) {
+	l.actionMu.Lock()
+	shared := l.shared
+	l.actionMu.Unlock()
+
+	if shared == nil {
+		return false, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sharedTimeout)
+	defer cancel()
+
+	count, reset, err := shared.Increment(ctx, id, window)
+	if err != nil {
+		l.logger.Warn("Shared rate limit unavailable, using the local limit", "error", err)
+		return false, false
+	}
+
+	l.actionMu.Lock()
+	defer l.actionMu.Unlock()
+	if count > int64(limit) {
+		l.sharedResets[id] = time.Now().Add(reset)
+		return false, true
+	}
+	delete(l.sharedResets, id)
+	return true, true
+}
+
+// ActionRetryAfter returns how long until the action limit of key allows a request again,
+// 0 when a request is allowed now
+func (l *Limiter) ActionRetryAfter(action, key string) time.Duration {
+	l.actionMu.Lock()
+	b, exists := l.actionBuckets[action+"/"+key]
+	resetsAt, limited := l.sharedResets[action+"/"+key]
+	l.actionMu.Unlock()
+
+	if limited {
+		return max(time.Until(resetsAt), 0)
+	}
+
+	if !exists {
+		return 0
+	}
+	return b.retryAfter()
+}
+
 // Allow checks all applicable rate limits
 func (l *Limiter) Allow(userID, channelID, guildID string) bool {
 	// Check all limits - all must pass
@@ -256,6 +374,32 @@ func (l *Limiter) GetUserRemaining(userID string) int {
 	return b.tokens
 }
 
+// Status returns the rate limit bucket of a user
+func (l *Limiter) Status(userID string) BucketStatus {
+	l.userMu.RLock()
+	defer l.userMu.RUnlock()
+
+	if l.userLimit == 0 {
+		return BucketStatus{Remaining: -1}
+	}
+
+	b, exists := l.userBuckets[userID]
+	if !exists {
+		return BucketStatus{Remaining: l.userLimit, Total: l.userLimit}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.reset()
+	return BucketStatus{
+		Remaining: b.tokens,
+		Total:     b.maxTokens,
+		ResetsAt:  b.lastReset.Add(b.window),
+		IsLimited: b.tokens == 0,
+	}
+}
+
 // Cleanup removes expired rate limit buckets
 func (l *Limiter) Cleanup() {
 	l.logger.Debug("Running rate limit cleanup")
@@ -288,6 +432,20 @@ func (l *Limiter) Cleanup() {
 		}
 	}
 	l.guildMu.Unlock()
+
+	// Clean action buckets
+	l.actionMu.Lock()
+	for id, b := range l.actionBuckets {
+		if now.Sub(b.lastReset) > b.window {
+			delete(l.actionBuckets, id)
+		}
+	}
+	for id, resetsAt := range l.sharedResets {
+		if now.After(resetsAt) {
+			delete(l.sharedResets, id)
+		}
+	}
+	l.actionMu.Unlock()
 }
 
 // StartCleanup starts automatic cleanup of expired buckets
@@ -353,6 +511,34 @@ func (b *bucket) allow() bool {
 	return false
 }
 
+// retryAfter returns how long until a token is available
+func (b *bucket) retryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.reset()
+	if b.tokens > 0 {
+		return 0
+	}
+	return max(b.window-time.Since(b.lastReset), 0)
+}
+
+// resize changes the limit and window of the bucket, the requests made in the current window still count
+func (b *bucket) resize(limit int, window time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.maxTokens == limit && b.window == window {
+		return
+	}
+
+	b.reset()
+	used := b.maxTokens - b.tokens
+	b.maxTokens = limit
+	b.window = window
+	b.tokens = max(limit-used, 0)
+}
+
 // reset resets the bucket if the window has passed
 func (b *bucket) reset() {
 	if time.Since(b.lastReset) >= b.window {
@@ -1,6 +1,8 @@
 package ratelimit_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -53,6 +55,33 @@ func TestLimiter_AllowUser(t *testing.T) {
 	assert.True(t, allowed)
 }
 
+func TestLimiter_Status(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	limiter := ratelimit.New(logger)
+	assert.Equal(t, ratelimit.BucketStatus{Remaining: -1}, limiter.Status("user123"))
+
+	limiter.SetUserLimit(2, time.Minute)
+	assert.Equal(t, ratelimit.BucketStatus{Remaining: 2, Total: 2}, limiter.Status("user123"))
+
+	start := time.Now()
+	limiter.AllowUser("user123")
+	status := limiter.Status("user123")
+	assert.Equal(t, 1, status.Remaining)
+	assert.Equal(t, 2, status.Total)
+	assert.False(t, status.IsLimited)
+	assert.WithinDuration(t, start.Add(time.Minute), status.ResetsAt, time.Second)
+
+	limiter.AllowUser("user123")
+	limiter.AllowUser("user123")
+	status = limiter.Status("user123")
+	assert.Equal(t, 0, status.Remaining)
+	assert.True(t, status.IsLimited)
+	assert.WithinDuration(t, start.Add(time.Minute), status.ResetsAt, time.Second)
+}
+
 func TestLimiter_AllowChannel(t *testing.T) {
 	logger := &testutil.MockLogger{}
 	logger.On("Info", mock.Anything, mock.Anything).Return()
@@ -268,3 +297,70 @@ func TestLimiter_DifferentUsers(t *testing.T) {
 	allowed = limiter.AllowUser("user2")
 	assert.True(t, allowed)
 }
+
+func TestLimiter_AllowAction(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	limiter := ratelimit.New(logger)
+
+	assert.True(t, limiter.AllowAction("ping", "user1", 1, time.Minute))
+	assert.False(t, limiter.AllowAction("ping", "user1", 1, time.Minute))
+
+	// Other keys and actions have their own buckets
+	assert.True(t, limiter.AllowAction("ping", "user2", 1, time.Minute))
+	assert.True(t, limiter.AllowAction("help", "user1", 1, time.Minute))
+
+	// A zero limit disables the check
+	assert.True(t, limiter.AllowAction("free", "user1", 0, time.Minute))
+}
+
+// fakeCounter is a shared counter of a single window, failing when err is set
+type fakeCounter struct {
+	counts map[string]int64
+	err    error
+}
+
+func (c *fakeCounter) Increment(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	if c.err != nil {
+		return 0, 0, c.err
+	}
+	c.counts[key]++
+	return c.counts[key], window, nil
+}
+
+func TestLimiter_AllowAction_Shared(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Warn", mock.Anything, mock.Anything).Return()
+
+	counter := &fakeCounter{counts: map[string]int64{"ping/user1": 1}}
+	limiter := ratelimit.New(logger)
+	limiter.SetSharedCounter(counter)
+
+	// The request counted by another process takes the only one of the window
+	assert.False(t, limiter.AllowAction("ping", "user1", 1, time.Minute))
+	assert.InDelta(t, time.Minute, limiter.ActionRetryAfter("ping", "user1"), float64(time.Second))
+	assert.True(t, limiter.AllowAction("ping", "user2", 1, time.Minute))
+
+	// The local limit applies while the shared counter is unavailable
+	counter.err = errors.New("connection refused")
+	assert.True(t, limiter.AllowAction("help", "user1", 1, time.Minute))
+	assert.False(t, limiter.AllowAction("help", "user1", 1, time.Minute))
+	logger.AssertCalled(t, "Warn", "Shared rate limit unavailable, using the local limit", mock.Anything)
+}
+
+func TestLimiter_AllowAction_LimitChanged(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	limiter := ratelimit.New(logger)
+
+	assert.True(t, limiter.AllowAction("ping", "user1", 2, time.Minute))
+	assert.True(t, limiter.AllowAction("ping", "user1", 2, time.Minute))
+
+	// The requests already made count against the new limit
+	assert.True(t, limiter.AllowAction("ping", "user1", 3, time.Minute))
+	assert.False(t, limiter.AllowAction("ping", "user1", 3, time.Minute))
+	assert.False(t, limiter.AllowAction("ping", "user1", 2, time.Hour))
+}
@@ -0,0 +1,157 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/common/logging"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+)
+
+// DefaultBatchConcurrency is the number of messages of a batch sent at the same time
+const DefaultBatchConcurrency = 5
+
+// throughputBuckets are the upper bounds, in messages per second, of the batch throughput histogram
+var throughputBuckets = []float64{1, 5, 10, 25, 50, 100}
+
+// BatchItem is a response sent to a channel as part of a batch
+type BatchItem struct {
+	ChannelID string
+	Response  config.ResponseConfig
+}
+
+// BatchStats reports the batches sent and their throughput
+type BatchStats struct {
+	Batches  int64 `json:"batches"`
+	Messages int64 `json:"messages"`
+	Failures int64 `json:"failures"`
+
+	// ThroughputBuckets counts the batches by throughput in messages per second,
+	// each bucket includes the batches of the lower buckets
+	ThroughputBuckets map[string]int64 `json:"throughputBuckets"`
+}
+
+// BatchSender sends responses to many channels concurrently
+type BatchSender struct {
+	session     DiscordSession
+	concurrency int
+	logger      logging.Logger
+	contextFunc func(*discordgo.Message) *TemplateContext
+
+	stats   BatchStats
+	buckets []int64
+	mu      sync.Mutex
+}
+
+// NewBatchSender creates a batch sender, a concurrency of 0 uses DefaultBatchConcurrency
+func NewBatchSender(session DiscordSession, concurrency int, logger logging.Logger) *BatchSender {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	return &BatchSender{
+		session:     session,
+		concurrency: concurrency,
+		logger:      logger,
+		buckets:     make([]int64, len(throughputBuckets)+1),
+	}
+}
+
+// SetTemplateContext sets the function creating the template context of each message,
+// so batches see the same lookups and bot user as other responses
+func (s *BatchSender) SetTemplateContext(fn func(*discordgo.Message) *TemplateContext) {
+	s.contextFunc = fn
+}
+
+// Send sends every item and returns the errors of the failed ones
+func (s *BatchSender) Send(ctx context.Context, items []BatchItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	errs := make([]error, len(items))
+	semaphore := make(chan struct{}, s.concurrency)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			message := &discordgo.Message{ChannelID: item.ChannelID}
+			data := NewTemplateContext(message)
+			if s.contextFunc != nil {
+				data = s.contextFunc(message)
+			}
+			if err := ExecuteWithContext(ctx, s.session, message, item.Response, data, s.logger); err != nil {
+				errs[i] = fmt.Errorf("channel %s: %w", item.ChannelID, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.record(len(items), errs, time.Since(start))
+	return errors.Join(errs...)
+}
+
+// Stats returns the statistics of the batches sent so far
+func (s *BatchSender) Stats() BatchStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	stats.ThroughputBuckets = make(map[string]int64, len(s.buckets))
+
+	var cumulative int64
+	for i, count := range s.buckets {
+		cumulative += count
+		bound := "+Inf"
+		if i < len(throughputBuckets) {
+			bound = strconv.FormatFloat(throughputBuckets[i], 'f', -1, 64)
+		}
+		stats.ThroughputBuckets[bound] = cumulative
+	}
+
+	return stats
+}
+
+// record adds a sent batch to the statistics
+func (s *BatchSender) record(messages int, errs []error, duration time.Duration) {
+	var failures int64
+	for _, err := range errs {
+		if err != nil {
+			failures++
+		}
+	}
+
+	throughput := float64(messages) / duration.Seconds()
+	bucket := len(throughputBuckets)
+	for i, bound := range throughputBuckets {
+		if throughput <= bound {
+			bucket = i
+			break
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Batches++
+	s.stats.Messages += int64(messages)
+	s.stats.Failures += failures
+	s.buckets[bucket]++
+}
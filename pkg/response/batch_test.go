@@ -0,0 +1,99 @@
+package response_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSender_Send(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	var running, maxRunning atomic.Int32
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "fail", "Daily reminder").Return(nil, errors.New("missing access"))
+	session.On("ChannelMessageSend", mock.Anything, "Daily reminder").Run(func(args mock.Arguments) {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			previous := maxRunning.Load()
+			if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}).Return(&discordgo.Message{}, nil)
+
+	items := make([]response.BatchItem, 0, 11)
+	for i := range 10 {
+		items = append(items, response.BatchItem{
+			ChannelID: fmt.Sprintf("channel%d", i),
+			Response:  config.ResponseConfig{Type: "text", Content: "Daily reminder"},
+		})
+	}
+	items = append(items, response.BatchItem{ChannelID: "fail", Response: config.ResponseConfig{Type: "text", Content: "Daily reminder"}})
+
+	sender := response.NewBatchSender(session, 3, logger)
+	err := sender.Send(context.Background(), items)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "channel fail")
+	session.AssertNumberOfCalls(t, "ChannelMessageSend", 11)
+	assert.Equal(t, int32(3), maxRunning.Load())
+
+	stats := sender.Stats()
+	assert.Equal(t, int64(1), stats.Batches)
+	assert.Equal(t, int64(11), stats.Messages)
+	assert.Equal(t, int64(1), stats.Failures)
+	assert.Equal(t, int64(1), stats.ThroughputBuckets["+Inf"])
+}
+
+func TestBatchSender_Empty(t *testing.T) {
+	sender := response.NewBatchSender(&testutil.MockDiscordSession{}, 0, &testutil.MockLogger{})
+
+	require.NoError(t, sender.Send(context.Background(), nil))
+	assert.Zero(t, sender.Stats().Batches)
+}
+
+func TestBatchSender_TemplateContext(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("Channel", "channel456").Return(&discordgo.Channel{ID: "channel456", GuildID: "guild456"}, nil)
+	session.On("GuildMember", "guild456", "bot999").Return(&discordgo.Member{}, nil)
+	session.On("UserChannelPermissions", "bot999", "channel456").Return(int64(discordgo.PermissionSendMessages), nil)
+	session.On("ChannelMessageSendComplex", "channel456", mock.Anything).Return(&discordgo.Message{}, nil)
+
+	sender := response.NewBatchSender(session, 0, logger)
+	sender.SetTemplateContext(func(message *discordgo.Message) *response.TemplateContext {
+		data := response.NewTemplateContext(message)
+		data.BotUserID = "bot999"
+		return data
+	})
+
+	err := sender.Send(context.Background(), []response.BatchItem{{
+		ChannelID: "channel456",
+		Response: config.ResponseConfig{
+			Type:            "relay",
+			Content:         "Weekly digest",
+			TargetGuildID:   "guild456",
+			TargetChannelID: "channel456",
+		},
+	}})
+
+	require.NoError(t, err)
+	session.AssertCalled(t, "ChannelMessageSendComplex", "channel456", mock.Anything)
+}
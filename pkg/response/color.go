@@ -0,0 +1,82 @@
+package response
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// colorNames maps common color names to their Discord embed color
+var colorNames = map[string]int{
+	"default":     0x000000,
+	"white":       0xFFFFFF,
+	"black":       0x23272A,
+	"aqua":        0x1ABC9C,
+	"dark_aqua":   0x11806A,
+	"green":       0x57F287,
+	"dark_green":  0x1F8B4C,
+	"blue":        0x3498DB,
+	"dark_blue":   0x206694,
+	"purple":      0x9B59B6,
+	"dark_purple": 0x71368A,
+	"pink":        0xE91E63,
+	"fuchsia":     0xEB459E,
+	"gold":        0xF1C40F,
+	"dark_gold":   0xC27C0E,
+	"orange":      0xE67E22,
+	"dark_orange": 0xA84300,
+	"red":         0xED4245,
+	"dark_red":    0x992D22,
+	"grey":        0x95A5A6,
+	"gray":        0x95A5A6,
+	"dark_grey":   0x607D8B,
+	"dark_gray":   0x607D8B,
+	"navy":        0x34495E,
+	"yellow":      0xFEE75C,
+	"blurple":     0x5865F2,
+	"greyple":     0x99AAB5,
+}
+
+// maxColor is the largest valid embed color
+const maxColor = 0xFFFFFF
+
+// ParseColor parses an embed color given as a color name ("gold"),
+// a hex string ("#FF5733", "0xFF5733" or "FF5733") or a decimal integer ("3447003").
+// Digits without a prefix are always decimal, "123456" is 123456 and "#123456" is 0x123456.
+func ParseColor(s string) (int, error) {
+	value := strings.TrimSpace(s)
+	if value == "" {
+		return 0, fmt.Errorf("empty color")
+	}
+
+	name := strings.ReplaceAll(strings.ToLower(value), " ", "_")
+	if color, ok := colorNames[name]; ok {
+		return color, nil
+	}
+
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		return parseColorNumber(hex, 16, s)
+	}
+	if hex, ok := strings.CutPrefix(strings.ToLower(value), "0x"); ok {
+		return parseColorNumber(hex, 16, s)
+	}
+
+	if color, err := parseColorNumber(value, 10, s); err == nil {
+		return color, nil
+	}
+
+	if len(value) == 6 {
+		return parseColorNumber(value, 16, s)
+	}
+
+	return 0, fmt.Errorf("invalid color: %q", s)
+}
+
+// parseColorNumber parses a color number in the given base and checks its range
+func parseColorNumber(value string, base int, original string) (int, error) {
+	color, err := strconv.ParseInt(value, base, 32)
+	if err != nil || color < 0 || color > maxColor {
+		return 0, fmt.Errorf("invalid color: %q", original)
+	}
+	return int(color), nil
+}
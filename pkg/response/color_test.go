@@ -0,0 +1,52 @@
+package response_test
+
+import (
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{input: "red", expected: 0xED4245},
+		{input: "Gold", expected: 0xF1C40F},
+		{input: "dark blue", expected: 0x206694},
+		{input: "#FF5733", expected: 0xFF5733},
+		{input: "FF5733", expected: 0xFF5733},
+		{input: "3447003", expected: 3447003},
+		{input: "0x3498DB", expected: 0x3498DB},
+		{input: "123456", expected: 123456},
+		{input: "#123456", expected: 0x123456},
+		{input: "#ffffff", expected: 0xFFFFFF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			color, err := response.ParseColor(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, color)
+		})
+	}
+}
+
+func TestParseColor_Invalid(t *testing.T) {
+	for _, input := range []string{"", "notacolor", "#GGGGGG", "#1000000", "-5"} {
+		_, err := response.ParseColor(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestBuildEmbed_ColorName(t *testing.T) {
+	embed := response.BuildEmbed(&config.EmbedConfig{
+		Title:     "Test",
+		ColorName: "blurple",
+	})
+
+	assert.Equal(t, 0x5865F2, embed.Color)
+}
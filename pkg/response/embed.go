@@ -0,0 +1,159 @@
+package response
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+)
+
+// Discord limits of the fields of an embed
+const (
+	maxEmbedFields           = 25
+	maxEmbedFieldNameLength  = 256
+	maxEmbedFieldValueLength = 1024
+)
+
+// embedFieldsPerRow is the number of inline fields Discord shows on a row
+const embedFieldsPerRow = 3
+
+// blankFieldText is the zero-width space filling the name and value of padding fields
+const blankFieldText = "\u200b"
+
+// truncationSuffix ends the texts shortened to the Discord limits
+const truncationSuffix = "…"
+
+// allowedURLSchemes are the URL schemes accepted in embeds
+var allowedURLSchemes = map[string]bool{"https": true, "http": true}
+
+// markdownLinkPattern matches the target of the markdown links of an embed text, e.g. [docs](https://example.com)
+var markdownLinkPattern = regexp.MustCompile(`\[[^\]]*\]\(\s*<?([^)\s>]+)`)
+
+// ValidateEmbed checks the URLs of an embed, including the links in its description and field values,
+// and the Discord limits of its fields when truncateOnOverflow is error, and returns an error for each invalid one.
+// Icon URLs must use HTTPS, Discord shows a broken image for HTTP icons
+func ValidateEmbed(embed *config.EmbedConfig) []error {
+	var errs []error
+	check := func(field, value string, requireHTTPS bool) {
+		if value == "" {
+			return
+		}
+		if err := ValidateURL(value, requireHTTPS); err != nil {
+			errs = append(errs, boterrors.ValidationError{Field: field, Reason: err.Error()})
+		}
+	}
+
+	check("embed.url", embed.URL, false)
+	check("embed.image", embed.Image, false)
+	check("embed.thumbnail", embed.Thumbnail, false)
+	if embed.Author != nil {
+		check("embed.author.url", embed.Author.URL, false)
+		check("embed.author.iconUrl", embed.Author.IconURL, true)
+	}
+	check("embed.footerIconUrl", embed.FooterIconURL, true)
+
+	for _, link := range markdownLinkPattern.FindAllStringSubmatch(embed.Description, -1) {
+		check("embed.description", link[1], false)
+	}
+	for i, field := range embed.Fields {
+		for _, link := range markdownLinkPattern.FindAllStringSubmatch(field.Value, -1) {
+			check(fmt.Sprintf("embed.fields[%d].value", i), link[1], false)
+		}
+	}
+
+	if embed.TruncateOnOverflow == "error" {
+		errs = append(errs, checkEmbedLimits(embed)...)
+	}
+
+	return errs
+}
+
+// checkEmbedLimits returns an error for the fields exceeding the Discord limits
+func checkEmbedLimits(embed *config.EmbedConfig) []error {
+	var errs []error
+	if len(embed.Fields) > maxEmbedFields {
+		errs = append(errs, boterrors.ValidationError{
+			Field:  "embed.fields",
+			Reason: fmt.Sprintf("%d fields exceed the maximum of %d", len(embed.Fields), maxEmbedFields),
+		})
+	}
+
+	for i, field := range embed.Fields {
+		if length := utf8.RuneCountInString(field.Name); length > maxEmbedFieldNameLength {
+			errs = append(errs, boterrors.ValidationError{
+				Field:  fmt.Sprintf("embed.fields[%d].name", i),
+				Reason: fmt.Sprintf("%d characters exceed the maximum of %d", length, maxEmbedFieldNameLength),
+			})
+		}
+		if length := utf8.RuneCountInString(field.Value); length > maxEmbedFieldValueLength {
+			errs = append(errs, boterrors.ValidationError{
+				Field:  fmt.Sprintf("embed.fields[%d].value", i),
+				Reason: fmt.Sprintf("%d characters exceed the maximum of %d", length, maxEmbedFieldValueLength),
+			})
+		}
+	}
+	return errs
+}
+
+// AlignFields pads the fields with blank inline fields to a multiple of 3, so the last
+// row of inline fields lines up with the rows above. The padding stops at the 25 fields limit.
+func AlignFields(fields []config.EmbedField) []config.EmbedField {
+	padded := (len(fields) + embedFieldsPerRow - 1) / embedFieldsPerRow * embedFieldsPerRow
+	padded = min(padded, max(maxEmbedFields, len(fields)))
+
+	aligned := slices.Clone(fields)
+	for len(aligned) < padded {
+		aligned = append(aligned, config.EmbedField{Name: blankFieldText, Value: blankFieldText, Inline: true})
+	}
+	return aligned
+}
+
+// validateAndTruncateEmbed keeps the first 25 fields of the embed and shortens
+// the field names and values over the Discord limits, ending them with …
+func validateAndTruncateEmbed(embed *discordgo.MessageEmbed) *discordgo.MessageEmbed {
+	if len(embed.Fields) > maxEmbedFields {
+		embed.Fields = embed.Fields[:maxEmbedFields]
+	}
+
+	for _, field := range embed.Fields {
+		field.Name = truncateText(field.Name, maxEmbedFieldNameLength)
+		field.Value = truncateText(field.Value, maxEmbedFieldValueLength)
+	}
+	return embed
+}
+
+// truncateText shortens the text to limit characters, the last one being the truncation suffix
+func truncateText(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	runes := []rune(text)
+	return string(runes[:limit-1]) + truncationSuffix
+}
+
+// ValidateURL checks that the URL is absolute with an http or https scheme, or https when requireHTTPS is set
+func ValidateURL(value string, requireHTTPS bool) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("malformed URL %q", value)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if requireHTTPS && scheme != "https" {
+		return fmt.Errorf("URL %q has scheme %q (must be https)", value, scheme)
+	}
+	if !allowedURLSchemes[scheme] {
+		return fmt.Errorf("URL %q has scheme %q (must be http or https)", value, scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("URL %q has no host", value)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package response_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEmbed(t *testing.T) {
+	tests := []struct {
+		name   string
+		embed  config.EmbedConfig
+		fields []string
+	}{
+		{name: "no URLs", embed: config.EmbedConfig{Title: "Plain"}},
+		{
+			name: "valid URLs",
+			embed: config.EmbedConfig{
+				URL:           "https://example.com",
+				Image:         "https://example.com/image.png",
+				Thumbnail:     "http://example.com/thumb.png",
+				Author:        &config.EmbedAuthor{Name: "Bot", URL: "https://example.com", IconURL: "https://example.com/icon.png"},
+				FooterIconURL: "https://example.com/footer.png",
+				Description:   "See [the docs](https://example.com/docs)",
+				Fields:        []config.EmbedField{{Name: "Link", Value: "[here](<https://example.com>)"}},
+			},
+		},
+		{name: "javascript URL", embed: config.EmbedConfig{URL: "javascript:alert(1)"}, fields: []string{"embed.url"}},
+		{name: "data image", embed: config.EmbedConfig{Image: "data:image/png;base64,AAAA"}, fields: []string{"embed.image"}},
+		{name: "relative thumbnail", embed: config.EmbedConfig{Thumbnail: "/thumb.png"}, fields: []string{"embed.thumbnail"}},
+		{name: "missing host", embed: config.EmbedConfig{Author: &config.EmbedAuthor{Name: "Bot", URL: "https://"}}, fields: []string{"embed.author.url"}},
+		{name: "http author icon", embed: config.EmbedConfig{Author: &config.EmbedAuthor{Name: "Bot", URL: "http://example.com", IconURL: "http://example.com/icon.png"}}, fields: []string{"embed.author.iconUrl"}},
+		{name: "http footer icon", embed: config.EmbedConfig{Footer: "Bot", FooterIconURL: "http://example.com/icon.png"}, fields: []string{"embed.footerIconUrl"}},
+		{name: "malformed URL", embed: config.EmbedConfig{Image: "https://exa mple.com/%zz"}, fields: []string{"embed.image"}},
+		{
+			name: "links in text",
+			embed: config.EmbedConfig{
+				Description: "[click](javascript:alert(1))",
+				Fields: []config.EmbedField{
+					{Name: "Safe", Value: "[ok](https://example.com)"},
+					{Name: "Unsafe", Value: "[a](ftp://example.com) and [b](data:text/html,hi)"},
+				},
+			},
+			fields: []string{"embed.description", "embed.fields[1].value", "embed.fields[1].value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := response.ValidateEmbed(&tt.embed)
+
+			fields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				var validationErr boterrors.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				fields = append(fields, validationErr.Field)
+			}
+			if len(tt.fields) == 0 {
+				assert.Empty(t, fields)
+				return
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		requireHTTPS bool
+		wantErr      string
+	}{
+		{name: "https", url: "https://example.com/icon.png", requireHTTPS: true},
+		{name: "http", url: "http://example.com/icon.png"},
+		{name: "uppercase scheme", url: "HTTPS://example.com/icon.png", requireHTTPS: true},
+		{name: "http when https required", url: "http://example.com/icon.png", requireHTTPS: true, wantErr: "must be https"},
+		{name: "javascript", url: "javascript:alert(1)", wantErr: "must be http or https"},
+		{name: "missing host", url: "https:///icon.png", requireHTTPS: true, wantErr: "has no host"},
+		{name: "malformed", url: "https://exa mple.com/%zz", wantErr: "malformed URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := response.ValidateURL(tt.url, tt.requireHTTPS)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExecuteEmbedResponse_InvalidURLs(t *testing.T) {
+	cfg := config.ResponseConfig{
+		Type: "embed",
+		Embed: &config.EmbedConfig{
+			Title:     "Profile",
+			Image:     "javascript:alert(1)",
+			Thumbnail: "data:image/png;base64,AAAA",
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+	message := &discordgo.Message{ChannelID: "channel123", Author: &discordgo.User{ID: "user123"}}
+
+	err := response.Execute(context.Background(), session, message, cfg, logger)
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "invalid embed.image")
+	assert.ErrorContains(t, err, "invalid embed.thumbnail")
+	session.AssertNotCalled(t, "ChannelMessageSendEmbed", mock.Anything, mock.Anything)
+}
+
+func TestBuildEmbed_Images(t *testing.T) {
+	embed := response.BuildEmbed(&config.EmbedConfig{
+		Title:         "Profile",
+		URL:           "https://example.com",
+		Image:         "https://example.com/image.png",
+		Thumbnail:     "https://example.com/thumb.png",
+		Author:        &config.EmbedAuthor{Name: "Bot", IconURL: "https://example.com/icon.png"},
+		Footer:        "Footer",
+		FooterIconURL: "https://example.com/footer.png",
+	})
+
+	assert.Equal(t, "https://example.com", embed.URL)
+	assert.Equal(t, "https://example.com/image.png", embed.Image.URL)
+	assert.Equal(t, "https://example.com/thumb.png", embed.Thumbnail.URL)
+	assert.Equal(t, "Bot", embed.Author.Name)
+	assert.Equal(t, "https://example.com/icon.png", embed.Author.IconURL)
+	assert.Equal(t, "https://example.com/footer.png", embed.Footer.IconURL)
+}
+
+func TestAlignFields(t *testing.T) {
+	tests := []struct {
+		count int
+		want  int
+	}{
+		{count: 0, want: 0},
+		{count: 1, want: 3},
+		{count: 2, want: 3},
+		{count: 3, want: 3},
+		{count: 4, want: 6},
+		{count: 5, want: 6},
+		{count: 6, want: 6},
+		{count: 7, want: 9},
+		{count: 8, want: 9},
+		{count: 9, want: 9},
+		{count: 25, want: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.count), func(t *testing.T) {
+			fields := make([]config.EmbedField, tt.count)
+			for i := range fields {
+				fields[i] = config.EmbedField{Name: fmt.Sprint("name", i), Value: "value", Inline: true}
+			}
+
+			aligned := response.AlignFields(fields)
+
+			require.Len(t, aligned, tt.want)
+			assert.Equal(t, fields, aligned[:tt.count])
+			for _, field := range aligned[tt.count:] {
+				assert.Equal(t, config.EmbedField{Name: "\u200b", Value: "\u200b", Inline: true}, field)
+			}
+			assert.Len(t, fields, tt.count)
+		})
+	}
+}
+
+func TestBuildEmbed_Align(t *testing.T) {
+	fields := []config.EmbedField{
+		{Name: "CPU", Value: "12%", Inline: true},
+		{Name: "Memory", Value: "1.2 GB", Inline: true},
+		{Name: "Disk", Value: "40%", Inline: true},
+		{Name: "Uptime", Value: "3d", Inline: true},
+	}
+
+	assert.Len(t, response.BuildEmbed(&config.EmbedConfig{Title: "Status", Fields: fields}).Fields, 4)
+
+	embed := response.BuildEmbed(&config.EmbedConfig{Title: "Status", Fields: fields, Align: true})
+	require.Len(t, embed.Fields, 6)
+	assert.Equal(t, "Uptime", embed.Fields[3].Name)
+	assert.Equal(t, "\u200b", embed.Fields[5].Value)
+	assert.True(t, embed.Fields[5].Inline)
+}
+
+func TestBuildEmbed_TruncatesOverflow(t *testing.T) {
+	fields := make([]config.EmbedField, 30)
+	for i := range fields {
+		fields[i] = config.EmbedField{Name: "name", Value: "value"}
+	}
+	fields[0] = config.EmbedField{Name: strings.Repeat("n", 300), Value: strings.Repeat("é", 2000)}
+	fields[1] = config.EmbedField{Name: strings.Repeat("n", 256), Value: strings.Repeat("v", 1024)}
+
+	embed := response.BuildEmbed(&config.EmbedConfig{Title: "Report", Fields: fields})
+
+	require.Len(t, embed.Fields, 25)
+	assert.Equal(t, 256, utf8.RuneCountInString(embed.Fields[0].Name))
+	assert.True(t, strings.HasSuffix(embed.Fields[0].Name, "n…"))
+	assert.Equal(t, 1024, utf8.RuneCountInString(embed.Fields[0].Value))
+	assert.True(t, strings.HasSuffix(embed.Fields[0].Value, "é…"))
+	assert.Equal(t, strings.Repeat("n", 256), embed.Fields[1].Name)
+	assert.Equal(t, strings.Repeat("v", 1024), embed.Fields[1].Value)
+}
+
+func TestValidateEmbed_OverflowError(t *testing.T) {
+	fields := make([]config.EmbedField, 26)
+	for i := range fields {
+		fields[i] = config.EmbedField{Name: "name", Value: "value"}
+	}
+	fields[3] = config.EmbedField{Name: strings.Repeat("n", 257), Value: strings.Repeat("v", 1025)}
+	embed := config.EmbedConfig{Title: "Report", Fields: fields}
+
+	assert.Empty(t, response.ValidateEmbed(&embed))
+
+	embed.TruncateOnOverflow = "error"
+	errs := response.ValidateEmbed(&embed)
+
+	require.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "invalid embed.fields: 26 fields exceed the maximum of 25")
+	assert.EqualError(t, errs[1], "invalid embed.fields[3].name: 257 characters exceed the maximum of 256")
+	assert.EqualError(t, errs[2], "invalid embed.fields[3].value: 1025 characters exceed the maximum of 1024")
+}
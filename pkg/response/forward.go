@@ -0,0 +1,130 @@
+package response
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+)
+
+// maxEmbedFooterLength is the Discord limit of an embed footer text
+const maxEmbedFooterLength = 2048
+
+// executeForwardResponse re-posts the content and embeds of a source message
+// to the channel of the message, with an attribution to the source channel
+func executeForwardResponse(session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig, data *TemplateContext) error {
+	channelID, err := Render(cfg.SourceChannelID, data)
+	if err != nil {
+		return err
+	}
+	messageID, err := Render(cfg.SourceMessageID, data)
+	if err != nil {
+		return err
+	}
+
+	channelID, messageID = strings.TrimSpace(channelID), strings.TrimSpace(messageID)
+	if channelID == "" || messageID == "" {
+		return boterrors.ValidationError{Field: "response.sourceMessageId", Reason: "forward response requires a source channel and message"}
+	}
+
+	// A source channel rendered from the message is chosen by the user, so it must be
+	// in the same guild and visible to them. Channels written in the config are trusted.
+	if strings.Contains(cfg.SourceChannelID, "{{") {
+		if err := checkForwardSource(session, message, channelID); err != nil {
+			return err
+		}
+	}
+
+	source, err := session.ChannelMessage(channelID, messageID)
+	if err != nil {
+		return fmt.Errorf("failed to get forwarded message: %w", boterrors.FromDiscord(err))
+	}
+
+	forward := buildForward(source, forwardAttribution(session, channelID))
+	if forward.Content == "" && len(forward.Embeds) == 0 {
+		return boterrors.ValidationError{Field: "response.sourceMessageId", Reason: "forwarded message has no content"}
+	}
+
+	if _, err := session.ChannelMessageSendComplex(message.ChannelID, forward); err != nil {
+		return fmt.Errorf("failed to send forwarded message: %w", boterrors.FromDiscord(err))
+	}
+
+	return nil
+}
+
+// checkForwardSource checks that the source channel is in the guild of the message
+// and that its author can read the messages of the channel
+func checkForwardSource(session DiscordSession, message *discordgo.Message, channelID string) error {
+	if message.Author == nil || message.GuildID == "" {
+		return boterrors.ValidationError{Field: "response.sourceChannelId", Reason: "forwarding from a user-chosen channel requires a guild message"}
+	}
+
+	channel, err := session.Channel(channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get forward source channel %s: %w", channelID, boterrors.FromDiscord(err))
+	}
+	if channel.GuildID != message.GuildID {
+		return boterrors.ValidationError{Field: "response.sourceChannelId", Reason: fmt.Sprintf("channel %s is not in this guild", channelID)}
+	}
+
+	permissions, err := session.UserChannelPermissions(message.Author.ID, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get permissions in forward source channel %s: %w", channelID, boterrors.FromDiscord(err))
+	}
+	const readable = discordgo.PermissionViewChannel | discordgo.PermissionReadMessageHistory
+	if permissions&readable != readable {
+		return boterrors.ValidationError{Field: "response.sourceChannelId", Reason: fmt.Sprintf("user cannot read channel %s", channelID)}
+	}
+	return nil
+}
+
+// forwardAttribution returns "Forwarded from #name", with the channel mention when its name is unknown
+func forwardAttribution(session DiscordSession, channelID string) string {
+	channel, err := session.Channel(channelID)
+	if err != nil || channel.Name == "" {
+		return "Forwarded from <#" + channelID + ">"
+	}
+	return "Forwarded from #" + channel.Name
+}
+
+// buildForward builds the copy of a message, the attribution is added to
+// the footer of its last embed or below its text, shortening them to the Discord limits
+func buildForward(source *discordgo.Message, attribution string) *discordgo.MessageSend {
+	content := source.Content
+	for _, attachment := range source.Attachments {
+		content = strings.TrimSpace(content + "\n" + attachment.URL)
+	}
+
+	embeds := make([]*discordgo.MessageEmbed, 0, len(source.Embeds))
+	for _, embed := range source.Embeds {
+		if embed == nil {
+			continue
+		}
+		copied := *embed
+		embeds = append(embeds, &copied)
+	}
+
+	if len(embeds) > 0 {
+		last := embeds[len(embeds)-1]
+		footer := &discordgo.MessageEmbedFooter{Text: attribution}
+		if last.Footer != nil && last.Footer.Text != "" {
+			suffix := " • " + attribution
+			footer.Text = truncateText(last.Footer.Text, maxEmbedFooterLength-utf8.RuneCountInString(suffix)) + suffix
+			footer.IconURL = last.Footer.IconURL
+		}
+		last.Footer = footer
+		content = truncateText(content, MaxMessageLength)
+	} else if content != "" {
+		suffix := "\n-# " + attribution
+		content = truncateText(content, MaxMessageLength-utf8.RuneCountInString(suffix)) + suffix
+	}
+
+	return &discordgo.MessageSend{
+		Content:         content,
+		Embeds:          embeds,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}
+}
@@ -0,0 +1,149 @@
+package response_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteForwardResponse(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	message := &discordgo.Message{
+		ChannelID: "target123",
+		Content:   "!forward 555",
+		Author:    &discordgo.User{ID: "user123", Username: "testuser"},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("ChannelMessage", "source123", "555").Return(&discordgo.Message{Content: "Release 2.0 is out <@&111>"}, nil)
+		session.On("Channel", "source123").Return(&discordgo.Channel{ID: "source123", Name: "announcements"}, nil)
+
+		var sent *discordgo.MessageSend
+		session.On("ChannelMessageSendComplex", "target123", mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(1).(*discordgo.MessageSend)
+		}).Return(&discordgo.Message{}, nil)
+
+		cfg := config.ResponseConfig{Type: "forward", SourceChannelID: "source123", SourceMessageID: "555"}
+
+		require.NoError(t, response.Execute(context.Background(), session, message, cfg, logger))
+		require.NotNil(t, sent)
+		assert.Equal(t, "Release 2.0 is out <@&111>\n-# Forwarded from #announcements", sent.Content)
+		// Mentions of the forwarded message do not ping again
+		assert.NotNil(t, sent.AllowedMentions)
+		assert.Empty(t, sent.AllowedMentions.Parse)
+	})
+
+	t.Run("embeds", func(t *testing.T) {
+		source := &discordgo.Message{
+			Embeds: []*discordgo.MessageEmbed{
+				{Title: "Release 2.0", Footer: &discordgo.MessageEmbedFooter{Text: "Changelog"}},
+			},
+		}
+
+		session := &testutil.MockDiscordSession{}
+		session.On("ChannelMessage", "source123", "msg42").Return(source, nil)
+		session.On("Channel", "source123").Return(nil, errors.New("missing access"))
+
+		var sent *discordgo.MessageSend
+		session.On("ChannelMessageSendComplex", "target123", mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(1).(*discordgo.MessageSend)
+		}).Return(&discordgo.Message{}, nil)
+
+		data := response.NewTemplateContext(message)
+		data.MessageID = "msg42"
+		cfg := config.ResponseConfig{Type: "forward", SourceChannelID: "source123", SourceMessageID: "{{.MessageID}}"}
+
+		require.NoError(t, response.ExecuteWithContext(context.Background(), session, message, cfg, data, logger))
+		require.NotNil(t, sent)
+		require.Len(t, sent.Embeds, 1)
+		assert.Equal(t, "Changelog • Forwarded from <#source123>", sent.Embeds[0].Footer.Text)
+		// The source message is not modified
+		assert.Equal(t, "Changelog", source.Embeds[0].Footer.Text)
+	})
+
+	t.Run("long text", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("ChannelMessage", "source123", "555").Return(&discordgo.Message{Content: strings.Repeat("a", 2000)}, nil)
+		session.On("Channel", "source123").Return(&discordgo.Channel{ID: "source123", Name: "announcements"}, nil)
+
+		var sent *discordgo.MessageSend
+		session.On("ChannelMessageSendComplex", "target123", mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(1).(*discordgo.MessageSend)
+		}).Return(&discordgo.Message{}, nil)
+
+		cfg := config.ResponseConfig{Type: "forward", SourceChannelID: "source123", SourceMessageID: "555"}
+
+		require.NoError(t, response.Execute(context.Background(), session, message, cfg, logger))
+		require.NotNil(t, sent)
+		assert.Equal(t, response.MaxMessageLength, utf8.RuneCountInString(sent.Content))
+		assert.True(t, strings.HasSuffix(sent.Content, "…\n-# Forwarded from #announcements"))
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("ChannelMessage", "source123", "555").Return(nil, errors.New("unknown message"))
+
+		cfg := config.ResponseConfig{Type: "forward", SourceChannelID: "source123", SourceMessageID: "555"}
+		assert.ErrorContains(t, response.Execute(context.Background(), session, message, cfg, logger), "failed to get forwarded message")
+		session.AssertNotCalled(t, "ChannelMessageSendComplex", mock.Anything, mock.Anything)
+	})
+}
+
+func TestExecuteForwardResponse_UserChosenChannel(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	message := &discordgo.Message{
+		ChannelID: "target123",
+		GuildID:   "guild123",
+		Content:   "source123",
+		Author:    &discordgo.User{ID: "user123", Username: "testuser"},
+	}
+	cfg := config.ResponseConfig{Type: "forward", SourceChannelID: "{{.Content}}", SourceMessageID: "555"}
+
+	execute := func(session *testutil.MockDiscordSession) error {
+		data := response.NewTemplateContext(message)
+		return response.ExecuteWithContext(context.Background(), session, message, cfg, data, logger)
+	}
+
+	t.Run("readable channel", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "source123").Return(&discordgo.Channel{ID: "source123", GuildID: "guild123", Name: "general"}, nil)
+		session.On("UserChannelPermissions", "user123", "source123").Return(int64(discordgo.PermissionViewChannel|discordgo.PermissionReadMessageHistory), nil)
+		session.On("ChannelMessage", "source123", "555").Return(&discordgo.Message{Content: "hello"}, nil)
+		session.On("ChannelMessageSendComplex", "target123", mock.Anything).Return(&discordgo.Message{}, nil)
+
+		require.NoError(t, execute(session))
+		session.AssertExpectations(t)
+	})
+
+	t.Run("channel of another guild", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "source123").Return(&discordgo.Channel{ID: "source123", GuildID: "guild999"}, nil)
+
+		assert.ErrorContains(t, execute(session), "channel source123 is not in this guild")
+		session.AssertNotCalled(t, "ChannelMessage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("channel hidden from the user", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "source123").Return(&discordgo.Channel{ID: "source123", GuildID: "guild123"}, nil)
+		session.On("UserChannelPermissions", "user123", "source123").Return(int64(discordgo.PermissionSendMessages), nil)
+
+		assert.ErrorContains(t, execute(session), "user cannot read channel source123")
+		session.AssertNotCalled(t, "ChannelMessage", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,487 @@
+package response
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/common/logging"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+)
+
+// Parse formats of http response bodies
+const (
+	ParseFormatJSON = "json"
+	ParseFormatText = "text"
+)
+
+// defaultHTTPTimeout is used when the http response has no timeout configured
+const defaultHTTPTimeout = 10 * time.Second
+
+// defaultInitialBackoff is the wait before the first retry of an http request
+const defaultInitialBackoff = 500 * time.Millisecond
+
+// maxBackoff caps the wait between retries of an http request
+const maxBackoff = 30 * time.Second
+
+// defaultRetryOn lists the status codes retried when none are configured
+var defaultRetryOn = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// maxHTTPResponseSize limits the response body read from http requests
+const maxHTTPResponseSize = 1 << 20
+
+// executeHTTPResponse sends an HTTP request and runs the optional follow-up response
+func executeHTTPResponse(ctx context.Context, session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig, data *TemplateContext, logger logging.Logger) error {
+	if cfg.HTTP == nil {
+		return boterrors.ValidationError{Field: "response.http", Reason: "http response requires http config"}
+	}
+
+	body, err := sendHTTPRequest(ctx, cfg.HTTP, data, logger)
+	if err != nil {
+		return err
+	}
+
+	if cfg.HTTP.ResponseMapping != nil {
+		embedCfg, err := mapHTTPResponse(body, cfg.HTTP.ResponseMapping)
+		if err != nil {
+			return err
+		}
+		// The mapped values come from the remote service and are checked like a configured embed
+		if err := errors.Join(ValidateEmbed(embedCfg)...); err != nil {
+			return fmt.Errorf("invalid embed mapped from http response: %w", err)
+		}
+
+		if _, err := session.ChannelMessageSendEmbed(message.ChannelID, BuildEmbed(embedCfg)); err != nil {
+			return fmt.Errorf("failed to send embed: %w", boterrors.FromDiscord(err))
+		}
+	}
+
+	if cfg.HTTP.ParseResponse {
+		parsed, err := parseHTTPResponse(body, cfg.HTTP.ParseFormat)
+		if err != nil {
+			return err
+		}
+		data.HTTPResponse = parsed
+	}
+
+	if cfg.HTTP.FollowUp == nil {
+		return nil
+	}
+
+	return ExecuteWithContext(ctx, session, message, *cfg.HTTP.FollowUp, data, logger)
+}
+
+// sendHTTPRequest performs the configured request, retrying failed attempts, and returns the response body
+func sendHTTPRequest(ctx context.Context, cfg *config.HTTPConfig, data *TemplateContext, logger logging.Logger) ([]byte, error) {
+	if cfg.URL == "" {
+		return nil, boterrors.ValidationError{Field: "response.http.url", Reason: "http response requires a url"}
+	}
+
+	body, err := loadHTTPBody(cfg, data)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := renderHeaders(cfg.Headers, data)
+	if err != nil {
+		return nil, err
+	}
+
+	retryOn := cfg.RetryOn
+	if len(retryOn) == 0 {
+		retryOn = defaultRetryOn
+	}
+
+	backoff := defaultInitialBackoff
+	if cfg.InitialBackoff > 0 {
+		backoff = time.Duration(min(cfg.InitialBackoff, int(maxBackoff/time.Millisecond))) * time.Millisecond
+	}
+
+	policy := defaultHTTPPolicy
+	if data != nil && data.HTTPPolicy != nil {
+		policy = data.HTTPPolicy
+	}
+
+	target, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, boterrors.ValidationError{Field: "response.http.url", Reason: err.Error()}
+	}
+
+	client, err := newHTTPClient(cfg, policy)
+	if err != nil {
+		return nil, err
+	}
+	defer client.CloseIdleConnections()
+
+	if err := policy.Check(ctx, target.Hostname()); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		respBody, status, err := doHTTPRequest(ctx, client, cfg, headers, body)
+		if err == nil {
+			return respBody, nil
+		}
+
+		retryable := status == 0 || slices.Contains(retryOn, status)
+		if !retryable || attempt >= cfg.MaxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		wait := jitter(retryBackoff(backoff, attempt))
+		logger.Debug("Retrying http request", "url", cfg.URL, "attempt", attempt+1, "wait", wait, "error", err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("http request cancelled during retry: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// loadHTTPBody renders the body of the request, read from bodyFilePath when set so the file can change between requests
+func loadHTTPBody(cfg *config.HTTPConfig, data *TemplateContext) (string, error) {
+	if cfg.BodyFilePath == "" {
+		return Render(cfg.Body, data)
+	}
+
+	content, err := readBodyFile(cfg.BodyFilePath, data)
+	if err != nil {
+		return "", err
+	}
+
+	// Binary files are sent as they are, templates only apply to text
+	if !utf8.Valid(content) || bytes.IndexByte(content, 0) >= 0 {
+		return string(content), nil
+	}
+	return Render(string(content), data)
+}
+
+// readBodyFile reads a body file, confined to the config directory so an
+// action cannot send arbitrary files of the host
+func readBodyFile(path string, data *TemplateContext) ([]byte, error) {
+	baseDir := "."
+	if data != nil && data.BaseDir != "" {
+		baseDir = data.BaseDir
+	}
+
+	if filepath.IsAbs(path) {
+		absBase, err := filepath.Abs(baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve config directory: %w", err)
+		}
+		if path, err = filepath.Rel(absBase, path); err != nil {
+			return nil, fmt.Errorf("http body file is outside the config directory: %w", err)
+		}
+	}
+
+	// The root also rejects symlinks and .. elements leaving the directory
+	root, err := os.OpenRoot(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config directory: %w", err)
+	}
+	defer root.Close()
+
+	content, err := root.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read http body file: %w", err)
+	}
+	return content, nil
+}
+
+// renderHeaders renders the templates of the header values for this execution
+func renderHeaders(headers map[string]string, data *TemplateContext) (map[string]string, error) {
+	rendered := make(map[string]string, len(headers))
+	for key, value := range headers {
+		var err error
+		if rendered[key], err = Render(value, data); err != nil {
+			return nil, fmt.Errorf("invalid header %s: %w", key, err)
+		}
+	}
+	return rendered, nil
+}
+
+// doHTTPRequest performs a single request attempt.
+// The returned status is 0 when no response was received.
+func doHTTPRequest(ctx context.Context, client *http.Client, cfg *config.HTTPConfig, headers map[string]string, body string) ([]byte, int, error) {
+	timeout := defaultHTTPTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := cfg.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), cfg.URL, strings.NewReader(body))
+	if err != nil {
+		return nil, -1, fmt.Errorf("failed to create http request: %w", err)
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// Blocked redirects and connections are not retried
+		if errors.As(err, &boterrors.BlockedHostError{}) {
+			return nil, -1, fmt.Errorf("failed to send http request: %w", err)
+		}
+		return nil, 0, fmt.Errorf("failed to send http request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseSize))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read http response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, resp.StatusCode, fmt.Errorf("http request failed with status %d", resp.StatusCode)
+	}
+
+	return respBody, resp.StatusCode, nil
+}
+
+// newHTTPClient creates the client for an http response, with TLS and proxy settings when configured.
+// Redirects and connections are checked against the policy, except the connections to the proxy.
+func newHTTPClient(cfg *config.HTTPConfig, policy *HTTPPolicy) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	client := &http.Client{Transport: transport, CheckRedirect: policy.checkRedirect}
+
+	// With a proxy the dial check applies to the proxy, the target is checked before the request
+	transport.DialContext = policy.dialContext(&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second})
+	if cfg.TLSClientCert == "" && cfg.TLSCACert == "" && !cfg.TLSSkipVerify && cfg.ProxyURL == "" {
+		return client, nil
+	}
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme: %s (must be http, https, or socks5)", proxyURL.Scheme)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.TLSSkipVerify, // #nosec G402 -- explicitly enabled by configuration
+	}
+
+	if cfg.TLSClientCert != "" {
+		certPEM, err := loadPEM(cfg.TLSClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls client certificate: %w", err)
+		}
+
+		keyPEM, err := loadPEM(cfg.TLSClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls client key: %w", err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.TLSCACert != "" {
+		caPEM, err := loadPEM(cfg.TLSCACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("invalid tls CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return client, nil
+}
+
+// loadPEM returns inline PEM data or reads it from a file path
+func loadPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(value, "vault://") {
+		return nil, fmt.Errorf("vault references require secrets manager")
+	}
+
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(value))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", value, err)
+	}
+
+	return data, nil
+}
+
+// retryBackoff doubles the initial backoff for each attempt up to maxBackoff,
+// stopping at the cap so large attempts cannot overflow
+func retryBackoff(initial time.Duration, attempt int) time.Duration {
+	wait := min(initial, maxBackoff)
+	for range attempt {
+		if wait >= maxBackoff/2 {
+			return maxBackoff
+		}
+		wait *= 2
+	}
+	return wait
+}
+
+// jitter randomizes a backoff duration between half and the full duration
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(half+1) // #nosec G404 -- jitter does not need a secure source
+}
+
+// parseHTTPResponse parses a response body into template data
+func parseHTTPResponse(body []byte, format string) (interface{}, error) {
+	switch format {
+	case "", ParseFormatJSON:
+		var parsed map[string]interface{}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse http response as JSON: %w", err)
+		}
+		return parsed, nil
+	case ParseFormatText:
+		return string(body), nil
+	default:
+		return nil, fmt.Errorf("unsupported parse format: %s", format)
+	}
+}
+
+// mapHTTPResponse builds an embed configuration from a JSON response body
+func mapHTTPResponse(body []byte, mapping *config.HTTPResponseMapping) (*config.EmbedConfig, error) {
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse http response as JSON: %w", err)
+	}
+
+	embed := &config.EmbedConfig{}
+
+	if value, ok := lookupPath(parsed, mapping.TitlePath); ok {
+		embed.Title = formatValue(value)
+	}
+
+	if value, ok := lookupPath(parsed, mapping.DescriptionPath); ok {
+		embed.Description = formatValue(value)
+	}
+
+	if value, ok := lookupPath(parsed, mapping.ColorPath); ok {
+		color, err := ParseColor(formatValue(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid color at %s: %w", mapping.ColorPath, err)
+		}
+		embed.Color = color
+	}
+
+	for _, field := range mapping.FieldMappings {
+		value, ok := lookupPath(parsed, field.ValuePath)
+		if !ok {
+			continue
+		}
+		embed.Fields = append(embed.Fields, config.EmbedField{
+			Name:   field.Name,
+			Value:  formatValue(value),
+			Inline: field.Inline,
+		})
+	}
+
+	return embed, nil
+}
+
+// lookupPath resolves a dot-notation path such as "data.items.0.name" in decoded JSON
+func lookupPath(data interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return nil, false
+	}
+
+	current := data
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, current != nil
+}
+
+// formatValue formats a decoded JSON value as text
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
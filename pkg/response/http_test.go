@@ -0,0 +1,479 @@
+package response_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newHTTPMessage() *discordgo.Message {
+	return &discordgo.Message{
+		ChannelID: "channel123",
+		Author: &discordgo.User{
+			ID:       "user123",
+			Username: "testuser",
+		},
+	}
+}
+
+// localPolicy allows the http responses of tests to reach httptest servers on loopback
+func localPolicy(t *testing.T) *response.HTTPPolicy {
+	t.Helper()
+	policy, err := response.NewHTTPPolicy([]string{"127.0.0.1"}, nil)
+	require.NoError(t, err)
+	return policy
+}
+
+// executeLocal executes a response allowed to reach httptest servers
+func executeLocal(t *testing.T, session response.DiscordSession, message *discordgo.Message, cfg config.ResponseConfig, logger *testutil.MockLogger) error {
+	t.Helper()
+	data := response.NewTemplateContext(message)
+	data.HTTPPolicy = localPolicy(t)
+	return response.ExecuteWithContext(context.Background(), session, message, cfg, data, logger)
+}
+
+func TestExecuteHTTPResponse_ParseJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"temperature": 21, "city": "Paris"}`))
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:           server.URL,
+			ParseResponse: true,
+			ParseFormat:   "json",
+			FollowUp: &config.ResponseConfig{
+				Type:    "text",
+				Content: "{{.HTTPResponse.city}}: {{.HTTPResponse.temperature}}°C",
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Paris: 21°C").Return(&discordgo.Message{}, nil)
+
+	err := executeLocal(t, session, newHTTPMessage(), cfg, logger)
+
+	require.NoError(t, err)
+	session.AssertExpectations(t)
+}
+
+func TestExecuteHTTPResponse_ParseText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "user=user123", string(body))
+		_, _ = w.Write([]byte("all good"))
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:           server.URL,
+			Method:        "post",
+			Body:          "user={{.UserID}}",
+			ParseResponse: true,
+			ParseFormat:   "text",
+			FollowUp: &config.ResponseConfig{
+				Type:    "text",
+				Content: "Status: {{.HTTPResponse}}",
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "Status: all good").Return(&discordgo.Message{}, nil)
+
+	err := executeLocal(t, session, newHTTPMessage(), cfg, logger)
+
+	require.NoError(t, err)
+	session.AssertExpectations(t)
+}
+
+func TestExecuteHTTPResponse_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{URL: server.URL},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+
+	err := executeLocal(t, session, newHTTPMessage(), cfg, logger)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+}
+
+func TestExecuteHTTPResponse_HeaderTemplates(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("X-Discord-User"))
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:     server.URL,
+			Headers: map[string]string{"X-Discord-User": "{{.Username}} ({{.UserID}})"},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+
+	require.NoError(t, executeLocal(t, session, newHTTPMessage(), cfg, logger))
+
+	message := newHTTPMessage()
+	message.Author = &discordgo.User{ID: "user456", Username: "otheruser"}
+	require.NoError(t, executeLocal(t, session, message, cfg, logger))
+
+	assert.Equal(t, []string{"testuser (user123)", "otheruser (user456)"}, received)
+}
+
+func TestExecuteHTTPResponse_InvalidHeaderTemplate(t *testing.T) {
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:     "http://127.0.0.1:1",
+			Headers: map[string]string{"Authorization": "Bearer {{.Token"},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := executeLocal(t, &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	assert.ErrorContains(t, err, "invalid header Authorization")
+}
+
+func TestExecuteHTTPResponse_BodyFile(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	bodyPath := filepath.Join(dir, "body.json")
+	require.NoError(t, os.WriteFile(bodyPath, []byte(`{"user": "{{.Username}}"}`), 0600))
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{URL: server.URL, Method: "POST", BodyFilePath: "body.json"},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	message := newHTTPMessage()
+	data := response.NewTemplateContext(message)
+	data.BaseDir = dir
+	data.HTTPPolicy = localPolicy(t)
+
+	require.NoError(t, response.ExecuteWithContext(context.Background(), &testutil.MockDiscordSession{}, message, cfg, data, logger))
+
+	require.NoError(t, os.WriteFile(bodyPath, []byte(`{"channel": "{{.ChannelID}}"}`), 0600))
+	require.NoError(t, response.ExecuteWithContext(context.Background(), &testutil.MockDiscordSession{}, message, cfg, data, logger))
+
+	assert.Equal(t, []string{`{"user": "testuser"}`, `{"channel": "channel123"}`}, received)
+}
+
+func TestExecuteHTTPResponse_MissingBodyFile(t *testing.T) {
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{URL: "http://127.0.0.1:1", BodyFilePath: "missing.json"},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	message := newHTTPMessage()
+	data := response.NewTemplateContext(message)
+	data.BaseDir = t.TempDir()
+
+	err := response.ExecuteWithContext(context.Background(), &testutil.MockDiscordSession{}, message, cfg, data, logger)
+
+	assert.ErrorContains(t, err, "failed to read http body file")
+}
+
+func TestExecuteHTTPResponse_BodyFileOutsideConfigDir(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "config")
+	require.NoError(t, os.Mkdir(dir, 0700))
+	secret := filepath.Join(parent, "secret.txt")
+	require.NoError(t, os.WriteFile(secret, []byte("secret"), 0600))
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	for _, path := range []string{"../secret.txt", secret} {
+		t.Run(path, func(t *testing.T) {
+			cfg := config.ResponseConfig{
+				Type: "http",
+				HTTP: &config.HTTPConfig{URL: "http://127.0.0.1:1", BodyFilePath: path},
+			}
+
+			message := newHTTPMessage()
+			data := response.NewTemplateContext(message)
+			data.BaseDir = dir
+
+			err := response.ExecuteWithContext(context.Background(), &testutil.MockDiscordSession{}, message, cfg, data, logger)
+
+			assert.ErrorContains(t, err, "failed to read http body file")
+		})
+	}
+}
+
+func TestExecuteHTTPResponse_BinaryBodyFile(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	content := []byte{0x89, 'P', 'N', 'G', 0x00, '{', '{'}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "image.png"), content, 0600))
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{URL: server.URL, Method: "POST", BodyFilePath: "image.png"},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	message := newHTTPMessage()
+	data := response.NewTemplateContext(message)
+	data.BaseDir = dir
+	data.HTTPPolicy = localPolicy(t)
+
+	require.NoError(t, response.ExecuteWithContext(context.Background(), &testutil.MockDiscordSession{}, message, cfg, data, logger))
+	assert.Equal(t, content, received)
+}
+
+func TestExecuteHTTPResponse_ResponseMapping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"release": {"name": "v1.2.0", "notes": "Bug fixes", "color": 3447003},
+			"stats": {"downloads": 1500, "assets": [{"name": "linux.tar.gz"}]}
+		}`))
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL: server.URL,
+			ResponseMapping: &config.HTTPResponseMapping{
+				TitlePath:       "release.name",
+				DescriptionPath: "release.notes",
+				ColorPath:       "release.color",
+				FieldMappings: []config.FieldMapping{
+					{Name: "Downloads", ValuePath: "stats.downloads", Inline: true},
+					{Name: "First asset", ValuePath: "stats.assets.0.name"},
+					{Name: "Missing", ValuePath: "stats.missing"},
+				},
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSendEmbed", "channel123", mock.MatchedBy(func(embed *discordgo.MessageEmbed) bool {
+		return embed.Title == "v1.2.0" &&
+			embed.Description == "Bug fixes" &&
+			embed.Color == 3447003 &&
+			len(embed.Fields) == 2 &&
+			embed.Fields[0].Value == "1500" &&
+			embed.Fields[0].Inline &&
+			embed.Fields[1].Value == "linux.tar.gz"
+	})).Return(&discordgo.Message{}, nil)
+
+	err := executeLocal(t, session, newHTTPMessage(), cfg, logger)
+
+	require.NoError(t, err)
+	session.AssertExpectations(t)
+}
+
+func TestExecuteHTTPResponse_ResponseMappingInvalidEmbed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"release": {"name": "v1.2.0", "notes": "[Download](javascript:alert(1))"}}`))
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL: server.URL,
+			ResponseMapping: &config.HTTPResponseMapping{
+				TitlePath:       "release.name",
+				DescriptionPath: "release.notes",
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+
+	err := executeLocal(t, session, newHTTPMessage(), cfg, logger)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid embed mapped from http response")
+	session.AssertNotCalled(t, "ChannelMessageSendEmbed", mock.Anything, mock.Anything)
+}
+
+func TestExecuteHTTPResponse_Retry(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:            server.URL,
+			MaxRetries:     3,
+			InitialBackoff: 1,
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := executeLocal(t, &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Contains(t, logger.DebugMessages, "Retrying http request")
+}
+
+func TestExecuteHTTPResponse_NoRetryOnClientError(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:            server.URL,
+			MaxRetries:     3,
+			InitialBackoff: 1,
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := executeLocal(t, &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestExecuteHTTPResponse_Proxy(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("direct"))
+	}))
+	defer target.Close()
+
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		_, _ = w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:           target.URL,
+			ProxyURL:      proxy.URL,
+			ParseResponse: true,
+			ParseFormat:   "text",
+			FollowUp: &config.ResponseConfig{
+				Type:    "text",
+				Content: "{{.HTTPResponse}}",
+			},
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", "proxied").Return(&discordgo.Message{}, nil)
+
+	err := executeLocal(t, session, newHTTPMessage(), cfg, logger)
+
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimPrefix(target.URL, "http://"), proxiedHost)
+	session.AssertExpectations(t)
+}
+
+func TestExecuteHTTPResponse_UnsupportedProxyScheme(t *testing.T) {
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:      "http://example.com",
+			ProxyURL: "ftp://proxy.example.com",
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := executeLocal(t, &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported proxy scheme")
+}
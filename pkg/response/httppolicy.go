@@ -0,0 +1,227 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+)
+
+// defaultDeniedNetworks are the loopback, private, link-local and cloud metadata networks
+// http responses cannot reach unless allowlisted
+var defaultDeniedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"127.0.0.0/8",
+	"::1/128",
+	"::/128",
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"169.254.0.0/16",
+	"100.64.0.0/10",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+// defaultDeniedHosts are the cloud metadata hostnames http responses cannot reach unless allowlisted
+var defaultDeniedHosts = []string{"metadata.google.internal", "metadata.azure.internal"}
+
+// HTTPPolicy decides which hosts http responses may reach.
+// Hosts and addresses matching the denylist are always blocked, private networks are blocked unless
+// they match the allowlist, and a non-empty allowlist blocks everything it does not match.
+type HTTPPolicy struct {
+	allow hostPatterns
+	deny  hostPatterns
+}
+
+// hostPatterns matches hosts against networks and hostnames, "*.example.com" matching the subdomains
+type hostPatterns struct {
+	networks []*net.IPNet
+	hosts    []string
+}
+
+// NewHTTPPolicy creates a policy from CIDR, IP or hostname patterns
+func NewHTTPPolicy(allowlist, denylist []string) (*HTTPPolicy, error) {
+	allow, err := parseHostPatterns(allowlist)
+	if err != nil {
+		return nil, fmt.Errorf("invalid httpAllowlist: %w", err)
+	}
+	deny, err := parseHostPatterns(denylist)
+	if err != nil {
+		return nil, fmt.Errorf("invalid httpDenylist: %w", err)
+	}
+	return &HTTPPolicy{allow: allow, deny: deny}, nil
+}
+
+// defaultHTTPPolicy is used when no policy is configured
+var defaultHTTPPolicy = &HTTPPolicy{}
+
+// Check resolves the host and returns a BlockedHostError if the policy does not allow requests to it
+func (p *HTTPPolicy) Check(ctx context.Context, host string) error {
+	_, err := p.resolve(ctx, host)
+	return err
+}
+
+// resolve returns the addresses of the host after checking them against the policy
+func (p *HTTPPolicy) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	if p.deny.matchHost(host) {
+		return nil, boterrors.BlockedHostError{Host: host, Reason: "host is in the httpDenylist"}
+	}
+	allowed := p.allow.matchHost(host)
+	if !allowed && matchHostname(defaultDeniedHosts, host) {
+		return nil, boterrors.BlockedHostError{Host: host, Reason: "cloud metadata host"}
+	}
+
+	ips, err := lookupIPs(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ip := range ips {
+		if p.deny.matchIP(ip) {
+			return nil, boterrors.BlockedHostError{Host: host, Reason: fmt.Sprintf("address %s is in the httpDenylist", ip)}
+		}
+		if allowed || p.allow.matchIP(ip) {
+			continue
+		}
+		if len(p.allow.networks) > 0 || len(p.allow.hosts) > 0 {
+			return nil, boterrors.BlockedHostError{Host: host, Reason: "host is not in the httpAllowlist"}
+		}
+		if matchNetworks(defaultDeniedNetworks, ip) {
+			return nil, boterrors.BlockedHostError{Host: host, Reason: fmt.Sprintf("address %s is not public", ip)}
+		}
+	}
+	return ips, nil
+}
+
+// dialContext dials the checked addresses of the host, so a DNS answer cannot change between the check and the connection
+func (p *HTTPPolicy) dialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		ips, err := p.resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var dialErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			dialErr = err
+		}
+		return nil, dialErr
+	}
+}
+
+// maxRedirects is the number of redirects followed by http responses, as by default
+const maxRedirects = 10
+
+// checkRedirect follows up to maxRedirects redirects to the hosts allowed by the policy
+func (p *HTTPPolicy) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return p.Check(req.Context(), req.URL.Hostname())
+}
+
+// lookupIPs returns the address of an IP literal or resolves the hostname
+func lookupIPs(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+
+	ips := make([]net.IP, len(addrs))
+	for i, addr := range addrs {
+		ips[i] = addr.IP
+	}
+	return ips, nil
+}
+
+// parseHostPatterns parses CIDR, IP and hostname patterns
+func parseHostPatterns(patterns []string) (hostPatterns, error) {
+	var parsed hostPatterns
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		switch {
+		case pattern == "":
+			return hostPatterns{}, fmt.Errorf("empty pattern")
+		case strings.Contains(pattern, "/"):
+			_, network, err := net.ParseCIDR(pattern)
+			if err != nil {
+				return hostPatterns{}, fmt.Errorf("invalid CIDR %q", pattern)
+			}
+			parsed.networks = append(parsed.networks, network)
+		case net.ParseIP(pattern) != nil:
+			ip := net.ParseIP(pattern)
+			parsed.networks = append(parsed.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+		default:
+			parsed.hosts = append(parsed.hosts, strings.ToLower(pattern))
+		}
+	}
+	return parsed, nil
+}
+
+// matchHost reports whether the hostname matches a hostname pattern
+func (h hostPatterns) matchHost(host string) bool {
+	return matchHostname(h.hosts, host)
+}
+
+// matchIP reports whether the address is in one of the networks
+func (h hostPatterns) matchIP(ip net.IP) bool {
+	return matchNetworks(h.networks, ip)
+}
+
+// matchHostname reports whether the hostname equals a pattern or is a subdomain of a "*." pattern
+func matchHostname(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// matchNetworks reports whether the address is in one of the networks
+func matchNetworks(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// mustParseCIDRs parses networks known to be valid
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
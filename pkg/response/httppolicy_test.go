@@ -0,0 +1,156 @@
+package response_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		allow   []string
+		deny    []string
+		host    string
+		blocked bool
+	}{
+		{name: "AWS metadata", host: "169.254.169.254", blocked: true},
+		{name: "EC2 IPv6 metadata", host: "fd00:ec2::254", blocked: true},
+		{name: "GCP metadata hostname", host: "metadata.google.internal", blocked: true},
+		{name: "private network", host: "10.1.2.3", blocked: true},
+		{name: "home network", host: "192.168.1.1", blocked: true},
+		{name: "mapped private address", host: "::ffff:172.16.0.1", blocked: true},
+		{name: "loopback", host: "127.0.0.1", blocked: true},
+		{name: "IPv6 loopback", host: "::1", blocked: true},
+		{name: "this network", host: "0.0.0.0", blocked: true},
+		{name: "mapped loopback", host: "::ffff:127.0.0.1", blocked: true},
+		{name: "allowlisted loopback", allow: []string{"127.0.0.1"}, host: "127.0.0.1"},
+		{name: "public address", host: "8.8.8.8"},
+		{name: "allowlisted private network", allow: []string{"10.0.0.0/8"}, host: "10.1.2.3"},
+		{name: "not allowlisted", allow: []string{"10.0.0.0/8"}, host: "8.8.8.8", blocked: true},
+		{name: "allowlisted address", allow: []string{"169.254.169.254"}, host: "169.254.169.254"},
+		{name: "denylisted within allowlist", allow: []string{"10.0.0.0/8"}, deny: []string{"10.0.0.5"}, host: "10.0.0.5", blocked: true},
+		{name: "denylisted loopback", deny: []string{"127.0.0.0/8"}, host: "127.0.0.1", blocked: true},
+		{name: "denylisted hostname", deny: []string{"*.internal.example"}, host: "api.internal.example", blocked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := response.NewHTTPPolicy(tt.allow, tt.deny)
+			require.NoError(t, err)
+
+			err = policy.Check(context.Background(), tt.host)
+			if !tt.blocked {
+				assert.NoError(t, err)
+				return
+			}
+			var blocked boterrors.BlockedHostError
+			assert.ErrorAs(t, err, &blocked)
+		})
+	}
+}
+
+func TestNewHTTPPolicy_InvalidPattern(t *testing.T) {
+	_, err := response.NewHTTPPolicy([]string{"10.0.0.0/33"}, nil)
+	assert.ErrorContains(t, err, "invalid httpAllowlist")
+
+	_, err = response.NewHTTPPolicy(nil, []string{" "})
+	assert.ErrorContains(t, err, "invalid httpDenylist")
+}
+
+func TestExecuteHTTPResponse_MetadataBlocked(t *testing.T) {
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{URL: "http://169.254.169.254/latest/meta-data/iam/security-credentials/", MaxRetries: 2},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := response.Execute(context.Background(), &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	var blocked boterrors.BlockedHostError
+	require.ErrorAs(t, err, &blocked)
+	assert.Equal(t, "169.254.169.254", blocked.Host)
+	logger.AssertNotCalled(t, "Debug", "Retrying http request", mock.Anything)
+}
+
+func TestExecuteHTTPResponse_RedirectBlocked(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{URL: server.URL, MaxRetries: 2, InitialBackoff: 1},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := executeLocal(t, &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	var blocked boterrors.BlockedHostError
+	require.ErrorAs(t, err, &blocked)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestExecuteHTTPResponse_Denylist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("denylisted server must not be reached")
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{URL: server.URL},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	policy, err := response.NewHTTPPolicy(nil, []string{"127.0.0.0/8"})
+	require.NoError(t, err)
+
+	message := newHTTPMessage()
+	data := response.NewTemplateContext(message)
+	data.HTTPPolicy = policy
+
+	err = response.ExecuteWithContext(context.Background(), &testutil.MockDiscordSession{}, message, cfg, data, logger)
+
+	assert.ErrorContains(t, err, "http request to 127.0.0.1 blocked: address 127.0.0.1 is in the httpDenylist")
+}
+
+func TestExecuteHTTPResponse_ProxyChecked(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("proxy on loopback must not be reached")
+	}))
+	defer proxy.Close()
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{URL: "http://8.8.8.8/", ProxyURL: proxy.URL},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := response.Execute(context.Background(), &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	var blocked boterrors.BlockedHostError
+	require.ErrorAs(t, err, &blocked)
+	assert.Equal(t, "127.0.0.1", blocked.Host)
+}
@@ -0,0 +1,63 @@
+package response
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+)
+
+// Preview renders the templates of a response and describes what it would send, without sending anything.
+// Webhook URLs are left out since they hold the webhook token.
+func Preview(cfg config.ResponseConfig, data *TemplateContext) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Type: %s\n", cfg.Type)
+
+	if cfg.Content != "" {
+		content, err := Render(cfg.Content, data)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "Content: %s\n", content)
+	}
+
+	if cfg.Embed != nil {
+		embed, err := renderEmbed(cfg.Embed, data)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "Embed title: %s\n", embed.Title)
+		if embed.Description != "" {
+			fmt.Fprintf(&b, "Embed description: %s\n", embed.Description)
+		}
+		for _, field := range embed.Fields {
+			fmt.Fprintf(&b, "Embed field %s: %s\n", field.Name, field.Value)
+		}
+	}
+
+	if cfg.Reaction != "" {
+		fmt.Fprintf(&b, "Reaction: %s\n", cfg.Reaction)
+	}
+
+	if cfg.TargetChannelID != "" {
+		fmt.Fprintf(&b, "Target: channel %s in guild %s\n", cfg.TargetChannelID, cfg.TargetGuildID)
+	}
+
+	if cfg.HTTP != nil {
+		method := cfg.HTTP.Method
+		if method == "" {
+			method = "GET"
+		}
+		fmt.Fprintf(&b, "Request: %s %s\n", strings.ToUpper(method), cfg.HTTP.URL)
+
+		body, err := loadHTTPBody(cfg.HTTP, data)
+		if err != nil {
+			return "", err
+		}
+		if body != "" {
+			fmt.Fprintf(&b, "Body: %s\n", body)
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
@@ -0,0 +1,47 @@
+package response_test
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreview(t *testing.T) {
+	data := response.NewTemplateContext(&discordgo.Message{
+		ChannelID: "channel123",
+		Author:    &discordgo.User{ID: "user123", Username: "testuser"},
+	})
+
+	preview, err := response.Preview(config.ResponseConfig{
+		Type: "embed",
+		Embed: &config.EmbedConfig{
+			Title:       "Profile of {{.Username}}",
+			Description: "ID {{.UserID}}",
+			Fields:      []config.EmbedField{{Name: "Channel", Value: "<#{{.ChannelID}}>"}},
+		},
+	}, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Type: embed\nEmbed title: Profile of testuser\nEmbed description: ID user123\nEmbed field Channel: <#channel123>", preview)
+
+	preview, err = response.Preview(config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{URL: "https://api.example.com/notify", Method: "post", Body: `{"user": "{{.Username}}"}`},
+	}, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Type: http\nRequest: POST https://api.example.com/notify\nBody: {\"user\": \"testuser\"}", preview)
+
+	preview, err = response.Preview(config.ResponseConfig{
+		Type:       "webhook",
+		WebhookURL: "https://discord.com/api/webhooks/1/secret",
+		Content:    "Hi",
+	}, data)
+	require.NoError(t, err)
+	assert.NotContains(t, preview, "secret")
+
+	_, err = response.Preview(config.ResponseConfig{Type: "text", Content: "{{.Missing"}, data)
+	assert.Error(t, err)
+}
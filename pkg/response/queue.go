@@ -0,0 +1,219 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord allows 5 messages per 5 seconds in a channel
+const (
+	DefaultChannelBurst    = 5
+	DefaultChannelInterval = 5 * time.Second
+)
+
+// Size of the queue of a channel and idle time after which its goroutine exits
+const (
+	channelQueueSize   = 100
+	channelIdleTimeout = time.Minute
+)
+
+// SendRequest is a message waiting in the queue of a channel
+type SendRequest struct {
+	ChannelID string
+	send      func() (*discordgo.Message, error)
+	result    chan sendResult
+}
+
+// sendResult is the outcome of a SendRequest
+type sendResult struct {
+	message *discordgo.Message
+	err     error
+}
+
+// Queue sends messages in order per channel, spacing them with a token bucket
+// per channel so the Discord rate limit is not hit
+type Queue struct {
+	burst    int
+	interval time.Duration
+
+	channels map[string]chan SendRequest
+	closed   bool
+	pending  sync.WaitGroup
+	mu       sync.Mutex
+}
+
+// NewQueue creates a queue allowing burst messages per interval in each channel
+func NewQueue(burst int, interval time.Duration) *Queue {
+	return &Queue{
+		burst:    burst,
+		interval: interval,
+		channels: make(map[string]chan SendRequest),
+	}
+}
+
+// Wrap returns a session whose channel messages go through the queue
+func (q *Queue) Wrap(session DiscordSession) DiscordSession {
+	return &queuedSession{DiscordSession: session, queue: q}
+}
+
+// Send queues a message and waits until it is sent or ctx is done
+func (q *Queue) Send(ctx context.Context, channelID string, send func() (*discordgo.Message, error)) (*discordgo.Message, error) {
+	request := SendRequest{
+		ChannelID: channelID,
+		send:      send,
+		result:    make(chan sendResult, 1),
+	}
+
+	if err := q.enqueue(request); err != nil {
+		return nil, err
+	}
+
+	select {
+	case result := <-request.result:
+		return result.message, result.err
+	case <-ctx.Done():
+		// The message is still sent, only the caller stops waiting
+		return nil, ctx.Err()
+	}
+}
+
+// Drain stops accepting messages and waits up to timeout for the queued ones to be sent
+func (q *Queue) Drain(timeout time.Duration) error {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s waiting for queued messages", timeout)
+	}
+}
+
+// enqueue adds a request to the queue of its channel, starting the channel goroutine if needed
+func (q *Queue) enqueue(request SendRequest) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return fmt.Errorf("response queue is closed")
+	}
+
+	requests, ok := q.channels[request.ChannelID]
+	if !ok {
+		requests = make(chan SendRequest, channelQueueSize)
+		q.channels[request.ChannelID] = requests
+		go q.run(request.ChannelID, requests)
+	}
+
+	// Counted before the send so the channel goroutine cannot call Done first,
+	// Drain sets closed under the same lock so no Add races with its Wait
+	q.pending.Add(1)
+	select {
+	case requests <- request:
+		return nil
+	default:
+		q.pending.Done()
+		return fmt.Errorf("response queue of channel %s is full", request.ChannelID)
+	}
+}
+
+// run sends the requests of a channel until it is idle
+func (q *Queue) run(channelID string, requests chan SendRequest) {
+	bucket := newTokenBucket(q.burst, q.interval)
+	idle := time.NewTimer(channelIdleTimeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case request := <-requests:
+			bucket.wait()
+			message, err := request.send()
+			request.result <- sendResult{message: message, err: err}
+			q.pending.Done()
+			idle.Reset(channelIdleTimeout)
+		case <-idle.C:
+			// Requests are only added under the lock, so an empty queue stays empty
+			q.mu.Lock()
+			if len(requests) == 0 {
+				delete(q.channels, channelID)
+				q.mu.Unlock()
+				return
+			}
+			q.mu.Unlock()
+			idle.Reset(channelIdleTimeout)
+		}
+	}
+}
+
+// tokenBucket allows burst events at once, refilled at burst per interval
+type tokenBucket struct {
+	capacity float64
+	tokens   float64
+	refill   time.Duration
+	last     time.Time
+}
+
+// newTokenBucket creates a full token bucket
+func newTokenBucket(burst int, interval time.Duration) *tokenBucket {
+	return &tokenBucket{
+		capacity: float64(burst),
+		tokens:   float64(burst),
+		refill:   interval / time.Duration(burst),
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a token is available and takes it
+func (b *tokenBucket) wait() {
+	now := time.Now()
+	b.tokens = min(b.capacity, b.tokens+float64(now.Sub(b.last))/float64(b.refill))
+	b.last = now
+
+	if b.tokens < 1 {
+		delay := time.Duration((1 - b.tokens) * float64(b.refill))
+		time.Sleep(delay)
+		b.tokens = 1
+		b.last = now.Add(delay)
+	}
+
+	b.tokens--
+}
+
+// queuedSession sends channel messages through a queue
+type queuedSession struct {
+	DiscordSession
+	queue *Queue
+}
+
+// ChannelMessageSend queues a text message
+func (s *queuedSession) ChannelMessageSend(channelID, content string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return s.queue.Send(context.Background(), channelID, func() (*discordgo.Message, error) {
+		return s.DiscordSession.ChannelMessageSend(channelID, content, options...)
+	})
+}
+
+// ChannelMessageSendComplex queues a message with attachments such as stickers
+func (s *queuedSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return s.queue.Send(context.Background(), channelID, func() (*discordgo.Message, error) {
+		return s.DiscordSession.ChannelMessageSendComplex(channelID, data, options...)
+	})
+}
+
+// ChannelMessageSendEmbed queues an embed message
+func (s *queuedSession) ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return s.queue.Send(context.Background(), channelID, func() (*discordgo.Message, error) {
+		return s.DiscordSession.ChannelMessageSendEmbed(channelID, embed, options...)
+	})
+}
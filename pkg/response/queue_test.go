@@ -0,0 +1,88 @@
+package response_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue_PacesMessagesPerChannel(t *testing.T) {
+	var mu sync.Mutex
+	sentAt := make(map[string][]time.Time)
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		channelID := args.String(0)
+		sentAt[channelID] = append(sentAt[channelID], time.Now())
+	}).Return(&discordgo.Message{}, nil)
+
+	// 2 messages per 100ms in each channel
+	queue := response.NewQueue(2, 100*time.Millisecond)
+	queued := queue.Wrap(session)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range 4 {
+		for _, channelID := range []string{"a", "b"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := queued.ChannelMessageSend(channelID, "hello")
+				assert.NoError(t, err)
+			}()
+		}
+	}
+	wg.Wait()
+
+	for _, channelID := range []string{"a", "b"} {
+		times := sentAt[channelID]
+		require.Len(t, times, 4)
+		// The burst is sent at once, the next messages wait for tokens
+		assert.Less(t, times[1].Sub(start), 40*time.Millisecond)
+		assert.GreaterOrEqual(t, times[2].Sub(start), 45*time.Millisecond)
+		assert.GreaterOrEqual(t, times[3].Sub(start), 95*time.Millisecond)
+	}
+}
+
+func TestQueue_Drain(t *testing.T) {
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "a", "hello").After(50*time.Millisecond).Return(&discordgo.Message{}, nil)
+
+	queue := response.NewQueue(response.DefaultChannelBurst, response.DefaultChannelInterval)
+
+	go func() {
+		_, _ = queue.Send(context.Background(), "a", func() (*discordgo.Message, error) {
+			return session.ChannelMessageSend("a", "hello")
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	require.NoError(t, queue.Drain(time.Second))
+	session.AssertNumberOfCalls(t, "ChannelMessageSend", 1)
+
+	_, err := queue.Wrap(session).ChannelMessageSend("a", "hello")
+	assert.ErrorContains(t, err, "closed")
+}
+
+func TestQueue_DrainTimeout(t *testing.T) {
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "a", "hello").After(200*time.Millisecond).Return(&discordgo.Message{}, nil)
+
+	queue := response.NewQueue(response.DefaultChannelBurst, response.DefaultChannelInterval)
+	go func() {
+		_, _ = queue.Wrap(session).ChannelMessageSend("a", "hello")
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	assert.Error(t, queue.Drain(20*time.Millisecond))
+}
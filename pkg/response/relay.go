@@ -0,0 +1,87 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+)
+
+// executeRelayResponse sends the content or embed to the target channel, which may be in another guild.
+// Mentions in the relayed message do not notify anyone in the target guild.
+func executeRelayResponse(session DiscordSession, cfg config.ResponseConfig, data *TemplateContext) error {
+	guildID, channelID := strings.TrimSpace(cfg.TargetGuildID), strings.TrimSpace(cfg.TargetChannelID)
+	if guildID == "" || channelID == "" {
+		return boterrors.ValidationError{Field: "response.targetChannelId", Reason: "relay response requires a target guild and channel"}
+	}
+
+	relay := &discordgo.MessageSend{AllowedMentions: &discordgo.MessageAllowedMentions{}}
+
+	content, err := Render(cfg.Content, data)
+	if err != nil {
+		return err
+	}
+	relay.Content = content
+
+	if cfg.Embed != nil {
+		embedCfg, err := renderEmbed(cfg.Embed, data)
+		if err != nil {
+			return err
+		}
+		if err := errors.Join(ValidateEmbed(embedCfg)...); err != nil {
+			return err
+		}
+		relay.Embeds = []*discordgo.MessageEmbed{BuildEmbed(embedCfg)}
+	}
+
+	if relay.Content == "" && len(relay.Embeds) == 0 {
+		return boterrors.ValidationError{Field: "response.content", Reason: "relay response requires content or an embed"}
+	}
+
+	var botUserID string
+	if data != nil {
+		botUserID = data.BotUserID
+	}
+	if err := checkRelayTarget(session, botUserID, guildID, channelID); err != nil {
+		return err
+	}
+
+	if _, err := session.ChannelMessageSendComplex(channelID, relay); err != nil {
+		return fmt.Errorf("failed to send relayed message: %w", boterrors.FromDiscord(err))
+	}
+
+	return nil
+}
+
+// checkRelayTarget checks that the channel belongs to the guild, that the bot
+// is a member of the guild and that it can send messages to the channel
+func checkRelayTarget(session DiscordSession, botUserID, guildID, channelID string) error {
+	if botUserID == "" {
+		return fmt.Errorf("cannot relay to channel %s before the bot is ready", channelID)
+	}
+
+	channel, err := session.Channel(channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get relay channel %s: %w", channelID, boterrors.FromDiscord(err))
+	}
+	if channel.GuildID != guildID {
+		return boterrors.ValidationError{Field: "response.targetChannelId", Reason: fmt.Sprintf("channel %s is not in guild %s", channelID, guildID)}
+	}
+
+	if _, err := session.GuildMember(guildID, botUserID); err != nil {
+		return fmt.Errorf("bot is not a member of guild %s: %w", guildID, boterrors.FromDiscord(err))
+	}
+
+	permissions, err := session.UserChannelPermissions(botUserID, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to get permissions in relay channel %s: %w", channelID, boterrors.FromDiscord(err))
+	}
+	if permissions&discordgo.PermissionSendMessages == 0 {
+		return fmt.Errorf("bot lacks the sendMessages permission in relay channel %s", channelID)
+	}
+
+	return nil
+}
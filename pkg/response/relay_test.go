@@ -0,0 +1,95 @@
+package response_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteRelayResponse(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	message := &discordgo.Message{
+		ChannelID: "source123",
+		GuildID:   "guild123",
+		Author:    &discordgo.User{ID: "user123", Username: "testuser"},
+	}
+	cfg := config.ResponseConfig{
+		Type:            "relay",
+		Content:         "Announcement from {{.Username}} <@&111>",
+		Embed:           &config.EmbedConfig{Title: "Release 2.0"},
+		TargetGuildID:   "guild456",
+		TargetChannelID: "channel456",
+	}
+
+	execute := func(session *testutil.MockDiscordSession) error {
+		data := response.NewTemplateContext(message)
+		data.BotUserID = "bot999"
+		return response.ExecuteWithContext(context.Background(), session, message, cfg, data, logger)
+	}
+
+	t.Run("sent to the other guild", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "channel456").Return(&discordgo.Channel{ID: "channel456", GuildID: "guild456"}, nil)
+		session.On("GuildMember", "guild456", "bot999").Return(&discordgo.Member{}, nil)
+		session.On("UserChannelPermissions", "bot999", "channel456").Return(int64(discordgo.PermissionViewChannel|discordgo.PermissionSendMessages), nil)
+
+		var sent *discordgo.MessageSend
+		session.On("ChannelMessageSendComplex", "channel456", mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(1).(*discordgo.MessageSend)
+		}).Return(&discordgo.Message{}, nil)
+
+		require.NoError(t, execute(session))
+		require.NotNil(t, sent)
+		assert.Equal(t, "Announcement from testuser <@&111>", sent.Content)
+		require.Len(t, sent.Embeds, 1)
+		assert.Equal(t, "Release 2.0", sent.Embeds[0].Title)
+		assert.NotNil(t, sent.AllowedMentions)
+		assert.Empty(t, sent.AllowedMentions.Parse)
+	})
+
+	t.Run("channel of another guild", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "channel456").Return(&discordgo.Channel{ID: "channel456", GuildID: "guild789"}, nil)
+
+		assert.ErrorContains(t, execute(session), "channel channel456 is not in guild guild456")
+		session.AssertNotCalled(t, "ChannelMessageSendComplex", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "channel456").Return(&discordgo.Channel{ID: "channel456", GuildID: "guild456"}, nil)
+		session.On("GuildMember", "guild456", "bot999").Return(nil, errors.New("unknown member"))
+
+		assert.ErrorContains(t, execute(session), "bot is not a member of guild guild456")
+		session.AssertNotCalled(t, "ChannelMessageSendComplex", mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing send permission", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+		session.On("Channel", "channel456").Return(&discordgo.Channel{ID: "channel456", GuildID: "guild456"}, nil)
+		session.On("GuildMember", "guild456", "bot999").Return(&discordgo.Member{}, nil)
+		session.On("UserChannelPermissions", "bot999", "channel456").Return(int64(discordgo.PermissionViewChannel), nil)
+
+		assert.ErrorContains(t, execute(session), "bot lacks the sendMessages permission in relay channel channel456")
+		session.AssertNotCalled(t, "ChannelMessageSendComplex", mock.Anything, mock.Anything)
+	})
+
+	t.Run("bot not ready", func(t *testing.T) {
+		session := &testutil.MockDiscordSession{}
+
+		err := response.Execute(context.Background(), session, message, cfg, logger)
+
+		assert.ErrorContains(t, err, "before the bot is ready")
+		session.AssertNotCalled(t, "ChannelMessageSendComplex", mock.Anything, mock.Anything)
+	})
+}
@@ -3,90 +3,148 @@ package response
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/geekxflood/common/logging"
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
 )
 
 // DiscordSession defines the interface for Discord session methods we need
 type DiscordSession interface {
 	ChannelMessageSend(channelID, content string, options ...discordgo.RequestOption) (*discordgo.Message, error)
 	ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error)
 	UserChannelCreate(userID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
 	MessageReactionAdd(channelID, messageID, emojiID string, options ...discordgo.RequestOption) error
+	MessageReactions(channelID, messageID, emojiID string, limit int, beforeID, afterID string, options ...discordgo.RequestOption) ([]*discordgo.User, error)
+	ChannelMessageDelete(channelID, messageID string, options ...discordgo.RequestOption) error
+	GuildMember(guildID, userID string, options ...discordgo.RequestOption) (*discordgo.Member, error)
+	UserChannelPermissions(userID, channelID string, options ...discordgo.RequestOption) (int64, error)
+	GuildMemberRoleAdd(guildID, userID, roleID string, options ...discordgo.RequestOption) error
+	GuildMemberRoleRemove(guildID, userID, roleID string, options ...discordgo.RequestOption) error
+	Guild(guildID string, options ...discordgo.RequestOption) (*discordgo.Guild, error)
+	Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	ChannelMessage(channelID, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error)
 }
 
 // Execute executes a response based on the configuration
 func Execute(ctx context.Context, session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig, logger logging.Logger) error {
+	return ExecuteWithContext(ctx, session, message, cfg, NewTemplateContext(message), logger)
+}
+
+// ExecuteWithContext executes a response, rendering its templates with the given context
+func ExecuteWithContext(ctx context.Context, session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig, data *TemplateContext, logger logging.Logger) error {
 	logger.Debug("Executing response", "type", cfg.Type)
 
 	switch cfg.Type {
 	case "text":
-		return executeTextResponse(session, message, cfg)
+		return executeTextResponse(session, message, cfg, data)
 	case "embed":
-		return executeEmbedResponse(session, message, cfg)
+		return executeEmbedResponse(session, message, cfg, data)
 	case "dm":
-		return executeDMResponse(session, message, cfg)
+		return executeDMResponse(session, message, cfg, data)
 	case "reaction":
 		return executeReactionResponse(session, message, cfg)
+	case "sticker":
+		return executeStickerResponse(session, message, cfg, data)
+	case "forward":
+		return executeForwardResponse(session, message, cfg, data)
+	case "relay":
+		return executeRelayResponse(session, cfg, data)
+	case "http":
+		return executeHTTPResponse(ctx, session, message, cfg, data, logger)
+	case "webhook":
+		return executeWebhookResponse(ctx, cfg, data)
 	default:
-		return fmt.Errorf("unsupported response type: %s", cfg.Type)
+		return boterrors.ValidationError{Field: "response.type", Reason: fmt.Sprintf("unsupported response type: %s", cfg.Type)}
 	}
 }
 
 // executeTextResponse sends a text message to the channel
-func executeTextResponse(session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig) error {
+func executeTextResponse(session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig, data *TemplateContext) error {
 	if cfg.Content == "" {
-		return fmt.Errorf("text response requires non-empty content")
+		return boterrors.ValidationError{Field: "response.content", Reason: "text response requires non-empty content"}
 	}
 
-	_, err := session.ChannelMessageSend(message.ChannelID, cfg.Content)
+	content, err := Render(cfg.Content, data)
 	if err != nil {
-		return fmt.Errorf("failed to send text message: %w", err)
+		return err
+	}
+
+	// Messages over the Discord limit are sent as sequential chunks
+	for _, chunk := range SplitMessage(content, MaxMessageLength) {
+		if _, err := session.ChannelMessageSend(message.ChannelID, chunk); err != nil {
+			return fmt.Errorf("failed to send text message: %w", boterrors.FromDiscord(err))
+		}
 	}
 
 	return nil
 }
 
 // executeEmbedResponse sends an embed message to the channel
-func executeEmbedResponse(session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig) error {
+func executeEmbedResponse(session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig, data *TemplateContext) error {
 	if cfg.Embed == nil {
-		return fmt.Errorf("embed response requires non-nil embed config is nil")
+		return boterrors.ValidationError{Field: "response.embed", Reason: "embed response requires an embed config"}
+	}
+
+	embedCfg, err := renderEmbed(cfg.Embed, data)
+	if err != nil {
+		return err
+	}
+	if err := errors.Join(ValidateEmbed(embedCfg)...); err != nil {
+		return err
 	}
 
-	embed := BuildEmbed(cfg.Embed)
+	embed := BuildEmbed(embedCfg)
 
-	_, err := session.ChannelMessageSendEmbed(message.ChannelID, embed)
+	_, err = session.ChannelMessageSendEmbed(message.ChannelID, embed)
 	if err != nil {
-		return fmt.Errorf("failed to send embed: %w", err)
+		return fmt.Errorf("failed to send embed: %w", boterrors.FromDiscord(err))
 	}
 
 	return nil
 }
 
 // executeDMResponse sends a direct message to the user
-func executeDMResponse(session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig) error {
+func executeDMResponse(session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig, data *TemplateContext) error {
 	// Create DM channel
 	channel, err := session.UserChannelCreate(message.Author.ID)
 	if err != nil {
-		return fmt.Errorf("failed to create DM channel: %w", err)
+		return fmt.Errorf("failed to create DM channel: %w", boterrors.FromDiscord(err))
 	}
 
 	// Send message to DM channel
 	content := cfg.Content
 	if content == "" && cfg.Embed != nil {
 		// If no content but embed exists, send embed
-		embed := BuildEmbed(cfg.Embed)
+		embedCfg, renderErr := renderEmbed(cfg.Embed, data)
+		if renderErr != nil {
+			return renderErr
+		}
+		if invalid := errors.Join(ValidateEmbed(embedCfg)...); invalid != nil {
+			return invalid
+		}
+		embed := BuildEmbed(embedCfg)
 		_, err = session.ChannelMessageSendEmbed(channel.ID, embed)
 	} else {
-		_, err = session.ChannelMessageSend(channel.ID, content)
+		content, err = Render(content, data)
+		if err != nil {
+			return err
+		}
+		for _, chunk := range SplitMessage(content, MaxMessageLength) {
+			if _, err = session.ChannelMessageSend(channel.ID, chunk); err != nil {
+				break
+			}
+		}
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to send DM: %w", err)
+		return fmt.Errorf("failed to send DM: %w", boterrors.FromDiscord(err))
 	}
 
 	return nil
@@ -95,12 +153,38 @@ func executeDMResponse(session DiscordSession, message *discordgo.Message, cfg c
 // executeReactionResponse adds a reaction to the message
 func executeReactionResponse(session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig) error {
 	if cfg.Reaction == "" {
-		return fmt.Errorf("reaction response requires non-empty reaction")
+		return boterrors.ValidationError{Field: "response.reaction", Reason: "reaction response requires non-empty reaction"}
 	}
 
 	err := session.MessageReactionAdd(message.ChannelID, message.ID, cfg.Reaction)
 	if err != nil {
-		return fmt.Errorf("failed to add reaction: %w", err)
+		return fmt.Errorf("failed to add reaction: %w", boterrors.FromDiscord(err))
+	}
+
+	return nil
+}
+
+// executeStickerResponse sends a sticker, with the content as text when set
+func executeStickerResponse(session DiscordSession, message *discordgo.Message, cfg config.ResponseConfig, data *TemplateContext) error {
+	stickerID, err := Render(cfg.StickerID, data)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(stickerID) == "" {
+		return boterrors.ValidationError{Field: "response.stickerId", Reason: "sticker response requires a sticker ID"}
+	}
+
+	content, err := Render(cfg.Content, data)
+	if err != nil {
+		return err
+	}
+
+	_, err = session.ChannelMessageSendComplex(message.ChannelID, &discordgo.MessageSend{
+		Content:    content,
+		StickerIDs: []string{strings.TrimSpace(stickerID)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send sticker: %w", boterrors.FromDiscord(err))
 	}
 
 	return nil
@@ -114,10 +198,21 @@ func BuildEmbed(cfg *config.EmbedConfig) *discordgo.MessageEmbed {
 		Color:       cfg.Color,
 	}
 
+	// Resolve colors given as names or hex strings
+	if cfg.ColorName != "" {
+		if color, err := ParseColor(cfg.ColorName); err == nil {
+			embed.Color = color
+		}
+	}
+
 	// Add fields
-	if len(cfg.Fields) > 0 {
-		embed.Fields = make([]*discordgo.MessageEmbedField, len(cfg.Fields))
-		for i, field := range cfg.Fields {
+	fields := cfg.Fields
+	if cfg.Align {
+		fields = AlignFields(fields)
+	}
+	if len(fields) > 0 {
+		embed.Fields = make([]*discordgo.MessageEmbedField, len(fields))
+		for i, field := range fields {
 			embed.Fields[i] = &discordgo.MessageEmbedField{
 				Name:   field.Name,
 				Value:  field.Value,
@@ -126,10 +221,27 @@ func BuildEmbed(cfg *config.EmbedConfig) *discordgo.MessageEmbed {
 		}
 	}
 
+	// Add links and images
+	embed.URL = cfg.URL
+	if cfg.Image != "" {
+		embed.Image = &discordgo.MessageEmbedImage{URL: cfg.Image}
+	}
+	if cfg.Thumbnail != "" {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: cfg.Thumbnail}
+	}
+	if cfg.Author != nil {
+		embed.Author = &discordgo.MessageEmbedAuthor{
+			Name:    cfg.Author.Name,
+			URL:     cfg.Author.URL,
+			IconURL: cfg.Author.IconURL,
+		}
+	}
+
 	// Add footer
 	if cfg.Footer != "" {
 		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: cfg.Footer,
+			Text:    cfg.Footer,
+			IconURL: cfg.FooterIconURL,
 		}
 	}
 
@@ -138,5 +250,5 @@ func BuildEmbed(cfg *config.EmbedConfig) *discordgo.MessageEmbed {
 		embed.Timestamp = time.Now().Format(time.RFC3339)
 	}
 
-	return embed
+	return validateAndTruncateEmbed(embed)
 }
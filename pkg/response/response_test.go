@@ -7,7 +7,9 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
 	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/geekxflood/gxf-discord-bot/pkg/sticker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -144,6 +146,43 @@ func TestExecuteReactionResponse(t *testing.T) {
 	session.AssertExpectations(t)
 }
 
+func TestExecuteStickerResponse(t *testing.T) {
+	cfg := config.ResponseConfig{
+		Type:      "sticker",
+		Content:   "Hi {{.Username}}",
+		StickerID: "{{stickerID \"wave\"}}",
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSendComplex", "channel123", &discordgo.MessageSend{
+		Content:    "Hi testuser",
+		StickerIDs: []string{"111"},
+	}).Return(&discordgo.Message{}, nil)
+
+	message := &discordgo.Message{
+		ChannelID: "channel123",
+		GuildID:   "guild1",
+		Author:    &discordgo.User{ID: "user123", Username: "testuser"},
+	}
+
+	stickers := sticker.NewRegistry()
+	stickers.Set("guild1", []*discordgo.Sticker{{ID: "111", Name: "wave"}})
+	data := response.NewTemplateContext(message)
+	data.Stickers = stickers
+
+	err := response.ExecuteWithContext(context.Background(), session, message, cfg, data, logger)
+
+	require.NoError(t, err)
+	session.AssertExpectations(t)
+
+	// Without a sticker ID nothing is sent
+	err = response.Execute(context.Background(), session, message, config.ResponseConfig{Type: "sticker"}, logger)
+	require.ErrorAs(t, err, &boterrors.ValidationError{})
+}
+
 func TestExecuteInvalidResponseType(t *testing.T) {
 	cfg := config.ResponseConfig{
 		Type:    "invalid",
@@ -167,6 +206,10 @@ func TestExecuteInvalidResponseType(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported response type")
+
+	var validationErr boterrors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "response.type", validationErr.Field)
 }
 
 func TestBuildEmbed(t *testing.T) {
@@ -254,5 +297,5 @@ func TestExecuteEmbedResponse_NilEmbed(t *testing.T) {
 	err := response.Execute(ctx, session, message, cfg, logger)
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "embed config is nil")
+	assert.Contains(t, err.Error(), "requires an embed config")
 }
@@ -0,0 +1,122 @@
+package response
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Discord content length limits
+const (
+	MaxMessageLength          = 2000
+	MaxEmbedDescriptionLength = 4096
+)
+
+// codeFence delimits Discord code blocks
+const codeFence = "```"
+
+// SplitMessage splits content into chunks of at most maxLen characters.
+// Chunks end at the last whitespace before the limit outside of code blocks;
+// a code block longer than the limit is split at a line break and its fence
+// is closed and reopened around the split.
+func SplitMessage(content string, maxLen int) []string {
+	if maxLen <= 0 {
+		maxLen = MaxMessageLength
+	}
+
+	if utf8.RuneCountInString(content) <= maxLen {
+		return []string{content}
+	}
+
+	var chunks []string
+	rest := []rune(content)
+
+	for len(rest) > maxLen {
+		point, inFence, lang := splitPoint(rest, maxLen)
+
+		var chunk string
+		if inFence {
+			// Split on the line break and keep code indentation intact
+			chunk = string(rest[:point]) + "\n" + codeFence
+			rest = append([]rune(codeFence+lang+"\n"), rest[point+1:]...)
+		} else {
+			chunk = strings.TrimRightFunc(string(rest[:point]), unicode.IsSpace)
+			rest = []rune(strings.TrimLeftFunc(string(rest[point:]), unicode.IsSpace))
+		}
+
+		if chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	if len(rest) > 0 {
+		chunks = append(chunks, string(rest))
+	}
+
+	return chunks
+}
+
+// SplitEmbedDescription splits an embed description into parts of at most maxLen characters
+func SplitEmbedDescription(desc string, maxLen int) []string {
+	if maxLen <= 0 {
+		maxLen = MaxEmbedDescriptionLength
+	}
+	return SplitMessage(desc, maxLen)
+}
+
+// splitPoint returns the index to split content at within maxLen characters,
+// whether the split falls inside a code block and the language of that block
+func splitPoint(content []rune, maxLen int) (int, bool, string) {
+	outside, inside := -1, -1
+	var lang, insideLang string
+	inFence := false
+	fenceBody := 0
+
+	// Leave room to close the fence when splitting inside a code block
+	insideLimit := maxLen - len(codeFence) - 1
+
+	for i := 0; i < maxLen && i < len(content); i++ {
+		if hasFenceAt(content, i) {
+			inFence = !inFence
+			if inFence {
+				lang = fenceLang(content, i+len(codeFence))
+				fenceBody = i + len(codeFence) + len(lang)
+			}
+			i += len(codeFence) - 1
+			continue
+		}
+
+		if !unicode.IsSpace(content[i]) {
+			continue
+		}
+
+		if !inFence {
+			outside = i
+		} else if content[i] == '\n' && i > fenceBody && i <= insideLimit {
+			inside = i
+			insideLang = lang
+		}
+	}
+
+	if outside > 0 {
+		return outside, false, ""
+	}
+	if inside > 0 {
+		return inside, true, insideLang
+	}
+	return maxLen, false, ""
+}
+
+// hasFenceAt reports whether a code fence starts at index i
+func hasFenceAt(content []rune, i int) bool {
+	return i+len(codeFence) <= len(content) && string(content[i:i+len(codeFence)]) == codeFence
+}
+
+// fenceLang returns the language following a code fence opening at index i
+func fenceLang(content []rune, i int) string {
+	end := i
+	for end < len(content) && !unicode.IsSpace(content[end]) && content[end] != '`' {
+		end++
+	}
+	return string(content[i:end])
+}
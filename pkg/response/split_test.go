@@ -0,0 +1,93 @@
+package response_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitMessage_Short(t *testing.T) {
+	assert.Equal(t, []string{"hello"}, response.SplitMessage("hello", 2000))
+}
+
+func TestSplitMessage_AtWhitespace(t *testing.T) {
+	chunks := response.SplitMessage("one two three four", 9)
+
+	assert.Equal(t, []string{"one two", "three", "four"}, chunks)
+}
+
+func TestSplitMessage_RespectsLimit(t *testing.T) {
+	content := strings.Repeat("lorem ipsum dolor sit amet ", 200)
+
+	chunks := response.SplitMessage(content, 2000)
+
+	require.Greater(t, len(chunks), 1)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, utf8.RuneCountInString(chunk), 2000)
+	}
+	assert.Equal(t, strings.Fields(content), strings.Fields(strings.Join(chunks, " ")))
+}
+
+func TestSplitMessage_DoesNotSplitInsideCodeBlock(t *testing.T) {
+	content := "intro text\n```go\nfmt.Println(1)\n```\noutro text"
+
+	chunks := response.SplitMessage(content, 30)
+
+	assert.Equal(t, []string{"intro text", "```go\nfmt.Println(1)\n```", "outro text"}, chunks)
+}
+
+func TestSplitMessage_LongCodeBlock(t *testing.T) {
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = "    line of code"
+	}
+	content := "```go\n" + strings.Join(lines, "\n") + "\n```"
+
+	chunks := response.SplitMessage(content, 100)
+
+	require.Greater(t, len(chunks), 1)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, utf8.RuneCountInString(chunk), 100)
+		assert.True(t, strings.HasPrefix(chunk, "```go\n"), chunk)
+		assert.True(t, strings.HasSuffix(chunk, "```"), chunk)
+		assert.Contains(t, chunk, "\n    line of code")
+	}
+}
+
+func TestSplitEmbedDescription(t *testing.T) {
+	desc := strings.Repeat("word ", 1000)
+
+	parts := response.SplitEmbedDescription(desc, 0)
+
+	require.Len(t, parts, 2)
+	assert.LessOrEqual(t, utf8.RuneCountInString(parts[0]), response.MaxEmbedDescriptionLength)
+}
+
+func TestExecuteTextResponse_SplitsLongMessages(t *testing.T) {
+	cfg := config.ResponseConfig{
+		Type:    "text",
+		Content: strings.Repeat("a ", 1500),
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	session := &testutil.MockDiscordSession{}
+	session.On("ChannelMessageSend", "channel123", mock.Anything).Return(&discordgo.Message{}, nil)
+
+	message := &discordgo.Message{ChannelID: "channel123", Author: &discordgo.User{ID: "user123"}}
+
+	err := response.Execute(context.Background(), session, message, cfg, logger)
+
+	require.NoError(t, err)
+	session.AssertNumberOfCalls(t, "ChannelMessageSend", 2)
+}
@@ -0,0 +1,235 @@
+package response
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/mentions"
+)
+
+// TemplateContext holds the values available to response templates
+type TemplateContext struct {
+	UserID    string
+	Username  string
+	ChannelID string
+	GuildID   string
+	MessageID string
+	Content   string
+
+	// Prefs holds the preferences set by the user with setpref
+	Prefs map[string]string
+
+	// Emojis resolves the custom emojis of the guild for the emoji function
+	Emojis EmojiLookup
+
+	// Stickers resolves the stickers of the guild for the stickerID function
+	Stickers StickerLookup
+
+	// HTTPPolicy restricts the hosts reached by http responses, private networks are blocked when nil
+	HTTPPolicy *HTTPPolicy
+
+	// BotUserID is the user of the bot, whose permissions are checked by relay responses
+	BotUserID string
+
+	// BaseDir resolves the relative bodyFilePath of http responses, the working directory when empty
+	BaseDir string
+
+	// HTTPResponse holds the parsed body of an http response,
+	// a map of top-level keys for JSON or the full body for text
+	HTTPResponse interface{}
+}
+
+// EmojiLookup resolves a custom guild emoji by name to its message format
+type EmojiLookup interface {
+	Lookup(guildID, name string) (string, bool)
+}
+
+// StickerLookup resolves a guild sticker by name to its ID
+type StickerLookup interface {
+	Lookup(guildID, name string) (string, bool)
+}
+
+// NewTemplateContext creates a template context from a Discord message
+func NewTemplateContext(message *discordgo.Message) *TemplateContext {
+	data := &TemplateContext{
+		ChannelID: message.ChannelID,
+		GuildID:   message.GuildID,
+		MessageID: message.ID,
+		Content:   message.Content,
+	}
+
+	if message.Author != nil {
+		data.UserID = message.Author.ID
+		data.Username = message.Author.Username
+	}
+
+	return data
+}
+
+// BuildFuncMap returns the functions available to response templates
+func BuildFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"join":  strings.Join,
+
+		"now":              time.Now,
+		"discordTimestamp": DiscordTimestamp,
+		"codeBlock":        CodeBlock,
+		"inlineCode":       InlineCode,
+
+		"parseUsers":     mentions.ParseUsers,
+		"parseRoles":     mentions.ParseRoles,
+		"parseChannels":  mentions.ParseChannels,
+		"mentionUser":    mentions.MentionUser,
+		"mentionRole":    mentions.MentionRole,
+		"mentionChannel": mentions.MentionChannel,
+
+		// Replaced by the guild emoji when rendering with emojis available
+		"emoji": emojiShortcode,
+		// Replaced by the guild sticker when rendering with a context
+		"stickerID": unknownSticker,
+	}
+}
+
+// emojiShortcode returns the :name: shortcode of an emoji that could not be resolved
+func emojiShortcode(name string) string {
+	return ":" + name + ":"
+}
+
+// emoji returns the guild emoji with the given name, or its shortcode when unknown
+func (d *TemplateContext) emoji(name string) string {
+	if d.Emojis != nil {
+		if value, ok := d.Emojis.Lookup(d.GuildID, name); ok {
+			return value
+		}
+	}
+	return emojiShortcode(name)
+}
+
+// unknownSticker fails the rendering of a sticker that could not be resolved
+func unknownSticker(name string) (string, error) {
+	return "", fmt.Errorf("unknown sticker: %s", name)
+}
+
+// stickerID returns the ID of the guild sticker with the given name
+func (d *TemplateContext) stickerID(name string) (string, error) {
+	if d.Stickers != nil {
+		if id, ok := d.Stickers.Lookup(d.GuildID, name); ok {
+			return id, nil
+		}
+	}
+	return unknownSticker(name)
+}
+
+// zeroWidthSpace breaks up backtick sequences without visibly changing content
+const zeroWidthSpace = "\u200b"
+
+// CodeBlock wraps content in a fenced code block with the given language.
+// Fences inside the content are broken up so they cannot close the block.
+func CodeBlock(lang, content string) string {
+	escaped := strings.ReplaceAll(content, codeFence, "`"+zeroWidthSpace+"`"+zeroWidthSpace+"`")
+	return codeFence + lang + "\n" + escaped + "\n" + codeFence
+}
+
+// InlineCode wraps content in inline code, using double backticks when it contains backticks
+func InlineCode(content string) string {
+	if !strings.Contains(content, "`") {
+		return "`" + content + "`"
+	}
+
+	for strings.Contains(content, "``") {
+		content = strings.ReplaceAll(content, "``", "`"+zeroWidthSpace+"`")
+	}
+	return "`` " + content + " ``"
+}
+
+// DiscordTimestamp formats a time as a Discord timestamp rendered in each reader's timezone.
+// Styles are t, T, d, D, f, F and R, unknown styles use Discord's default.
+func DiscordTimestamp(t time.Time, style string) string {
+	switch style {
+	case "t", "T", "d", "D", "f", "F", "R":
+		return fmt.Sprintf("<t:%d:%s>", t.Unix(), style)
+	default:
+		return fmt.Sprintf("<t:%d>", t.Unix())
+	}
+}
+
+// Render executes a response template with the given context
+func Render(text string, data *TemplateContext) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	funcs := BuildFuncMap()
+	if data != nil {
+		funcs["emoji"] = data.emoji
+		funcs["stickerID"] = data.stickerID
+	}
+
+	tmpl, err := template.New("response").Funcs(funcs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// renderEmbed returns a copy of the embed configuration with its text rendered
+func renderEmbed(cfg *config.EmbedConfig, data *TemplateContext) (*config.EmbedConfig, error) {
+	embed := *cfg
+
+	var err error
+	if embed.Title, err = Render(cfg.Title, data); err != nil {
+		return nil, err
+	}
+	if embed.Description, err = Render(cfg.Description, data); err != nil {
+		return nil, err
+	}
+	if embed.Footer, err = Render(cfg.Footer, data); err != nil {
+		return nil, err
+	}
+	for _, url := range []*string{&embed.URL, &embed.Image, &embed.Thumbnail, &embed.FooterIconURL} {
+		if *url, err = Render(*url, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Author != nil {
+		author := *cfg.Author
+		if author.Name, err = Render(author.Name, data); err != nil {
+			return nil, err
+		}
+		if author.URL, err = Render(author.URL, data); err != nil {
+			return nil, err
+		}
+		if author.IconURL, err = Render(author.IconURL, data); err != nil {
+			return nil, err
+		}
+		embed.Author = &author
+	}
+
+	embed.Fields = make([]config.EmbedField, len(cfg.Fields))
+	for i, field := range cfg.Fields {
+		embed.Fields[i] = field
+		if embed.Fields[i].Name, err = Render(field.Name, data); err != nil {
+			return nil, err
+		}
+		if embed.Fields[i].Value, err = Render(field.Value, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return &embed, nil
+}
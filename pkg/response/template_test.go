@@ -0,0 +1,120 @@
+package response_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/emoji"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/geekxflood/gxf-discord-bot/pkg/sticker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	data := response.NewTemplateContext(&discordgo.Message{
+		ChannelID: "channel123",
+		Content:   "!hello",
+		Author:    &discordgo.User{ID: "user123", Username: "testuser"},
+	})
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "plain text", text: "Hello!", expected: "Hello!"},
+		{name: "user fields", text: "Hello {{.Username}} ({{.UserID}})", expected: "Hello testuser (user123)"},
+		{name: "functions", text: "{{upper .Username}}", expected: "TESTUSER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := response.Render(tt.text, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rendered)
+		})
+	}
+}
+
+func TestRender_InvalidTemplate(t *testing.T) {
+	_, err := response.Render("{{.Username", &response.TemplateContext{})
+
+	assert.Error(t, err)
+}
+
+func TestDiscordTimestamp(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+
+	assert.Equal(t, "<t:1700000000:R>", response.DiscordTimestamp(ts, "R"))
+	assert.Equal(t, "<t:1700000000:F>", response.DiscordTimestamp(ts, "F"))
+	assert.Equal(t, "<t:1700000000>", response.DiscordTimestamp(ts, "x"))
+}
+
+func TestRender_DiscordTimestampNow(t *testing.T) {
+	rendered, err := response.Render(`Sent {{discordTimestamp now "R"}}`, &response.TemplateContext{})
+
+	require.NoError(t, err)
+	assert.Regexp(t, `^Sent <t:\d+:R>$`, rendered)
+}
+
+func TestCodeBlock(t *testing.T) {
+	assert.Equal(t, "```go\nfmt.Println()\n```", response.CodeBlock("go", "fmt.Println()"))
+
+	block := response.CodeBlock("md", "before ``` after")
+	assert.Equal(t, 2, strings.Count(block, "```"))
+}
+
+func TestInlineCode(t *testing.T) {
+	assert.Equal(t, "`x := 1`", response.InlineCode("x := 1"))
+	assert.Equal(t, "`` a`b ``", response.InlineCode("a`b"))
+
+	escaped := response.InlineCode("a``b")
+	assert.NotContains(t, strings.TrimSuffix(strings.TrimPrefix(escaped, "``"), "``"), "``")
+}
+
+func TestRender_CodeHelpers(t *testing.T) {
+	rendered, err := response.Render(`{{codeBlock "sh" "make test"}} {{inlineCode .Username}}`, &response.TemplateContext{Username: "bob"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "```sh\nmake test\n``` `bob`", rendered)
+}
+
+func TestRender_Emoji(t *testing.T) {
+	registry := emoji.NewRegistry()
+	registry.Set("guild1", []*discordgo.Emoji{{ID: "111", Name: "party"}})
+
+	rendered, err := response.Render(`{{emoji "party"}} {{emoji "unknown"}}`, &response.TemplateContext{GuildID: "guild1", Emojis: registry})
+	require.NoError(t, err)
+	assert.Equal(t, "<:party:111> :unknown:", rendered)
+
+	// Without a registry the shortcode is kept
+	rendered, err = response.Render(`{{emoji "party"}}`, &response.TemplateContext{GuildID: "guild1"})
+	require.NoError(t, err)
+	assert.Equal(t, ":party:", rendered)
+}
+
+func TestRender_StickerID(t *testing.T) {
+	registry := sticker.NewRegistry()
+	registry.Set("guild1", []*discordgo.Sticker{{ID: "111", Name: "wave"}})
+
+	rendered, err := response.Render(`{{stickerID "wave"}}`, &response.TemplateContext{GuildID: "guild1", Stickers: registry})
+	require.NoError(t, err)
+	assert.Equal(t, "111", rendered)
+
+	_, err = response.Render(`{{stickerID "dance"}}`, &response.TemplateContext{GuildID: "guild1", Stickers: registry})
+	assert.ErrorContains(t, err, "unknown sticker: dance")
+}
+
+func TestRender_Mentions(t *testing.T) {
+	data := &response.TemplateContext{
+		UserID:  "123456789012345678",
+		Content: "ping <@223456789012345678> and <@!323456789012345678> in <#423456789012345678>",
+	}
+
+	rendered, err := response.Render(`{{mentionUser .UserID}} pinged{{range parseUsers .Content}} {{mentionUser .}}{{end}} in {{range parseChannels .Content}}{{mentionChannel .}}{{end}}`, data)
+	require.NoError(t, err)
+	assert.Equal(t, "<@123456789012345678> pinged <@223456789012345678> <@323456789012345678> in <#423456789012345678>", rendered)
+}
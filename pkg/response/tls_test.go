@@ -0,0 +1,141 @@
+package response_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// generateClientCert creates a self-signed client certificate and returns its PEM encoded certificate and key
+func generateClientCert(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gxf-discord-bot"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM
+}
+
+// newMTLSServer starts a TLS server requiring the given client certificate
+func newMTLSServer(t *testing.T, clientCertPEM []byte) *httptest.Server {
+	t.Helper()
+
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(clientCertPEM))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestExecuteHTTPResponse_MTLS(t *testing.T) {
+	certPEM, keyPEM := generateClientCert(t)
+	server := newMTLSServer(t, certPEM)
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "client.crt")
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0600))
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:           server.URL,
+			TLSClientCert: certPath,
+			TLSClientKey:  string(keyPEM),
+			TLSCACert:     string(caPEM),
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := executeLocal(t, &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	assert.NoError(t, err)
+}
+
+func TestExecuteHTTPResponse_MTLSWithoutClientCert(t *testing.T) {
+	certPEM, _ := generateClientCert(t)
+	server := newMTLSServer(t, certPEM)
+
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:           server.URL,
+			TLSSkipVerify: true,
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := executeLocal(t, &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	assert.Error(t, err)
+}
+
+func TestExecuteHTTPResponse_VaultCertificate(t *testing.T) {
+	cfg := config.ResponseConfig{
+		Type: "http",
+		HTTP: &config.HTTPConfig{
+			URL:           "https://example.com",
+			TLSClientCert: "vault://secret/data/bot#cert",
+			TLSClientKey:  "vault://secret/data/bot#key",
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := executeLocal(t, &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "secrets manager")
+}
@@ -0,0 +1,95 @@
+package response
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	boterrors "github.com/geekxflood/gxf-discord-bot/pkg/errors"
+)
+
+// webhookPayload is the body of a Discord webhook execution
+type webhookPayload struct {
+	Content   string                    `json:"content,omitempty"`
+	Username  string                    `json:"username,omitempty"`
+	AvatarURL string                    `json:"avatar_url,omitempty"`
+	Embeds    []*discordgo.MessageEmbed `json:"embeds,omitempty"`
+}
+
+// executeWebhookResponse posts the response content or embed to a Discord webhook
+func executeWebhookResponse(ctx context.Context, cfg config.ResponseConfig, data *TemplateContext) error {
+	if cfg.WebhookURL == "" {
+		return boterrors.ValidationError{Field: "response.webhookUrl", Reason: "webhook response requires a webhookUrl"}
+	}
+
+	payload := webhookPayload{
+		Username:  cfg.Username,
+		AvatarURL: cfg.AvatarURL,
+	}
+
+	var err error
+	if payload.Content, err = Render(cfg.Content, data); err != nil {
+		return err
+	}
+
+	if cfg.Embed != nil {
+		embedCfg, err := renderEmbed(cfg.Embed, data)
+		if err != nil {
+			return err
+		}
+		if err := errors.Join(ValidateEmbed(embedCfg)...); err != nil {
+			return err
+		}
+		payload.Embeds = []*discordgo.MessageEmbed{BuildEmbed(embedCfg)}
+	}
+
+	if payload.Content == "" && len(payload.Embeds) == 0 {
+		return boterrors.ValidationError{Field: "response.content", Reason: "webhook response requires content or embed"}
+	}
+
+	return sendWebhook(ctx, cfg.WebhookURL, payload)
+}
+
+// SendWebhookEmbed posts an embed to a Discord webhook
+func SendWebhookEmbed(ctx context.Context, webhookURL string, embed *discordgo.MessageEmbed) error {
+	return sendWebhook(ctx, webhookURL, webhookPayload{Embeds: []*discordgo.MessageEmbed{embed}})
+}
+
+// sendWebhook posts a payload to a Discord webhook
+func sendWebhook(ctx context.Context, webhookURL string, payload webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultHTTPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxHTTPResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package response_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteWebhookResponse_EscapesContent(t *testing.T) {
+	content := `He said "hi" \ then left` + "\n" + `{"not": "json"}`
+
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type:       "webhook",
+		WebhookURL: server.URL,
+		Content:    content,
+		Username:   "Alert Bot",
+		AvatarURL:  "https://example.com/avatar.png",
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := response.Execute(context.Background(), &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	require.NoError(t, err)
+	assert.Equal(t, content, received["content"])
+	assert.Equal(t, "Alert Bot", received["username"])
+	assert.Equal(t, "https://example.com/avatar.png", received["avatar_url"])
+}
+
+func TestExecuteWebhookResponse_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cfg := config.ResponseConfig{
+		Type:       "webhook",
+		WebhookURL: server.URL,
+		Content:    "hello",
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	err := response.Execute(context.Background(), &testutil.MockDiscordSession{}, newHTTPMessage(), cfg, logger)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
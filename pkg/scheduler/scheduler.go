@@ -5,12 +5,21 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/geekxflood/common/logging"
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/coordination"
+	"github.com/geekxflood/gxf-discord-bot/pkg/eventbus"
 	"github.com/robfig/cron/v3"
 )
 
+// jobLockTTL is how long the lock of a job tick is held
+const jobLockTTL = time.Minute
+
+// cronParser parses the cron expressions of the jobs, with seconds and descriptors such as @daily
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 // JobFunc represents a scheduled job function
 type JobFunc func(ctx context.Context) error
 
@@ -19,6 +28,9 @@ type JobInfo struct {
 	ID       string
 	Name     string
 	Schedule string
+	Timezone string
+	NextRun  time.Time
+	Paused   bool
 }
 
 // Scheduler manages scheduled jobs
@@ -29,13 +41,60 @@ type Scheduler struct {
 	jobsMu  sync.RWMutex
 	running bool
 	runMu   sync.RWMutex
+	lock    coordination.DistributedLock
+
+	timers    map[int]*time.Timer
+	nextTimer int
+	timersMu  sync.Mutex
 }
 
 type jobEntry struct {
 	id       cron.EntryID
 	name     string
 	schedule string
+	timezone string
+	parsed   cron.Schedule
 	fn       JobFunc
+	paused   bool
+}
+
+// spec returns the cron spec of the job, in its timezone when set
+func (j *jobEntry) spec() string {
+	return cronSpec(j.schedule, j.timezone)
+}
+
+// cronSpec prefixes the schedule with its timezone when set
+func cronSpec(schedule, timezone string) string {
+	if timezone == "" {
+		return schedule
+	}
+	return "CRON_TZ=" + timezone + " " + schedule
+}
+
+// NextRun returns the first time after from matching the schedule in the timezone, the local one when empty
+func NextRun(schedule, timezone string, from time.Time) (time.Time, error) {
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone: %s", timezone)
+		}
+	}
+
+	parsed, err := cronParser.Parse(cronSpec(schedule, timezone))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression: %w", err)
+	}
+	return parsed.Next(from), nil
+}
+
+// LastRun returns the latest time at or before now matching the schedule in the timezone,
+// the fire time of the tick a job starting at now belongs to. Ticks older than the job
+// lock TTL are not searched, now truncated to the second is returned instead.
+func LastRun(schedule, timezone string, now time.Time) (time.Time, error) {
+	parsed, err := cronParser.Parse(cronSpec(schedule, timezone))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression: %w", err)
+	}
+	return scheduledTick(parsed, now), nil
 }
 
 // New creates a new scheduler
@@ -43,9 +102,10 @@ func New(logger logging.Logger) *Scheduler {
 	logger.Info("Creating new scheduler")
 
 	return &Scheduler{
-		cron:    cron.New(cron.WithSeconds()),
+		cron:    cron.New(cron.WithParser(cronParser)),
 		logger:  logger,
 		jobs:    make(map[string]*jobEntry),
+		timers:  make(map[int]*time.Timer),
 		running: false,
 	}
 }
@@ -66,6 +126,23 @@ func (s *Scheduler) Start() error {
 	return nil
 }
 
+// StartOnReady starts the scheduler once the bot.ready event is published,
+// so jobs only fire after the Discord session is established
+func (s *Scheduler) StartOnReady(bus *eventbus.Bus) {
+	ready := bus.Subscribe(eventbus.TopicReady)
+
+	go func() {
+		if _, ok := <-ready; !ok {
+			return
+		}
+		bus.Unsubscribe(eventbus.TopicReady, ready)
+
+		if err := s.Start(); err != nil {
+			s.logger.Error("Failed to start scheduler", "error", err)
+		}
+	}()
+}
+
 // Stop stops the scheduler
 func (s *Scheduler) Stop() error {
 	s.runMu.Lock()
@@ -76,6 +153,14 @@ func (s *Scheduler) Stop() error {
 	}
 
 	s.logger.Info("Stopping scheduler")
+
+	s.timersMu.Lock()
+	for id, timer := range s.timers {
+		timer.Stop()
+		delete(s.timers, id)
+	}
+	s.timersMu.Unlock()
+
 	ctx := s.cron.Stop()
 	<-ctx.Done()
 	s.running = false
@@ -90,44 +175,79 @@ func (s *Scheduler) IsRunning() bool {
 	return s.running
 }
 
+// SetLock sets the lock ensuring each job tick runs on a single bot instance
+func (s *Scheduler) SetLock(lock coordination.DistributedLock) {
+	s.lock = lock
+}
+
 // AddJob adds a new job to the scheduler
 func (s *Scheduler) AddJob(name, schedule string, fn JobFunc) (string, error) {
-	s.jobsMu.Lock()
-	defer s.jobsMu.Unlock()
-
-	s.logger.Debug("Adding job", "name", name, "schedule", schedule)
+	return s.AddJobInTimezone(name, schedule, "", fn)
+}
 
-	// Wrap the job function to handle context and errors
-	wrappedFn := func() {
-		ctx := context.Background()
-		if err := fn(ctx); err != nil {
-			s.logger.Error("Job execution failed", "name", name, "error", err)
+// AddJobInTimezone adds a new job whose schedule is in the IANA timezone, the local one when empty
+func (s *Scheduler) AddJobInTimezone(name, schedule, timezone string, fn JobFunc) (string, error) {
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return "", fmt.Errorf("invalid timezone: %s", timezone)
 		}
 	}
 
-	// Add job to cron
-	entryID, err := s.cron.AddFunc(schedule, wrappedFn)
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	s.logger.Debug("Adding job", "name", name, "schedule", schedule, "timezone", timezone)
+
+	parsed, err := cronParser.Parse(cronSpec(schedule, timezone))
 	if err != nil {
 		s.logger.Error("Failed to add job", "name", name, "error", err)
 		return "", fmt.Errorf("invalid cron expression: %w", err)
 	}
 
-	// Generate job ID
-	jobID := fmt.Sprintf("job-%d", entryID)
-
-	// Store job entry
-	s.jobs[jobID] = &jobEntry{
-		id:       entryID,
+	job := &jobEntry{
 		name:     name,
 		schedule: schedule,
+		timezone: timezone,
+		parsed:   parsed,
 		fn:       fn,
 	}
 
+	// Add job to cron
+	entryID := s.cron.Schedule(parsed, cron.FuncJob(s.wrapJob(name, s.lockedJob(job))))
+	job.id = entryID
+
+	// Generate job ID
+	jobID := fmt.Sprintf("job-%d", entryID)
+
+	// Store job entry
+	s.jobs[jobID] = job
+
 	s.logger.Debug("Job added successfully", "jobID", jobID, "name", name)
 
 	return jobID, nil
 }
 
+// ScheduleOnce runs a job once after the given delay.
+// Pending one-off jobs are cancelled when the scheduler stops.
+func (s *Scheduler) ScheduleOnce(name string, delay time.Duration, fn JobFunc) {
+	s.timersMu.Lock()
+	defer s.timersMu.Unlock()
+
+	id := s.nextTimer
+	s.nextTimer++
+
+	run := s.wrapJob(name, fn)
+	s.timers[id] = time.AfterFunc(delay, func() {
+		s.timersMu.Lock()
+		delete(s.timers, id)
+		s.timersMu.Unlock()
+
+		run()
+	})
+
+	s.logger.Debug("One-off job scheduled", "name", name, "delay", delay)
+}
+
 // RemoveJob removes a job from the scheduler
 func (s *Scheduler) RemoveJob(jobID string) error {
 	s.jobsMu.Lock()
@@ -162,11 +282,8 @@ func (s *Scheduler) GetJobInfo(jobID string) (*JobInfo, error) {
 		return nil, fmt.Errorf("job not found: %s", jobID)
 	}
 
-	return &JobInfo{
-		ID:       jobID,
-		Name:     job.name,
-		Schedule: job.schedule,
-	}, nil
+	info := s.jobInfo(jobID, job)
+	return &info, nil
 }
 
 // ListJobs returns a list of all scheduled jobs
@@ -176,16 +293,119 @@ func (s *Scheduler) ListJobs() []JobInfo {
 
 	jobs := make([]JobInfo, 0, len(s.jobs))
 	for jobID, job := range s.jobs {
-		jobs = append(jobs, JobInfo{
-			ID:       jobID,
-			Name:     job.name,
-			Schedule: job.schedule,
-		})
+		jobs = append(jobs, s.jobInfo(jobID, job))
 	}
 
 	return jobs
 }
 
+// PauseJob pauses a job so it no longer fires until resumed
+func (s *Scheduler) PauseJob(jobID string) error {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	job, exists := s.jobs[jobID]
+	if !exists {
+		return fmt.Errorf("job not found: %s", jobID)
+	}
+
+	if job.paused {
+		return fmt.Errorf("job already paused: %s", jobID)
+	}
+
+	s.cron.Remove(job.id)
+	job.paused = true
+
+	s.logger.Info("Job paused", "jobID", jobID, "name", job.name)
+	return nil
+}
+
+// ResumeJob resumes a paused job
+func (s *Scheduler) ResumeJob(jobID string) error {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	job, exists := s.jobs[jobID]
+	if !exists {
+		return fmt.Errorf("job not found: %s", jobID)
+	}
+
+	if !job.paused {
+		return fmt.Errorf("job not paused: %s", jobID)
+	}
+
+	job.id = s.cron.Schedule(job.parsed, cron.FuncJob(s.wrapJob(job.name, s.lockedJob(job))))
+	job.paused = false
+
+	s.logger.Info("Job resumed", "jobID", jobID, "name", job.name)
+	return nil
+}
+
+// wrapJob wraps a job function to handle context and errors
+func (s *Scheduler) wrapJob(name string, fn JobFunc) func() {
+	return func() {
+		ctx := context.Background()
+		if err := fn(ctx); err != nil {
+			s.logger.Error("Job execution failed", "name", name, "error", err)
+		}
+	}
+}
+
+// lockedJob runs a job only on the instance acquiring the lock for the current tick
+func (s *Scheduler) lockedJob(job *jobEntry) JobFunc {
+	name, fn := job.name, job.fn
+	return func(ctx context.Context) error {
+		if s.lock == nil {
+			return fn(ctx)
+		}
+
+		// The key is the scheduled fire time, not the clock, so instances firing
+		// on either side of a second boundary compete for the same tick
+		tick := scheduledTick(job.parsed, time.Now())
+		key := fmt.Sprintf("%s:%d", name, tick.Unix())
+
+		// The lock is not released after the job completes, it expires instead
+		// so that instances firing slightly later skip the same tick
+		release, err := s.lock.Acquire(ctx, key, jobLockTTL)
+		if err != nil {
+			return err
+		}
+		if release == nil {
+			s.logger.Debug("Job tick owned by another instance", "name", name)
+			return nil
+		}
+
+		return fn(ctx)
+	}
+}
+
+// scheduledTick returns the latest fire time of a schedule at or before now.
+// A job delayed past the lock TTL falls back to the current second.
+func scheduledTick(schedule cron.Schedule, now time.Time) time.Time {
+	tick := now.Truncate(time.Second)
+	for next := schedule.Next(now.Add(-jobLockTTL)); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		tick = next
+	}
+	return tick
+}
+
+// jobInfo builds the public job information for a job entry
+func (s *Scheduler) jobInfo(jobID string, job *jobEntry) JobInfo {
+	info := JobInfo{
+		ID:       jobID,
+		Name:     job.name,
+		Schedule: job.schedule,
+		Timezone: job.timezone,
+		Paused:   job.paused,
+	}
+
+	if !job.paused {
+		info.NextRun = s.cron.Entry(job.id).Next
+	}
+
+	return info
+}
+
 // LoadFromConfig loads scheduled actions from configuration
 func (s *Scheduler) LoadFromConfig(cfg *config.Config) (int, error) {
 	s.logger.Info("Loading scheduled actions from config")
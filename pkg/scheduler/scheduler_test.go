@@ -3,11 +3,13 @@ package scheduler_test
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
 	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/eventbus"
 	"github.com/geekxflood/gxf-discord-bot/pkg/scheduler"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -214,6 +216,57 @@ func TestScheduler_GetJobInfo(t *testing.T) {
 	assert.Equal(t, jobID, info.ID)
 }
 
+func TestScheduler_AddJobInTimezone(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	sched := scheduler.New(logger)
+	require.NoError(t, sched.Start())
+	defer sched.Stop()
+
+	jobID, err := sched.AddJobInTimezone("morning", "0 0 9 * * *", "Asia/Tokyo", func(ctx context.Context) error { return nil })
+	require.NoError(t, err)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	info, err := sched.GetJobInfo(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, "0 0 9 * * *", info.Schedule)
+	assert.Equal(t, "Asia/Tokyo", info.Timezone)
+	assert.Equal(t, 9, info.NextRun.In(tokyo).Hour())
+	assert.Zero(t, info.NextRun.In(tokyo).Minute())
+
+	// Resumed jobs keep their timezone
+	require.NoError(t, sched.PauseJob(jobID))
+	require.NoError(t, sched.ResumeJob(jobID))
+	info, err = sched.GetJobInfo(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, 9, info.NextRun.In(tokyo).Hour())
+
+	_, err = sched.AddJobInTimezone("morning", "0 0 9 * * *", "Mars/Olympus", func(ctx context.Context) error { return nil })
+	assert.EqualError(t, err, "invalid timezone: Mars/Olympus")
+}
+
+func TestNextRun(t *testing.T) {
+	from := time.Date(2026, 3, 28, 12, 0, 0, 0, time.UTC)
+
+	next, err := scheduler.NextRun("0 0 9 * * *", "Europe/Paris", from)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC), next.UTC())
+
+	next, err = scheduler.NextRun("@hourly", "", from)
+	require.NoError(t, err)
+	assert.Equal(t, from.Add(time.Hour), next.UTC())
+
+	_, err = scheduler.NextRun("0 99 * * *", "", from)
+	assert.ErrorContains(t, err, "invalid cron expression")
+
+	_, err = scheduler.NextRun("@daily", "Mars/Olympus", from)
+	assert.EqualError(t, err, "invalid timezone: Mars/Olympus")
+}
+
 func TestScheduler_ListJobs(t *testing.T) {
 	logger := &testutil.MockLogger{}
 	logger.On("Info", mock.Anything, mock.Anything).Return()
@@ -266,3 +319,181 @@ func TestScheduler_LoadFromConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 1, count)
 }
+
+func TestScheduler_PauseResumeJob(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	sched := scheduler.New(logger)
+
+	jobID, err := sched.AddJob("test-job", "0 0 9 * * *", func(ctx context.Context) error { return nil })
+	require.NoError(t, err)
+
+	err = sched.PauseJob(jobID)
+	require.NoError(t, err)
+
+	info, err := sched.GetJobInfo(jobID)
+	require.NoError(t, err)
+	assert.True(t, info.Paused)
+	assert.True(t, info.NextRun.IsZero())
+
+	// Pausing twice is an error
+	assert.Error(t, sched.PauseJob(jobID))
+
+	err = sched.ResumeJob(jobID)
+	require.NoError(t, err)
+
+	info, err = sched.GetJobInfo(jobID)
+	require.NoError(t, err)
+	assert.False(t, info.Paused)
+
+	assert.Error(t, sched.PauseJob("job-999"))
+}
+
+func TestScheduler_ScheduleOnce(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	sched := scheduler.New(logger)
+	require.NoError(t, sched.Start())
+	defer sched.Stop()
+
+	executed := make(chan struct{}, 2)
+	sched.ScheduleOnce("once", 10*time.Millisecond, func(ctx context.Context) error {
+		executed <- struct{}{}
+		return nil
+	})
+
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		t.Fatal("one-off job was not executed")
+	}
+
+	// The job runs only once
+	select {
+	case <-executed:
+		t.Fatal("one-off job executed twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestScheduler_StopCancelsScheduleOnce(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	sched := scheduler.New(logger)
+	require.NoError(t, sched.Start())
+
+	executed := make(chan struct{}, 1)
+	sched.ScheduleOnce("once", 50*time.Millisecond, func(ctx context.Context) error {
+		executed <- struct{}{}
+		return nil
+	})
+	require.NoError(t, sched.Stop())
+
+	select {
+	case <-executed:
+		t.Fatal("one-off job executed after stop")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// memoryLock is an in-memory DistributedLock shared by schedulers in a test
+type memoryLock struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+func (l *memoryLock) Acquire(ctx context.Context, key string, ttl time.Duration) (func(context.Context) error, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held[key] {
+		return nil, nil
+	}
+	l.held[key] = true
+
+	return func(context.Context) error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, key)
+		return nil
+	}, nil
+}
+
+func TestScheduler_SetLock(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+
+	lock := &memoryLock{held: make(map[string]bool)}
+
+	var runs atomic.Int32
+	job := func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}
+
+	// Two instances schedule the same job, each tick runs once
+	for range 2 {
+		sched := scheduler.New(logger)
+		sched.SetLock(lock)
+		_, err := sched.AddJob("shared-job", "* * * * * *", job)
+		require.NoError(t, err)
+		require.NoError(t, sched.Start())
+		defer sched.Stop()
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+
+	ticks := runs.Load()
+	assert.GreaterOrEqual(t, ticks, int32(2))
+	assert.LessOrEqual(t, ticks, int32(3))
+}
+
+func TestLastRun(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule string
+		now      time.Time
+		want     time.Time
+	}{
+		{name: "on time", schedule: "0 * * * * *", now: base.Add(300 * time.Millisecond), want: base},
+		{name: "late past a second boundary", schedule: "0 * * * * *", now: base.Add(1200 * time.Millisecond), want: base},
+		{name: "every second", schedule: "* * * * * *", now: base.Add(2500 * time.Millisecond), want: base.Add(2 * time.Second)},
+		{name: "no tick within the lock TTL", schedule: "0 0 * * * *", now: base.Add(5*time.Minute + 300*time.Millisecond), want: base.Add(5 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scheduler.LastRun(tt.schedule, "UTC", tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.UTC())
+		})
+	}
+
+	_, err := scheduler.LastRun("not a schedule", "", base)
+	assert.Error(t, err)
+}
+
+func TestScheduler_StartOnReady(t *testing.T) {
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+
+	bus := eventbus.New()
+	sched := scheduler.New(logger)
+	sched.StartOnReady(bus)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, sched.IsRunning())
+
+	bus.Publish(eventbus.TopicReady, nil)
+	require.Eventually(t, sched.IsRunning, time.Second, 10*time.Millisecond)
+	require.NoError(t, sched.Stop())
+}
@@ -0,0 +1,86 @@
+// Package sharding runs every shard of a bot in a single process.
+package sharding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/geekxflood/common/logging"
+	"github.com/geekxflood/gxf-discord-bot/pkg/bot"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+)
+
+// ShardManager starts and stops one bot per shard
+type ShardManager struct {
+	shards []*bot.Bot
+	logger logging.Logger
+}
+
+// NewShardManager creates the bots for all shards configured by shardCount.
+// Shard 0 owns the scheduler and admin API, the other shards share its actions.
+func NewShardManager(ctx context.Context, cfg *config.Config, logger logging.Logger) (*ShardManager, error) {
+	if cfg.Bot.ShardCount < 2 {
+		return nil, fmt.Errorf("sharding requires a shardCount of at least 2")
+	}
+
+	primaryCfg := *cfg
+	primaryCfg.Bot.ShardID = 0
+
+	primary, err := bot.New(ctx, &primaryCfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shard 0: %w", err)
+	}
+
+	shards := []*bot.Bot{primary}
+	for id := 1; id < cfg.Bot.ShardCount; id++ {
+		shard, err := primary.NewShard(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create shard %d: %w", id, err)
+		}
+		shards = append(shards, shard)
+	}
+
+	logger.Info("Shards created", "count", len(shards))
+
+	return &ShardManager{
+		shards: shards,
+		logger: logger,
+	}, nil
+}
+
+// Primary returns the bot of shard 0
+func (m *ShardManager) Primary() *bot.Bot {
+	return m.shards[0]
+}
+
+// Shards returns the bots of all shards
+func (m *ShardManager) Shards() []*bot.Bot {
+	return m.shards
+}
+
+// Start connects every shard, stopping the started shards if one fails
+func (m *ShardManager) Start(ctx context.Context) error {
+	for id, shard := range m.shards {
+		if err := shard.Start(ctx); err != nil {
+			for _, started := range m.shards[:id] {
+				if stopErr := started.Stop(); stopErr != nil {
+					m.logger.Error("Error stopping shard", "error", stopErr)
+				}
+			}
+			return fmt.Errorf("failed to start shard %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// Stop disconnects every shard, shard 0 last so queued actions can complete
+func (m *ShardManager) Stop() error {
+	for id := len(m.shards) - 1; id >= 0; id-- {
+		if err := m.shards[id].Stop(); err != nil {
+			m.logger.Error("Error stopping shard", "shard", id, "error", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package sharding_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/internal/testutil"
+	"github.com/geekxflood/gxf-discord-bot/pkg/config"
+	"github.com/geekxflood/gxf-discord-bot/pkg/sharding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShardManager(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:      "test-token",
+			Prefix:     "!",
+			ShardID:    2,
+			ShardCount: 3,
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("With", mock.Anything).Return()
+
+	mgr, err := sharding.NewShardManager(context.Background(), cfg, logger)
+	require.NoError(t, err)
+
+	assert.Len(t, mgr.Shards(), 3)
+	assert.Same(t, mgr.Shards()[0], mgr.Primary())
+}
+
+func TestNewShardManager_SingleShard(t *testing.T) {
+	cfg := &config.Config{
+		Bot: config.BotConfig{
+			Token:  "test-token",
+			Prefix: "!",
+		},
+	}
+
+	logger := &testutil.MockLogger{}
+
+	_, err := sharding.NewShardManager(context.Background(), cfg, logger)
+	assert.Error(t, err)
+}
@@ -0,0 +1,216 @@
+// Package stats tracks how often the actions of the bot are used.
+package stats
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+)
+
+// Usage is the usage of an action
+type Usage struct {
+	Action          string    `json:"action"`
+	InvocationCount int64     `json:"invocationCount"`
+	LastUsedAt      time.Time `json:"lastUsedAt"`
+	UniqueUsers     int       `json:"uniqueUsers"`
+}
+
+// actionUsage is the usage of an action with the set of its users
+type actionUsage struct {
+	count      int64
+	lastUsedAt time.Time
+	users      map[string]struct{}
+}
+
+// usageQueueSize is the number of usage writes waiting for the store, more are dropped
+const usageQueueSize = 1024
+
+// usageWrite is a change of the usage waiting to be written to the store
+type usageWrite struct {
+	actionName string
+	userID     string
+	at         time.Time
+	reset      bool
+}
+
+// CommandStats tracks the invocations, last use and unique users of each action.
+// Usage is kept in memory, and persisted in the background when a store is set.
+type CommandStats struct {
+	actions map[string]*actionUsage
+	writes  chan usageWrite
+	done    chan struct{}
+	onError func(error)
+	mu      sync.RWMutex
+}
+
+// New creates empty command statistics
+func New() *CommandStats {
+	return &CommandStats{
+		actions: make(map[string]*actionUsage),
+	}
+}
+
+// SetErrorHandler sets the function receiving the errors of the background store writes
+func (s *CommandStats) SetErrorHandler(fn func(error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onError = fn
+}
+
+// SetStore loads the usage persisted in the store and records the next invocations to it.
+// Close must be called to write the pending invocations before the store is closed.
+func (s *CommandStats) SetStore(store storage.UsageStore) error {
+	entries, err := store.LoadUsage()
+	if err != nil {
+		return fmt.Errorf("failed to load usage: %w", err)
+	}
+
+	s.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.writes = make(chan usageWrite, usageQueueSize)
+	s.done = make(chan struct{})
+	go s.write(store, s.writes, s.done)
+
+	s.actions = make(map[string]*actionUsage)
+	for _, entry := range entries {
+		usage := s.usage(entry.ActionName)
+		usage.count += entry.Count
+		usage.users[entry.UserID] = struct{}{}
+		if entry.LastUsedAt.After(usage.lastUsedAt) {
+			usage.lastUsedAt = entry.LastUsedAt
+		}
+	}
+
+	return nil
+}
+
+// Close writes the pending usage to the store and stops writing to it
+func (s *CommandStats) Close() {
+	s.mu.Lock()
+	writes, done := s.writes, s.done
+	s.writes, s.done = nil, nil
+	s.mu.Unlock()
+
+	if writes != nil {
+		close(writes)
+		<-done
+	}
+}
+
+// Record counts an invocation of the action by the user
+func (s *CommandStats) Record(actionName, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at := time.Now()
+	usage := s.usage(actionName)
+	usage.count++
+	usage.lastUsedAt = at
+	usage.users[userID] = struct{}{}
+
+	return s.enqueue(usageWrite{actionName: actionName, userID: userID, at: at})
+}
+
+// Get returns the usage of an action, false when it was never invoked
+func (s *CommandStats) Get(actionName string) (Usage, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	usage, ok := s.actions[actionName]
+	if !ok {
+		return Usage{}, false
+	}
+	return usage.snapshot(actionName), true
+}
+
+// Top returns the n most invoked actions, all of them when n is 0 or less
+func (s *CommandStats) Top(n int) []Usage {
+	s.mu.RLock()
+	top := make([]Usage, 0, len(s.actions))
+	for name, usage := range s.actions {
+		top = append(top, usage.snapshot(name))
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(top, func(a, b Usage) int {
+		return cmp.Or(cmp.Compare(b.InvocationCount, a.InvocationCount), cmp.Compare(a.Action, b.Action))
+	})
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// Reset deletes the usage of an action
+func (s *CommandStats) Reset(actionName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.actions, actionName)
+	return s.enqueue(usageWrite{actionName: actionName, reset: true})
+}
+
+// enqueue queues a write for the store without blocking, the caller holds the lock
+func (s *CommandStats) enqueue(write usageWrite) error {
+	if s.writes == nil {
+		return nil
+	}
+
+	select {
+	case s.writes <- write:
+		return nil
+	default:
+		return fmt.Errorf("usage write queue is full, usage of %s not saved", write.actionName)
+	}
+}
+
+// write applies the queued writes to the store in order until the queue is closed
+func (s *CommandStats) write(store storage.UsageStore, writes <-chan usageWrite, done chan<- struct{}) {
+	defer close(done)
+
+	for write := range writes {
+		var err error
+		if write.reset {
+			err = store.ResetUsage(write.actionName)
+		} else {
+			err = store.RecordUsage(write.actionName, write.userID, write.at)
+		}
+		if err == nil {
+			continue
+		}
+
+		s.mu.RLock()
+		onError := s.onError
+		s.mu.RUnlock()
+		if onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// usage returns the usage of an action, creating it when missing
+func (s *CommandStats) usage(actionName string) *actionUsage {
+	usage, ok := s.actions[actionName]
+	if !ok {
+		usage = &actionUsage{users: make(map[string]struct{})}
+		s.actions[actionName] = usage
+	}
+	return usage
+}
+
+// snapshot returns the usage of the action
+func (u *actionUsage) snapshot(actionName string) Usage {
+	return Usage{
+		Action:          actionName,
+		InvocationCount: u.count,
+		LastUsedAt:      u.lastUsedAt,
+		UniqueUsers:     len(u.users),
+	}
+}
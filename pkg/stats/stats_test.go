@@ -0,0 +1,73 @@
+package stats_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/stats"
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandStats(t *testing.T) {
+	s := stats.New()
+
+	require.NoError(t, s.Record("ping", "u1"))
+	require.NoError(t, s.Record("ping", "u1"))
+	require.NoError(t, s.Record("ping", "u2"))
+	require.NoError(t, s.Record("help", "u1"))
+	require.NoError(t, s.Record("roll", "u3"))
+
+	usage, ok := s.Get("ping")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), usage.InvocationCount)
+	assert.Equal(t, 2, usage.UniqueUsers)
+	assert.False(t, usage.LastUsedAt.IsZero())
+
+	_, ok = s.Get("missing")
+	assert.False(t, ok)
+
+	top := s.Top(2)
+	require.Len(t, top, 2)
+	assert.Equal(t, "ping", top[0].Action)
+	// Ties are ordered by name
+	assert.Equal(t, "help", top[1].Action)
+	assert.Len(t, s.Top(0), 3)
+
+	require.NoError(t, s.Reset("ping"))
+	_, ok = s.Get("ping")
+	assert.False(t, ok)
+}
+
+func TestCommandStats_Store(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.db")
+	store, err := storage.NewSQLiteStore(path)
+	require.NoError(t, err)
+
+	s := stats.New()
+	require.NoError(t, s.SetStore(store))
+	require.NoError(t, s.Record("ping", "u1"))
+	require.NoError(t, s.Record("ping", "u2"))
+	require.NoError(t, s.Record("ping", "u1"))
+	require.NoError(t, s.Record("help", "u1"))
+	require.NoError(t, s.Reset("help"))
+	s.Close()
+	require.NoError(t, store.Close())
+
+	// Usage survives a restart
+	store, err = storage.NewSQLiteStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	restarted := stats.New()
+	require.NoError(t, restarted.SetStore(store))
+
+	usage, ok := restarted.Get("ping")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), usage.InvocationCount)
+	assert.Equal(t, 2, usage.UniqueUsers)
+
+	_, ok = restarted.Get("help")
+	assert.False(t, ok)
+}
@@ -0,0 +1,61 @@
+// Package sticker resolves guild stickers by name.
+package sticker
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Session defines the Discord session methods used to fetch stickers.
+// Stickers are part of the guild object, discordgo has no sticker endpoint.
+type Session interface {
+	Guild(guildID string, options ...discordgo.RequestOption) (*discordgo.Guild, error)
+}
+
+// Registry holds the stickers of each guild by name
+type Registry struct {
+	// guilds maps a guild ID to a map[string]*discordgo.Sticker of its stickers by name
+	guilds sync.Map
+}
+
+// NewRegistry creates an empty sticker registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Load fetches the stickers of a guild, replacing the ones already known
+func (r *Registry) Load(session Session, guildID string) error {
+	guild, err := session.Guild(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch stickers of guild %s: %w", guildID, err)
+	}
+	r.Set(guildID, guild.Stickers)
+	return nil
+}
+
+// Set replaces the stickers of a guild
+func (r *Registry) Set(guildID string, stickers []*discordgo.Sticker) {
+	byName := make(map[string]*discordgo.Sticker, len(stickers))
+	for _, sticker := range stickers {
+		if sticker != nil && sticker.Name != "" {
+			byName[sticker.Name] = sticker
+		}
+	}
+	r.guilds.Store(guildID, byName)
+}
+
+// Lookup returns the ID of a guild sticker
+func (r *Registry) Lookup(guildID, name string) (string, bool) {
+	value, ok := r.guilds.Load(guildID)
+	if !ok {
+		return "", false
+	}
+
+	sticker, ok := value.(map[string]*discordgo.Sticker)[name]
+	if !ok {
+		return "", false
+	}
+	return sticker.ID, true
+}
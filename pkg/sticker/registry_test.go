@@ -0,0 +1,58 @@
+package sticker_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/geekxflood/gxf-discord-bot/pkg/sticker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSession returns fixed stickers per guild
+type fakeSession map[string][]*discordgo.Sticker
+
+func (s fakeSession) Guild(guildID string, options ...discordgo.RequestOption) (*discordgo.Guild, error) {
+	stickers, ok := s[guildID]
+	if !ok {
+		return nil, errors.New("unknown guild")
+	}
+	return &discordgo.Guild{ID: guildID, Stickers: stickers}, nil
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	session := fakeSession{
+		"guild1": {
+			{ID: "111", Name: "wave"},
+			{ID: "222", Name: "party"},
+		},
+		"guild2": {
+			{ID: "333", Name: "wave"},
+		},
+	}
+
+	registry := sticker.NewRegistry()
+	require.NoError(t, registry.Load(session, "guild1"))
+	require.NoError(t, registry.Load(session, "guild2"))
+	assert.Error(t, registry.Load(session, "guild3"))
+
+	id, ok := registry.Lookup("guild1", "party")
+	assert.True(t, ok)
+	assert.Equal(t, "222", id)
+
+	id, ok = registry.Lookup("guild2", "wave")
+	assert.True(t, ok)
+	assert.Equal(t, "333", id)
+
+	_, ok = registry.Lookup("guild2", "party")
+	assert.False(t, ok)
+
+	_, ok = registry.Lookup("guild3", "wave")
+	assert.False(t, ok)
+
+	// Reloading replaces the known stickers
+	registry.Set("guild1", []*discordgo.Sticker{{ID: "444", Name: "dance"}})
+	_, ok = registry.Lookup("guild1", "wave")
+	assert.False(t, ok)
+}
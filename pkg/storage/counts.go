@@ -0,0 +1,39 @@
+package storage
+
+import "sync"
+
+// ExecutionCounts stores the lifetime number of successful executions of actions
+type ExecutionCounts interface {
+	IncrementExecutionCount(actionName string) (int64, error)
+	ExecutionCount(actionName string) (int64, error)
+}
+
+// MemoryExecutionCounts keeps execution counts in memory, they are lost on restart
+type MemoryExecutionCounts struct {
+	counts map[string]int64
+	mu     sync.Mutex
+}
+
+// NewMemoryExecutionCounts creates an in-memory execution count store
+func NewMemoryExecutionCounts() *MemoryExecutionCounts {
+	return &MemoryExecutionCounts{
+		counts: make(map[string]int64),
+	}
+}
+
+// IncrementExecutionCount adds an execution of the action and returns the new count
+func (c *MemoryExecutionCounts) IncrementExecutionCount(actionName string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counts[actionName]++
+	return c.counts[actionName], nil
+}
+
+// ExecutionCount returns the number of executions of the action
+func (c *MemoryExecutionCounts) ExecutionCount(actionName string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[actionName], nil
+}
@@ -0,0 +1,51 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionCounts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.db")
+	sqliteStore, err := storage.NewSQLiteStore(path)
+	require.NoError(t, err)
+
+	stores := map[string]storage.ExecutionCounts{
+		"memory": storage.NewMemoryExecutionCounts(),
+		"sqlite": sqliteStore,
+	}
+
+	for name, counts := range stores {
+		t.Run(name, func(t *testing.T) {
+			count, err := counts.ExecutionCount("giveaway")
+			require.NoError(t, err)
+			assert.Zero(t, count)
+
+			for want := int64(1); want <= 3; want++ {
+				count, err := counts.IncrementExecutionCount("giveaway")
+				require.NoError(t, err)
+				assert.Equal(t, want, count)
+			}
+			_, err = counts.IncrementExecutionCount("other")
+			require.NoError(t, err)
+
+			count, err = counts.ExecutionCount("giveaway")
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), count)
+		})
+	}
+
+	// Counts survive a restart
+	require.NoError(t, sqliteStore.Close())
+	reopened, err := storage.NewSQLiteStore(path)
+	require.NoError(t, err)
+	defer reopened.Close()
+
+	count, err := reopened.ExecutionCount("giveaway")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"maps"
+	"sync"
+)
+
+// UserPrefs stores per-user preferences such as a preferred language
+type UserPrefs interface {
+	SetPref(userID, key, value string) error
+	GetPref(userID, key string) (string, bool)
+	Prefs(userID string) map[string]string
+}
+
+// MemoryPrefs keeps preferences in memory, they are lost on restart
+type MemoryPrefs struct {
+	prefs map[string]map[string]string
+	mu    sync.RWMutex
+}
+
+// NewMemoryPrefs creates an in-memory preference store
+func NewMemoryPrefs() *MemoryPrefs {
+	return &MemoryPrefs{
+		prefs: make(map[string]map[string]string),
+	}
+}
+
+// SetPref sets a preference of a user
+func (p *MemoryPrefs) SetPref(userID, key, value string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.prefs[userID] == nil {
+		p.prefs[userID] = make(map[string]string)
+	}
+	p.prefs[userID][key] = value
+	return nil
+}
+
+// GetPref returns a preference of a user
+func (p *MemoryPrefs) GetPref(userID, key string) (string, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	value, ok := p.prefs[userID][key]
+	return value, ok
+}
+
+// Prefs returns a copy of all preferences of a user
+func (p *MemoryPrefs) Prefs(userID string) map[string]string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return maps.Clone(p.prefs[userID])
+}
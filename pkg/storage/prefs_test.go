@@ -0,0 +1,40 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserPrefs(t *testing.T) {
+	sqliteStore, err := storage.NewSQLiteStore(filepath.Join(t.TempDir(), "bot.db"))
+	require.NoError(t, err)
+	defer sqliteStore.Close()
+
+	stores := map[string]storage.UserPrefs{
+		"memory": storage.NewMemoryPrefs(),
+		"sqlite": sqliteStore,
+	}
+
+	for name, prefs := range stores {
+		t.Run(name, func(t *testing.T) {
+			_, ok := prefs.GetPref("u1", "language")
+			assert.False(t, ok)
+
+			require.NoError(t, prefs.SetPref("u1", "language", "en"))
+			require.NoError(t, prefs.SetPref("u1", "language", "fr"))
+			require.NoError(t, prefs.SetPref("u1", "timezone", "Europe/Paris"))
+			require.NoError(t, prefs.SetPref("u2", "language", "de"))
+
+			value, ok := prefs.GetPref("u1", "language")
+			assert.True(t, ok)
+			assert.Equal(t, "fr", value)
+
+			assert.Equal(t, map[string]string{"language": "fr", "timezone": "Europe/Paris"}, prefs.Prefs("u1"))
+			assert.Empty(t, prefs.Prefs("u3"))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Reminder is a message sent to a user once FireAt is reached
+type Reminder struct {
+	ID      int64
+	UserID  string
+	Message string
+	FireAt  time.Time
+}
+
+// Reminders stores the pending reminders of users
+type Reminders interface {
+	// AddReminder stores a reminder and returns its ID
+	AddReminder(reminder Reminder) (int64, error)
+	// PendingReminders returns the reminders of a user, the soonest first
+	PendingReminders(userID string) ([]Reminder, error)
+	// DueReminders returns the reminders of every user due at now
+	DueReminders(now time.Time) ([]Reminder, error)
+	// DeleteReminder deletes a reminder of a user and reports whether it existed
+	DeleteReminder(userID string, id int64) (bool, error)
+}
+
+// MemoryReminders keeps reminders in memory, they are lost on restart
+type MemoryReminders struct {
+	reminders map[int64]Reminder
+	nextID    int64
+	mu        sync.Mutex
+}
+
+// NewMemoryReminders creates an in-memory reminder store
+func NewMemoryReminders() *MemoryReminders {
+	return &MemoryReminders{
+		reminders: make(map[int64]Reminder),
+		nextID:    1,
+	}
+}
+
+// AddReminder stores a reminder and returns its ID
+func (r *MemoryReminders) AddReminder(reminder Reminder) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reminder.ID = r.nextID
+	r.nextID++
+	r.reminders[reminder.ID] = reminder
+	return reminder.ID, nil
+}
+
+// PendingReminders returns the reminders of a user, the soonest first
+func (r *MemoryReminders) PendingReminders(userID string) ([]Reminder, error) {
+	return r.filter(func(reminder Reminder) bool {
+		return reminder.UserID == userID
+	}), nil
+}
+
+// DueReminders returns the reminders of every user due at now
+func (r *MemoryReminders) DueReminders(now time.Time) ([]Reminder, error) {
+	return r.filter(func(reminder Reminder) bool {
+		return !reminder.FireAt.After(now)
+	}), nil
+}
+
+// DeleteReminder deletes a reminder of a user and reports whether it existed
+func (r *MemoryReminders) DeleteReminder(userID string, id int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reminder, ok := r.reminders[id]
+	if !ok || reminder.UserID != userID {
+		return false, nil
+	}
+	delete(r.reminders, id)
+	return true, nil
+}
+
+// filter returns the reminders matching keep, the soonest first
+func (r *MemoryReminders) filter(keep func(Reminder) bool) []Reminder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var reminders []Reminder
+	for _, reminder := range r.reminders {
+		if keep(reminder) {
+			reminders = append(reminders, reminder)
+		}
+	}
+
+	slices.SortFunc(reminders, func(a, b Reminder) int {
+		return cmp.Or(a.FireAt.Compare(b.FireAt), cmp.Compare(a.ID, b.ID))
+	})
+	return reminders
+}
@@ -0,0 +1,62 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminders(t *testing.T) {
+	sqliteStore, err := storage.NewSQLiteStore(filepath.Join(t.TempDir(), "bot.db"))
+	require.NoError(t, err)
+	defer sqliteStore.Close()
+
+	stores := map[string]storage.Reminders{
+		"memory": storage.NewMemoryReminders(),
+		"sqlite": sqliteStore,
+	}
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for name, reminders := range stores {
+		t.Run(name, func(t *testing.T) {
+			laundry, err := reminders.AddReminder(storage.Reminder{UserID: "u1", Message: "do laundry", FireAt: now.Add(2 * time.Hour)})
+			require.NoError(t, err)
+			tea, err := reminders.AddReminder(storage.Reminder{UserID: "u1", Message: "tea", FireAt: now.Add(-time.Minute)})
+			require.NoError(t, err)
+			_, err = reminders.AddReminder(storage.Reminder{UserID: "u2", Message: "call", FireAt: now})
+			require.NoError(t, err)
+			assert.NotEqual(t, laundry, tea)
+
+			pending, err := reminders.PendingReminders("u1")
+			require.NoError(t, err)
+			require.Len(t, pending, 2)
+			assert.Equal(t, "tea", pending[0].Message)
+			assert.Equal(t, laundry, pending[1].ID)
+			assert.True(t, now.Add(2*time.Hour).Equal(pending[1].FireAt))
+
+			due, err := reminders.DueReminders(now)
+			require.NoError(t, err)
+			require.Len(t, due, 2)
+			assert.Equal(t, "tea", due[0].Message)
+			assert.Equal(t, "call", due[1].Message)
+
+			// Reminders can only be deleted by their user
+			deleted, err := reminders.DeleteReminder("u2", laundry)
+			require.NoError(t, err)
+			assert.False(t, deleted)
+
+			deleted, err = reminders.DeleteReminder("u1", laundry)
+			require.NoError(t, err)
+			assert.True(t, deleted)
+
+			pending, err = reminders.PendingReminders("u1")
+			require.NoError(t, err)
+			require.Len(t, pending, 1)
+			assert.Equal(t, tea, pending[0].ID)
+		})
+	}
+}
@@ -0,0 +1,577 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	// Registers the cgo-free "sqlite" driver
+	_ "modernc.org/sqlite"
+)
+
+// schema creates the executions table and its indexes
+const schema = `
+CREATE TABLE IF NOT EXISTS executions (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp     INTEGER NOT NULL,
+	action_name   TEXT NOT NULL,
+	user_id       TEXT NOT NULL,
+	guild_id      TEXT NOT NULL,
+	channel_id    TEXT NOT NULL,
+	response_type TEXT NOT NULL,
+	duration      INTEGER NOT NULL,
+	error         TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS executions_action ON executions (action_name, timestamp);
+CREATE INDEX IF NOT EXISTS executions_user ON executions (user_id, timestamp);
+CREATE TABLE IF NOT EXISTS prefs (
+	user_id TEXT NOT NULL,
+	key     TEXT NOT NULL,
+	value   TEXT NOT NULL,
+	PRIMARY KEY (user_id, key)
+);
+CREATE TABLE IF NOT EXISTS reminders (
+	id      INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id TEXT NOT NULL,
+	fire_at INTEGER NOT NULL,
+	message TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS reminders_user ON reminders (user_id, fire_at);
+CREATE INDEX IF NOT EXISTS reminders_due ON reminders (fire_at);
+CREATE TABLE IF NOT EXISTS execution_counts (
+	action_name TEXT PRIMARY KEY,
+	count       INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS usage (
+	action_name  TEXT NOT NULL,
+	user_id      TEXT NOT NULL,
+	count        INTEGER NOT NULL,
+	last_used_at INTEGER NOT NULL,
+	PRIMARY KEY (action_name, user_id)
+);
+`
+
+// Executions are written in batches in the background, the queue holds the executions waiting for a batch
+const (
+	executionQueueSize     = 4096
+	executionBatchSize     = 256
+	executionFlushInterval = time.Second
+	executionPruneInterval = time.Hour
+)
+
+// errExecutionQueueFull is returned when executions are saved faster than they are written
+var errExecutionQueueFull = errors.New("execution queue is full")
+
+// SQLiteStore persists executions to a SQLite database
+type SQLiteStore struct {
+	db *sql.DB
+
+	// mu guards closing the queue, settingsMu the retention and error handler used by the writer
+	executions chan ActionExecution
+	flushes    chan chan struct{}
+	done       chan struct{}
+	closed     bool
+	mu         sync.RWMutex
+	retention  time.Duration
+	onError    func(error)
+	settingsMu sync.Mutex
+}
+
+// NewSQLiteStore opens the database at path, creating it if needed
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store %s: %w", path, err)
+	}
+
+	// SQLite allows a single writer, serializing access avoids busy errors
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create store schema: %w", err)
+	}
+
+	s := &SQLiteStore{
+		db:         db,
+		executions: make(chan ActionExecution, executionQueueSize),
+		flushes:    make(chan chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go s.writeExecutions()
+
+	return s, nil
+}
+
+// SetErrorHandler sets the function receiving the errors of the background execution writes
+func (s *SQLiteStore) SetErrorHandler(fn func(error)) {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	s.onError = fn
+}
+
+// SetRetention deletes the executions older than retention now and every hour, 0 keeps them all
+func (s *SQLiteStore) SetRetention(retention time.Duration) error {
+	s.settingsMu.Lock()
+	s.retention = retention
+	s.settingsMu.Unlock()
+
+	return s.pruneExecutions()
+}
+
+// SaveExecution queues an execution, it is written with the next batch
+func (s *SQLiteStore) SaveExecution(entry ActionExecution) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return fmt.Errorf("failed to save execution: store closed")
+	}
+	select {
+	case s.executions <- entry:
+		return nil
+	default:
+		return fmt.Errorf("failed to save execution: %w", errExecutionQueueFull)
+	}
+}
+
+// writeExecutions writes the queued executions in batches until the queue is closed,
+// and deletes the executions past the retention
+func (s *SQLiteStore) writeExecutions() {
+	defer close(s.done)
+
+	flushTicker := time.NewTicker(executionFlushInterval)
+	defer flushTicker.Stop()
+	pruneTicker := time.NewTicker(executionPruneInterval)
+	defer pruneTicker.Stop()
+
+	batch := make([]ActionExecution, 0, executionBatchSize)
+	write := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.insertExecutions(batch); err != nil {
+			s.reportError(err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case entry, ok := <-s.executions:
+			if !ok {
+				write()
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) >= executionBatchSize {
+				write()
+			}
+		case reply := <-s.flushes:
+			for range len(s.executions) {
+				batch = append(batch, <-s.executions)
+			}
+			write()
+			close(reply)
+		case <-flushTicker.C:
+			write()
+		case <-pruneTicker.C:
+			if err := s.pruneExecutions(); err != nil {
+				s.reportError(err)
+			}
+		}
+	}
+}
+
+// flushExecutions waits until the queued executions are written
+func (s *SQLiteStore) flushExecutions() {
+	s.mu.RLock()
+	if s.closed {
+		s.mu.RUnlock()
+		return
+	}
+	reply := make(chan struct{})
+	s.flushes <- reply
+	s.mu.RUnlock()
+
+	<-reply
+}
+
+// insertExecutions writes a batch of executions in a single transaction
+func (s *SQLiteStore) insertExecutions(batch []ActionExecution) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to save executions: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare(`INSERT INTO executions (timestamp, action_name, user_id, guild_id, channel_id, response_type, duration, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to save executions: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, entry := range batch {
+		if _, err := stmt.Exec(entry.Timestamp.UnixNano(), entry.ActionName, entry.UserID, entry.GuildID,
+			entry.ChannelID, entry.ResponseType, int64(entry.Duration), entry.Error); err != nil {
+			return fmt.Errorf("failed to save execution: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save executions: %w", err)
+	}
+	return nil
+}
+
+// pruneExecutions deletes the executions older than the retention
+func (s *SQLiteStore) pruneExecutions() error {
+	s.settingsMu.Lock()
+	retention := s.retention
+	s.settingsMu.Unlock()
+
+	if retention <= 0 {
+		return nil
+	}
+	before := time.Now().Add(-retention).UnixNano()
+	if _, err := s.db.Exec("DELETE FROM executions WHERE timestamp < ?", before); err != nil {
+		return fmt.Errorf("failed to delete old executions: %w", err)
+	}
+	return nil
+}
+
+// reportError passes an error of a background write to the error handler
+func (s *SQLiteStore) reportError(err error) {
+	s.settingsMu.Lock()
+	onError := s.onError
+	s.settingsMu.Unlock()
+
+	if onError != nil {
+		onError(err)
+	}
+}
+
+// executionConditions returns the WHERE clause selecting the executions of the filter, with its arguments
+func executionConditions(filter ExecutionFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.ActionName != "" {
+		conditions = append(conditions, "action_name = ?")
+		args = append(args, filter.ActionName)
+	}
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, filter.Until.UnixNano())
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// QueryExecutions returns the executions matching the filter, most recent first
+func (s *SQLiteStore) QueryExecutions(filter ExecutionFilter) ([]ActionExecution, error) {
+	s.flushExecutions()
+
+	where, args := executionConditions(filter)
+	query := "SELECT timestamp, action_name, user_id, guild_id, channel_id, response_type, duration, error FROM executions" + where
+	query += " ORDER BY timestamp DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query executions: %w", err)
+	}
+	defer rows.Close()
+
+	var executions []ActionExecution
+	for rows.Next() {
+		var execution ActionExecution
+		var timestamp, duration int64
+		if err := rows.Scan(&timestamp, &execution.ActionName, &execution.UserID, &execution.GuildID,
+			&execution.ChannelID, &execution.ResponseType, &duration, &execution.Error); err != nil {
+			return nil, fmt.Errorf("failed to read execution: %w", err)
+		}
+		execution.Timestamp = time.Unix(0, timestamp)
+		execution.Duration = time.Duration(duration)
+		executions = append(executions, execution)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read executions: %w", err)
+	}
+
+	return executions, nil
+}
+
+// SummarizeExecutions returns the statistics of each action with executions matching the filter, sorted by name
+func (s *SQLiteStore) SummarizeExecutions(filter ExecutionFilter) ([]ActionStats, error) {
+	s.flushExecutions()
+
+	where, args := executionConditions(filter)
+	rows, err := s.db.Query(`SELECT action_name, COUNT(*), SUM(error != ''), CAST(AVG(duration) AS INTEGER)
+		FROM executions`+where+` GROUP BY action_name ORDER BY action_name`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize executions: %w", err)
+	}
+	defer rows.Close()
+
+	var summary []ActionStats
+	for rows.Next() {
+		var stats ActionStats
+		var duration int64
+		if err := rows.Scan(&stats.ActionName, &stats.Executions, &stats.Failures, &duration); err != nil {
+			return nil, fmt.Errorf("failed to read action stats: %w", err)
+		}
+		stats.AverageDuration = time.Duration(duration)
+		summary = append(summary, stats)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read action stats: %w", err)
+	}
+
+	return summary, nil
+}
+
+// TopUsers returns the n users with the most executions matching the filter
+func (s *SQLiteStore) TopUsers(filter ExecutionFilter, n int) ([]UserCount, error) {
+	s.flushExecutions()
+
+	where, args := executionConditions(filter)
+	if where == "" {
+		where = " WHERE user_id != ''"
+	} else {
+		where += " AND user_id != ''"
+	}
+	rows, err := s.db.Query(`SELECT user_id, COUNT(*) AS executions FROM executions`+where+
+		` GROUP BY user_id ORDER BY executions DESC, user_id LIMIT ?`, append(args, n)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user executions: %w", err)
+	}
+	defer rows.Close()
+
+	var users []UserCount
+	for rows.Next() {
+		var user UserCount
+		if err := rows.Scan(&user.UserID, &user.Executions); err != nil {
+			return nil, fmt.Errorf("failed to read user executions: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read user executions: %w", err)
+	}
+
+	return users, nil
+}
+
+// SetPref sets a preference of a user
+func (s *SQLiteStore) SetPref(userID, key, value string) error {
+	_, err := s.db.Exec(
+		`INSERT INTO prefs (user_id, key, value) VALUES (?, ?, ?)
+		ON CONFLICT (user_id, key) DO UPDATE SET value = excluded.value`,
+		userID, key, value,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save preference: %w", err)
+	}
+	return nil
+}
+
+// GetPref returns a preference of a user, a failed lookup is reported as unset
+func (s *SQLiteStore) GetPref(userID, key string) (string, bool) {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM prefs WHERE user_id = ? AND key = ?", userID, key).Scan(&value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// Prefs returns all preferences of a user
+func (s *SQLiteStore) Prefs(userID string) map[string]string {
+	rows, err := s.db.Query("SELECT key, value FROM prefs WHERE user_id = ?", userID)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	prefs := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil
+		}
+		prefs[key] = value
+	}
+	return prefs
+}
+
+// AddReminder stores a reminder and returns its ID
+func (s *SQLiteStore) AddReminder(reminder Reminder) (int64, error) {
+	result, err := s.db.Exec(
+		"INSERT INTO reminders (user_id, fire_at, message) VALUES (?, ?, ?)",
+		reminder.UserID, reminder.FireAt.UnixNano(), reminder.Message,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save reminder: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read reminder ID: %w", err)
+	}
+	return id, nil
+}
+
+// PendingReminders returns the reminders of a user, the soonest first
+func (s *SQLiteStore) PendingReminders(userID string) ([]Reminder, error) {
+	return s.queryReminders("WHERE user_id = ?", userID)
+}
+
+// DueReminders returns the reminders of every user due at now
+func (s *SQLiteStore) DueReminders(now time.Time) ([]Reminder, error) {
+	return s.queryReminders("WHERE fire_at <= ?", now.UnixNano())
+}
+
+// DeleteReminder deletes a reminder of a user and reports whether it existed
+func (s *SQLiteStore) DeleteReminder(userID string, id int64) (bool, error) {
+	result, err := s.db.Exec("DELETE FROM reminders WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete reminder: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete reminder: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+// queryReminders returns the reminders matching the where clause, the soonest first
+func (s *SQLiteStore) queryReminders(where string, args ...interface{}) ([]Reminder, error) {
+	rows, err := s.db.Query("SELECT id, user_id, fire_at, message FROM reminders "+where+" ORDER BY fire_at, id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []Reminder
+	for rows.Next() {
+		var reminder Reminder
+		var fireAt int64
+		if err := rows.Scan(&reminder.ID, &reminder.UserID, &fireAt, &reminder.Message); err != nil {
+			return nil, fmt.Errorf("failed to read reminder: %w", err)
+		}
+		reminder.FireAt = time.Unix(0, fireAt)
+		reminders = append(reminders, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// IncrementExecutionCount adds an execution of the action and returns the new count
+func (s *SQLiteStore) IncrementExecutionCount(actionName string) (int64, error) {
+	var count int64
+	err := s.db.QueryRow(
+		`INSERT INTO execution_counts (action_name, count) VALUES (?, 1)
+		ON CONFLICT (action_name) DO UPDATE SET count = count + 1
+		RETURNING count`,
+		actionName,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save execution count: %w", err)
+	}
+	return count, nil
+}
+
+// ExecutionCount returns the number of executions of the action
+func (s *SQLiteStore) ExecutionCount(actionName string) (int64, error) {
+	var count int64
+	err := s.db.QueryRow("SELECT count FROM execution_counts WHERE action_name = ?", actionName).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read execution count: %w", err)
+	}
+	return count, nil
+}
+
+// RecordUsage counts an invocation of the action by the user
+func (s *SQLiteStore) RecordUsage(actionName, userID string, at time.Time) error {
+	_, err := s.db.Exec(
+		`INSERT INTO usage (action_name, user_id, count, last_used_at) VALUES (?, ?, 1, ?)
+		ON CONFLICT (action_name, user_id) DO UPDATE SET count = count + 1, last_used_at = excluded.last_used_at`,
+		actionName, userID, at.UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save usage: %w", err)
+	}
+	return nil
+}
+
+// LoadUsage returns the invocations of every action per user
+func (s *SQLiteStore) LoadUsage() ([]UserUsage, error) {
+	rows, err := s.db.Query("SELECT action_name, user_id, count, last_used_at FROM usage ORDER BY action_name, user_id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []UserUsage
+	for rows.Next() {
+		var entry UserUsage
+		var lastUsedAt int64
+		if err := rows.Scan(&entry.ActionName, &entry.UserID, &entry.Count, &lastUsedAt); err != nil {
+			return nil, fmt.Errorf("failed to read usage: %w", err)
+		}
+		entry.LastUsedAt = time.Unix(0, lastUsedAt)
+		usage = append(usage, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+// ResetUsage deletes the invocations of the action
+func (s *SQLiteStore) ResetUsage(actionName string) error {
+	if _, err := s.db.Exec("DELETE FROM usage WHERE action_name = ?", actionName); err != nil {
+		return fmt.Errorf("failed to reset usage: %w", err)
+	}
+	return nil
+}
+
+// Close writes the queued executions and closes the database
+func (s *SQLiteStore) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.executions)
+	}
+	s.mu.Unlock()
+
+	<-s.done
+	return s.db.Close()
+}
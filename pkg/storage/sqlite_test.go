@@ -0,0 +1,114 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStore_SaveAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.db")
+	store, err := storage.NewSQLiteStore(path)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	executions := []storage.ActionExecution{
+		{Timestamp: start, ActionName: "ping", UserID: "u1", ChannelID: "c1", ResponseType: "text", Duration: 10 * time.Millisecond},
+		{Timestamp: start.Add(time.Minute), ActionName: "ping", UserID: "u2", ChannelID: "c1", ResponseType: "text", Duration: 30 * time.Millisecond, Error: "boom"},
+		{Timestamp: start.Add(2 * time.Minute), ActionName: "help", UserID: "u1", GuildID: "g1", ChannelID: "c2", ResponseType: "embed", Duration: 5 * time.Millisecond},
+	}
+	for _, execution := range executions {
+		require.NoError(t, store.SaveExecution(execution))
+	}
+	require.NoError(t, store.Close())
+
+	// Executions survive reopening the database
+	store, err = storage.NewSQLiteStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	all, err := store.QueryExecutions(storage.ExecutionFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "help", all[0].ActionName)
+	assert.True(t, executions[2].Timestamp.Equal(all[0].Timestamp))
+	assert.Equal(t, "g1", all[0].GuildID)
+	assert.Equal(t, 5*time.Millisecond, all[0].Duration)
+
+	pings, err := store.QueryExecutions(storage.ExecutionFilter{ActionName: "ping"})
+	require.NoError(t, err)
+	require.Len(t, pings, 2)
+	assert.Equal(t, "boom", pings[0].Error)
+
+	recent, err := store.QueryExecutions(storage.ExecutionFilter{UserID: "u1", Since: start.Add(time.Second)})
+	require.NoError(t, err)
+	require.Len(t, recent, 1)
+	assert.Equal(t, "help", recent[0].ActionName)
+
+	limited, err := store.QueryExecutions(storage.ExecutionFilter{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+}
+
+func TestSQLiteStore_SummarizeExecutions(t *testing.T) {
+	store, err := storage.NewSQLiteStore(filepath.Join(t.TempDir(), "bot.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Now()
+	for _, execution := range []storage.ActionExecution{
+		{Timestamp: now, ActionName: "ping", UserID: "u1", Duration: 10 * time.Millisecond},
+		{Timestamp: now, ActionName: "ping", UserID: "u2", Duration: 30 * time.Millisecond, Error: "boom"},
+		{Timestamp: now, ActionName: "help", UserID: "u1", Duration: 5 * time.Millisecond},
+		{Timestamp: now.Add(-time.Hour), ActionName: "help", Duration: 5 * time.Millisecond},
+	} {
+		require.NoError(t, store.SaveExecution(execution))
+	}
+
+	stats, err := store.SummarizeExecutions(storage.ExecutionFilter{Since: now.Add(-time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, storage.ActionStats{ActionName: "help", Executions: 1, AverageDuration: 5 * time.Millisecond}, stats[0])
+	assert.Equal(t, storage.ActionStats{ActionName: "ping", Executions: 2, Failures: 1, AverageDuration: 20 * time.Millisecond}, stats[1])
+	assert.InDelta(t, 0.5, stats[1].SuccessRate(), 0.001)
+
+	users, err := store.TopUsers(storage.ExecutionFilter{}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.UserCount{{UserID: "u1", Executions: 2}}, users)
+
+	users, err = store.TopUsers(storage.ExecutionFilter{ActionName: "ping"}, 5)
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+}
+
+func TestSQLiteStore_Retention(t *testing.T) {
+	store, err := storage.NewSQLiteStore(filepath.Join(t.TempDir(), "bot.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Now()
+	require.NoError(t, store.SaveExecution(storage.ActionExecution{Timestamp: now.Add(-48 * time.Hour), ActionName: "old"}))
+	require.NoError(t, store.SaveExecution(storage.ActionExecution{Timestamp: now, ActionName: "new"}))
+
+	// Queued executions are written before the old ones are deleted
+	_, err = store.QueryExecutions(storage.ExecutionFilter{})
+	require.NoError(t, err)
+	require.NoError(t, store.SetRetention(24*time.Hour))
+
+	executions, err := store.QueryExecutions(storage.ExecutionFilter{})
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.Equal(t, "new", executions[0].ActionName)
+}
+
+func TestSQLiteStore_SaveAfterClose(t *testing.T) {
+	store, err := storage.NewSQLiteStore(filepath.Join(t.TempDir(), "bot.db"))
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	assert.Error(t, store.SaveExecution(storage.ActionExecution{ActionName: "ping"}))
+}
@@ -0,0 +1,66 @@
+// Package storage persists the history of action executions.
+package storage
+
+import "time"
+
+// ActionExecution records a single action execution
+type ActionExecution struct {
+	Timestamp    time.Time
+	ActionName   string
+	UserID       string
+	GuildID      string
+	ChannelID    string
+	ResponseType string
+	Duration     time.Duration
+	Error        string
+}
+
+// ExecutionFilter selects executions, zero fields match everything
+type ExecutionFilter struct {
+	ActionName string
+	UserID     string
+	Since      time.Time
+	Until      time.Time
+	Limit      int
+}
+
+// Store persists action executions
+type Store interface {
+	SaveExecution(entry ActionExecution) error
+	QueryExecutions(filter ExecutionFilter) ([]ActionExecution, error)
+	Close() error
+}
+
+// NoopStore discards all executions
+type NoopStore struct{}
+
+// SaveExecution discards the execution
+func (NoopStore) SaveExecution(entry ActionExecution) error { return nil }
+
+// QueryExecutions returns no executions
+func (NoopStore) QueryExecutions(filter ExecutionFilter) ([]ActionExecution, error) { return nil, nil }
+
+// Close does nothing
+func (NoopStore) Close() error { return nil }
+
+// ActionStats summarizes the executions of an action
+type ActionStats struct {
+	ActionName      string
+	Executions      int
+	Failures        int
+	AverageDuration time.Duration
+}
+
+// SuccessRate returns the fraction of executions that succeeded
+func (s ActionStats) SuccessRate() float64 {
+	if s.Executions == 0 {
+		return 0
+	}
+	return float64(s.Executions-s.Failures) / float64(s.Executions)
+}
+
+// UserCount is the number of executions triggered by a user
+type UserCount struct {
+	UserID     string
+	Executions int
+}
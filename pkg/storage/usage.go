@@ -0,0 +1,18 @@
+package storage
+
+import "time"
+
+// UserUsage is the number of times a user invoked an action
+type UserUsage struct {
+	ActionName string
+	UserID     string
+	Count      int64
+	LastUsedAt time.Time
+}
+
+// UsageStore persists the invocations of actions per user
+type UsageStore interface {
+	RecordUsage(actionName, userID string, at time.Time) error
+	LoadUsage() ([]UserUsage, error)
+	ResetUsage(actionName string) error
+}
@@ -0,0 +1,37 @@
+// Package version provides build metadata for the Discord bot.
+package version
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Build metadata, populated at build time via -ldflags "-X"
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = "unknown"
+)
+
+// Info contains version and build information
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get returns the build information of the running binary
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// String returns a human-readable version string
+func (i Info) String() string {
+	return fmt.Sprintf("gxf-discord-bot %s (commit: %s, built: %s, %s)", i.Version, i.Commit, i.BuildDate, i.GoVersion)
+}
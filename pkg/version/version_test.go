@@ -0,0 +1,29 @@
+package version_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/geekxflood/gxf-discord-bot/pkg/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	info := version.Get()
+
+	assert.Equal(t, version.Version, info.Version)
+	assert.Equal(t, version.Commit, info.Commit)
+	assert.Equal(t, version.BuildDate, info.BuildDate)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+}
+
+func TestInfo_String(t *testing.T) {
+	info := version.Info{
+		Version:   "v1.2.3",
+		Commit:    "abc1234",
+		BuildDate: "2025-01-01T00:00:00Z",
+		GoVersion: "go1.25.0",
+	}
+
+	assert.Equal(t, "gxf-discord-bot v1.2.3 (commit: abc1234, built: 2025-01-01T00:00:00Z, go1.25.0)", info.String())
+}